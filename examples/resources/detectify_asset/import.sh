@@ -0,0 +1,2 @@
+# Assets can be imported by their token.
+terraform import detectify_asset.example 5bd9a6d8cbf4b9a1e2f4f5b2b5b1f2a3
@@ -0,0 +1,23 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+)
+
+// Account is the Detectify account of the API key, as represented by the Detectify API.
+type Account struct {
+	Name string `json:"name"`
+	// PlanTier is the subscription plan of the account, such as starter or professional.
+	PlanTier string `json:"plan_tier"`
+}
+
+// GetAccount returns the account of the API key.
+func (c *Client) GetAccount(ctx context.Context) (*Account, error) {
+	var account Account
+	if err := c.do(ctx, http.MethodGet, "/v2/account/", nil, &account); err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
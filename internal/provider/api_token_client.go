@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+)
+
+// APIToken is a scoped API token as represented by the Detectify API.
+type APIToken struct {
+	Token  string   `json:"token"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// Secret is only returned when the token is created.
+	Secret string `json:"secret,omitempty"`
+}
+
+// APITokenRequest is the request body used when creating an API token.
+type APITokenRequest struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// GetAPIToken returns the API token identified by token, without its secret.
+func (c *Client) GetAPIToken(ctx context.Context, token string) (*APIToken, error) {
+	var apiToken APIToken
+	if err := c.do(ctx, http.MethodGet, apiPath("keys", token), nil, &apiToken); err != nil {
+		return nil, err
+	}
+
+	return &apiToken, nil
+}
+
+// CreateAPIToken creates a new API token, returning it along with its secret.
+func (c *Client) CreateAPIToken(ctx context.Context, body APITokenRequest) (*APIToken, error) {
+	var apiToken APIToken
+	if err := c.do(ctx, http.MethodPost, "/v2/keys/", body, &apiToken); err != nil {
+		return nil, err
+	}
+
+	return &apiToken, nil
+}
+
+// RevokeAPIToken revokes the API token identified by token.
+func (c *Client) RevokeAPIToken(ctx context.Context, token string) error {
+	return c.do(ctx, http.MethodDelete, apiPath("keys", token), nil, nil)
+}
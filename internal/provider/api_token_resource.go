@@ -0,0 +1,200 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &APITokenResource{}
+
+func NewAPITokenResource() resource.Resource {
+	return &APITokenResource{}
+}
+
+// APITokenResource defines the resource implementation.
+type APITokenResource struct {
+	client *Client
+}
+
+// APITokenResourceModel describes the resource data model.
+type APITokenResourceModel struct {
+	Token  types.String `tfsdk:"token"`
+	Name   types.String `tfsdk:"name"`
+	Scopes types.Set    `tfsdk:"scopes"`
+	Secret types.String `tfsdk:"secret"`
+}
+
+func (r *APITokenResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_api_token"
+}
+
+func (r *APITokenResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a scoped API token. Tokens cannot be changed, so changing any attribute replaces the token. " +
+			"To rotate a token, replace it with `terraform apply -replace`, or tie it to another resource with `replace_triggered_by`. " +
+			"Tokens cannot be imported, since the secret is only available when the token is created.",
+
+		Attributes: map[string]schema.Attribute{
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the API token.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the API token.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scopes": schema.SetAttribute{
+				MarkdownDescription: "The scopes granted to the API token.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.RequiresReplace(),
+				},
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "The secret of the API token. It is only returned by the API when the token is created, " +
+					"and kept in the state from then on.",
+				Computed:  true,
+				Sensitive: true,
+				// The secret cannot be read back, so it must never be planned as unknown once set.
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *APITokenResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *APITokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data APITokenResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body := APITokenRequest{
+		Name:   data.Name.ValueString(),
+		Scopes: []string{},
+	}
+	resp.Diagnostics.Append(data.Scopes.ElementsAs(ctx, &body.Scopes, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiToken, err := r.client.CreateAPIToken(ctx, body)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create API token, got error: %s", err))
+		return
+	}
+
+	data.Token = types.StringValue(apiToken.Token)
+	data.Secret = types.StringValue(apiToken.Secret)
+
+	tflog.Trace(ctx, "created an API token", map[string]any{"token": apiToken.Token})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APITokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data APITokenResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	apiToken, err := r.client.GetAPIToken(ctx, data.Token.ValueString())
+	if IsNotFound(err) {
+		tflog.Warn(ctx, "API token not found, removing from state", map[string]any{"token": data.Token.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read API token, got error: %s", err))
+		return
+	}
+
+	// The secret is not returned, so the one in state is kept.
+	data.Name = types.StringValue(apiToken.Name)
+	data.Scopes = stringSetValue(ctx, data.Scopes, apiToken.Scopes, &resp.Diagnostics)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *APITokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All configurable attributes require replacement, so there is nothing to update.
+	resp.Diagnostics.AddError(
+		"Unexpected Update",
+		"API tokens cannot be updated in place. Please report this issue to the provider developers.",
+	)
+}
+
+func (r *APITokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data APITokenResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.RevokeAPIToken(ctx, data.Token.ValueString())
+	if err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to revoke API token, got error: %s", err))
+		return
+	}
+}
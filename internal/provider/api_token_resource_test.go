@@ -0,0 +1,92 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccAPITokenResource(t *testing.T) {
+	api := newFakeAPI(t)
+
+	// checkRevoked verifies that no API tokens remain.
+	checkRevoked := func(*terraform.State) error {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		for token := range api.tokens {
+			return fmt.Errorf("API token %q was not revoked", token)
+		}
+
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             checkRevoked,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_api_token" "test" {
+  name   = "ci"
+  scopes = ["assets:read"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_api_token.test", "token", "key0001"),
+					resource.TestCheckResourceAttr("detectify_api_token.test", "secret", "secret0001"),
+					resource.TestCheckResourceAttr("detectify_api_token.test", "scopes.#", "1"),
+				),
+			},
+			{
+				// The secret is kept in state, although it is not returned when reading.
+				Config: api.providerConfig() + `
+resource "detectify_api_token" "test" {
+  name   = "ci"
+  scopes = ["assets:read"]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_api_token.test", "secret", "secret0001"),
+				),
+			},
+			{
+				// Changing the scopes rotates the token, revoking the old one.
+				Config: api.providerConfig() + `
+resource "detectify_api_token" "test" {
+  name   = "ci"
+  scopes = ["assets:read", "assets:write"]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_api_token.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_api_token.test", "token", "key0002"),
+					resource.TestCheckResourceAttr("detectify_api_token.test", "secret", "secret0002"),
+					func(*terraform.State) error {
+						api.mu.Lock()
+						defer api.mu.Unlock()
+
+						if _, ok := api.tokens["key0001"]; ok {
+							return fmt.Errorf("replaced API token was not revoked")
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
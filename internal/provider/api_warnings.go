@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// withAPIWarnings returns a context collecting the warnings of the API responses, along with the collected warnings.
+func withAPIWarnings(ctx context.Context) (context.Context, *APIWarnings) {
+	warnings := &APIWarnings{}
+	return WithAPIWarnings(ctx, warnings), warnings
+}
+
+// apiWarningDiagnostics returns a warning diagnostic for each collected warning, on the attribute that fields
+// maps its API field to. Warnings about the whole response, or a field without an attribute, are not on an attribute.
+func apiWarningDiagnostics(warnings *APIWarnings, fields map[string]path.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	for _, warning := range warnings.Warnings() {
+		if warning.Field == "" {
+			diags.AddWarning("Detectify API Warning", warning.Message)
+			continue
+		}
+
+		detail := fmt.Sprintf("The Detectify API returned a warning about the field %q: %s", warning.Field, warning.Message)
+		if p, ok := fields[warning.Field]; ok {
+			diags.AddAttributeWarning(p, "Detectify API Warning", detail)
+		} else {
+			diags.AddWarning("Detectify API Warning", detail)
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,384 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Asset is a domain asset as represented by the Detectify API.
+type Asset struct {
+	Token      string      `json:"token"`
+	Name       string      `json:"name"`
+	Tags       []string    `json:"tags,omitempty"`
+	DNSRecords []DNSRecord `json:"dns_records,omitempty"`
+	// Technologies are the technologies fingerprinted on the asset.
+	Technologies []Technology `json:"technologies,omitempty"`
+	// Criticality is empty if the asset has not been classified.
+	Criticality string `json:"criticality,omitempty"`
+	Notes       string `json:"notes,omitempty"`
+	// Status is the lifecycle status of the asset, such as active or archived.
+	Status string `json:"status,omitempty"`
+	// TeamToken is the token of the team owning the asset, empty if the API does not report it.
+	TeamToken string `json:"team_token,omitempty"`
+	// CreatedAt is when the asset was added, in RFC 3339 format, and nil if the API does not report it.
+	CreatedAt *string `json:"created_at,omitempty"`
+	// LastScannedAt and LastScanStatus are nil if the asset has never been scanned.
+	LastScannedAt  *string `json:"last_scanned_at,omitempty"`
+	LastScanStatus *string `json:"last_scan_status,omitempty"`
+	// Verified is nil if the API does not report the verification status of the asset.
+	Verified *bool `json:"verified,omitempty"`
+	// VerificationMethod is how ownership was verified, such as dns-txt or file, and empty if it is not verified.
+	VerificationMethod string `json:"verification_method,omitempty"`
+}
+
+// DNSRecord is a DNS record discovered for an asset.
+type DNSRecord struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	TTL   int64  `json:"ttl"`
+}
+
+// Technology is a technology fingerprinted on an asset, such as a web server or framework.
+type Technology struct {
+	Name string `json:"name"`
+	// Version is empty if the version could not be determined.
+	Version  string `json:"version,omitempty"`
+	Category string `json:"category"`
+}
+
+// AssetRequest is the request body used when creating or updating an asset.
+type AssetRequest struct {
+	Name      string   `json:"name,omitempty"`
+	TeamToken string   `json:"team_token,omitempty"`
+	Tags      []string `json:"tags"`
+	// Criticality is left unchanged if empty.
+	Criticality string `json:"criticality,omitempty"`
+	// Notes are cleared if empty.
+	Notes string `json:"notes"`
+}
+
+// AssetPatchRequest is the request body used when changing some of the attributes of an asset.
+// Attributes that are nil are left unchanged.
+type AssetPatchRequest struct {
+	Tags        *[]string `json:"tags,omitempty"`
+	Criticality *string   `json:"criticality,omitempty"`
+	Notes       *string   `json:"notes,omitempty"`
+}
+
+// empty reports whether the request leaves all attributes unchanged.
+func (r AssetPatchRequest) empty() bool {
+	return r.Tags == nil && r.Criticality == nil && r.Notes == nil
+}
+
+// ListAssets returns all assets available to the API key.
+func (c *Client) ListAssets(ctx context.Context) ([]Asset, error) {
+	assets, decodeErrs, err := c.ListAssetsPartial(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(decodeErrs) > 0 {
+		return nil, errors.Join(decodeErrs...)
+	}
+
+	return assets, nil
+}
+
+// ListAssetsPartial returns all assets available to the API key that could be decoded,
+// along with an error for each asset that could not.
+func (c *Client) ListAssetsPartial(ctx context.Context) ([]Asset, []error, error) {
+	var items []json.RawMessage
+	if err := c.do(ctx, http.MethodGet, "/v2/domains/", nil, &items); err != nil {
+		return nil, nil, err
+	}
+
+	assets := make([]Asset, 0, len(items))
+
+	var decodeErrs []error
+	for i, item := range items {
+		var asset Asset
+		if err := json.Unmarshal(item, &asset); err != nil {
+			decodeErrs = append(decodeErrs, fmt.Errorf("decoding asset at index %d: %w", i, err))
+			continue
+		}
+
+		assets = append(assets, asset)
+	}
+
+	return assets, decodeErrs, nil
+}
+
+// FindAssetByName returns the asset with the domain name, or nil if there is none.
+// The API has no lookup by name, so the asset is found among all of them.
+func (c *Client) FindAssetByName(ctx context.Context, name string) (*Asset, error) {
+	assets, err := c.ListAssets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range assets {
+		if assets[i].Name == name {
+			return &assets[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetAsset returns the asset identified by token.
+func (c *Client) GetAsset(ctx context.Context, token string) (*Asset, error) {
+	var asset Asset
+	if err := c.do(ctx, http.MethodGet, apiPath("domains", token), nil, &asset); err != nil {
+		return nil, err
+	}
+
+	return &asset, nil
+}
+
+// GetAssets returns the assets identified by tokens, requesting at most concurrency of them at once,
+// or all of them at once if concurrency is not positive. The asset and the error at each index are
+// those of the token at the index, so that the failure of some tokens does not fail the others.
+func (c *Client) GetAssets(ctx context.Context, tokens []string, concurrency int) ([]*Asset, []error) {
+	assets := make([]*Asset, len(tokens))
+	errs := make([]error, len(tokens))
+
+	requests := newSemaphore(int64(concurrency))
+
+	var wg sync.WaitGroup
+	for i, token := range tokens {
+		wg.Add(1)
+		go func(i int, token string) {
+			defer wg.Done()
+
+			if err := requests.acquire(ctx); err != nil {
+				errs[i] = err
+				return
+			}
+			defer requests.release()
+
+			assets[i], errs[i] = c.GetAsset(ctx, token)
+		}(i, token)
+	}
+	wg.Wait()
+
+	return assets, errs
+}
+
+// WaitForAsset returns the asset identified by token, retrying while it is not found.
+// Assets that were just created may not be readable right away, as the API is eventually consistent.
+// It retries as many times as for transient errors, with the same backoff.
+func (c *Client) WaitForAsset(ctx context.Context, token string) (*Asset, error) {
+	for attempt := 0; ; attempt++ {
+		asset, err := c.GetAsset(ctx, token)
+		if !IsNotFound(err) || attempt >= c.maxRetries {
+			return asset, err
+		}
+
+		wait := c.retryWait << attempt
+		tflog.Debug(ctx, "Waiting for asset to become readable", map[string]any{"token": token, "attempt": attempt + 1, "wait": wait.String()})
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// CreateAsset adds a new asset.
+func (c *Client) CreateAsset(ctx context.Context, body AssetRequest) (*Asset, error) {
+	var asset Asset
+	if err := c.do(ctx, http.MethodPost, "/v2/domains/", body, &asset); err != nil {
+		return nil, err
+	}
+
+	return &asset, nil
+}
+
+// UpdateAsset replaces the mutable properties of the asset identified by token.
+func (c *Client) UpdateAsset(ctx context.Context, token string, body AssetRequest) (*Asset, error) {
+	var asset Asset
+	if err := c.do(ctx, http.MethodPut, apiPath("domains", token), body, &asset); err != nil {
+		return nil, err
+	}
+
+	return &asset, nil
+}
+
+// PatchAsset changes the attributes set in body of the asset identified by token, leaving the others unchanged.
+func (c *Client) PatchAsset(ctx context.Context, token string, body AssetPatchRequest) (*Asset, error) {
+	var asset Asset
+	if err := c.do(ctx, http.MethodPatch, apiPath("domains", token), body, &asset); err != nil {
+		return nil, err
+	}
+
+	return &asset, nil
+}
+
+// DeleteAsset removes the asset identified by token.
+func (c *Client) DeleteAsset(ctx context.Context, token string) error {
+	return c.do(ctx, http.MethodDelete, apiPath("domains", token), nil, nil)
+}
+
+// The lifecycle statuses of an asset.
+const (
+	AssetStatusActive   = "active"
+	AssetStatusPaused   = "paused"
+	AssetStatusArchived = "archived"
+)
+
+// assetStatuses are the allowed values of the status of an asset.
+var assetStatuses = []string{AssetStatusActive, AssetStatusPaused, AssetStatusArchived}
+
+// assetStatusTransitions are the statuses an asset can be moved to from each status.
+// The API only restores archived assets to active.
+var assetStatusTransitions = map[string][]string{
+	AssetStatusActive:   {AssetStatusPaused, AssetStatusArchived},
+	AssetStatusPaused:   {AssetStatusActive, AssetStatusArchived},
+	AssetStatusArchived: {AssetStatusActive},
+}
+
+// canTransitionAssetStatus reports whether an asset with the status from can be moved to the status to.
+func canTransitionAssetStatus(from, to string) bool {
+	if from == to {
+		return true
+	}
+
+	for _, status := range assetStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AssetStatusRequest is the request body used when changing the status of an asset.
+type AssetStatusRequest struct {
+	Status string `json:"status"`
+}
+
+// SetAssetStatus moves the asset identified by token to the status.
+func (c *Client) SetAssetStatus(ctx context.Context, token, status string) (*Asset, error) {
+	var asset Asset
+	if err := c.do(ctx, http.MethodPut, apiPath("domains", token, "status"), AssetStatusRequest{Status: status}, &asset); err != nil {
+		return nil, err
+	}
+
+	return &asset, nil
+}
+
+// AssetTransferRequest is the request body used when moving an asset to another team.
+type AssetTransferRequest struct {
+	TeamToken string `json:"team_token"`
+}
+
+// TransferAsset moves the asset identified by token to the team identified by teamToken.
+func (c *Client) TransferAsset(ctx context.Context, token, teamToken string) (*Asset, error) {
+	var asset Asset
+	if err := c.do(ctx, http.MethodPost, apiPath("domains", token, "transfer"), AssetTransferRequest{TeamToken: teamToken}, &asset); err != nil {
+		return nil, err
+	}
+
+	return &asset, nil
+}
+
+// AssetSettings are the scan settings of an asset.
+type AssetSettings struct {
+	ScanFrequency string `json:"scan_frequency,omitempty"`
+	// TestCategories are the names of the test categories the asset is scanned with.
+	// They are left unchanged when updating the settings if nil.
+	TestCategories *[]string `json:"test_categories,omitempty"`
+}
+
+// GetAssetSettings returns the scan settings of the asset identified by token.
+func (c *Client) GetAssetSettings(ctx context.Context, token string) (*AssetSettings, error) {
+	var settings AssetSettings
+	if err := c.do(ctx, http.MethodGet, apiPath("domains", token, "settings"), nil, &settings); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// UpdateAssetSettings updates the scan settings of the asset identified by token.
+// Settings left empty are not changed.
+func (c *Client) UpdateAssetSettings(ctx context.Context, token string, body AssetSettings) (*AssetSettings, error) {
+	var settings AssetSettings
+	if err := c.do(ctx, http.MethodPut, apiPath("domains", token, "settings"), body, &settings); err != nil {
+		return nil, err
+	}
+
+	return &settings, nil
+}
+
+// SubdomainMonitoring is the configuration of which discovered subdomains of an asset are monitored.
+type SubdomainMonitoring struct {
+	Enabled bool `json:"enabled"`
+	// Allowlist and Blocklist are hostnames that are always or never monitored.
+	Allowlist []string `json:"allowlist"`
+	Blocklist []string `json:"blocklist"`
+}
+
+// GetSubdomainMonitoring returns the subdomain monitoring configuration of the asset identified by token.
+func (c *Client) GetSubdomainMonitoring(ctx context.Context, token string) (*SubdomainMonitoring, error) {
+	var monitoring SubdomainMonitoring
+	if err := c.do(ctx, http.MethodGet, apiPath("domains", token, "subdomain-monitoring"), nil, &monitoring); err != nil {
+		return nil, err
+	}
+
+	return &monitoring, nil
+}
+
+// UpdateSubdomainMonitoring replaces the subdomain monitoring configuration of the asset identified by token.
+func (c *Client) UpdateSubdomainMonitoring(ctx context.Context, token string, body SubdomainMonitoring) (*SubdomainMonitoring, error) {
+	var monitoring SubdomainMonitoring
+	if err := c.do(ctx, http.MethodPut, apiPath("domains", token, "subdomain-monitoring"), body, &monitoring); err != nil {
+		return nil, err
+	}
+
+	return &monitoring, nil
+}
+
+// MetadataValue is the value of a metadata key of an asset.
+type MetadataValue struct {
+	Value string `json:"value"`
+}
+
+// assetMetadataPath returns the path of the metadata of the asset, or of a single key if key is not empty.
+// The key is escaped, so it may contain any characters.
+func assetMetadataPath(token, key string) string {
+	if key == "" {
+		return apiPath("domains", token, "metadata")
+	}
+
+	return apiPath("domains", token, "metadata", key)
+}
+
+// GetAssetMetadata returns the metadata of the asset identified by token, by key.
+func (c *Client) GetAssetMetadata(ctx context.Context, token string) (map[string]string, error) {
+	metadata := map[string]string{}
+	if err := c.do(ctx, http.MethodGet, assetMetadataPath(token, ""), nil, &metadata); err != nil {
+		return nil, err
+	}
+
+	return metadata, nil
+}
+
+// SetAssetMetadata sets a single metadata key of the asset identified by token, leaving other keys unchanged.
+func (c *Client) SetAssetMetadata(ctx context.Context, token, key, value string) error {
+	return c.do(ctx, http.MethodPut, assetMetadataPath(token, key), MetadataValue{Value: value}, nil)
+}
+
+// DeleteAssetMetadata removes a single metadata key from the asset identified by token.
+func (c *Client) DeleteAssetMetadata(ctx context.Context, token, key string) error {
+	return c.do(ctx, http.MethodDelete, assetMetadataPath(token, key), nil, nil)
+}
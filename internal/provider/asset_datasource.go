@@ -2,31 +2,48 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
-	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
-var _ datasource.DataSource = &AssetDataSource{}
+var (
+	_ datasource.DataSource                     = &AssetDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &AssetDataSource{}
+)
 
 func NewAssetDataSource() datasource.DataSource {
 	return &AssetDataSource{}
 }
 
-// ExampleDataSource defines the data source implementation.
+// AssetDataSource defines the data source implementation.
 type AssetDataSource struct {
-	client *http.Client
+	client *Client
 }
 
-// ExampleDataSourceModel describes the data source data model.
+// AssetDataSourceModel describes the data source data model.
 type AssetDataSourceModel struct {
-	ConfigurableAttribute types.String `tfsdk:"configurable_attribute"`
-	Id                    types.String `tfsdk:"id"`
+	Domain types.String `tfsdk:"domain"`
+	Token  types.String `tfsdk:"token"`
+	Tags   types.Set    `tfsdk:"tags"`
+
+	DNSRecords   types.List `tfsdk:"dns_records"`
+	Technologies types.List `tfsdk:"technologies"`
+
+	Verified           types.Bool   `tfsdk:"verified"`
+	VerificationMethod types.String `tfsdk:"verification_method"`
+
+	IncludeRaw      types.Bool   `tfsdk:"include_raw"`
+	RawJSON         types.String `tfsdk:"raw_json"`
+	ExposeHeaders   types.List   `tfsdk:"expose_headers"`
+	ResponseHeaders types.Map    `tfsdk:"response_headers"`
 }
 
 func (d *AssetDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -36,39 +53,121 @@ func (d *AssetDataSource) Metadata(ctx context.Context, req datasource.MetadataR
 func (d *AssetDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Example data source",
+		MarkdownDescription: "Looks up an asset by its token or domain name.",
 
 		Attributes: map[string]schema.Attribute{
-			"configurable_attribute": schema.StringAttribute{
-				MarkdownDescription: "Example configurable attribute",
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The domain name of the asset. Exactly one of `domain` and `token` must be set.",
+				Optional:            true,
+				Computed:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The asset token. Exactly one of `domain` and `token` must be set.",
 				Optional:            true,
+				Computed:            true,
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Tags attached to the asset.",
+				ElementType:         types.StringType,
+				Computed:            true,
 			},
-			"id": schema.StringAttribute{
-				MarkdownDescription: "Example identifier",
+			"dns_records": schema.ListNestedAttribute{
+				MarkdownDescription: "DNS records discovered for the asset.",
 				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The record type, such as `A` or `CNAME`.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the record.",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The value of the record.",
+							Computed:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "The time to live of the record, in seconds.",
+							Computed:            true,
+						},
+					},
+				},
 			},
+			"technologies": schema.ListNestedAttribute{
+				MarkdownDescription: "Technologies fingerprinted on the asset, such as web servers and frameworks.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the technology, such as `nginx`.",
+							Computed:            true,
+						},
+						"version": schema.StringAttribute{
+							MarkdownDescription: "The version of the technology. Null if it could not be determined.",
+							Computed:            true,
+						},
+						"category": schema.StringAttribute{
+							MarkdownDescription: "The category of the technology, such as `web-server` or `javascript-framework`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"verified": schema.BoolAttribute{
+				MarkdownDescription: "Whether ownership of the asset is verified. Null if the API does not report it.",
+				Computed:            true,
+			},
+			"verification_method": schema.StringAttribute{
+				MarkdownDescription: "How ownership of the asset was verified, such as `dns-txt` or `file`. Null if it is not verified.",
+				Computed:            true,
+			},
+			"include_raw":      includeRawAttribute(),
+			"raw_json":         rawJSONAttribute(),
+			"expose_headers":   exposeHeadersAttribute(),
+			"response_headers": responseHeadersAttribute(),
 		},
 	}
 }
 
+func (d *AssetDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("domain"),
+			path.MatchRoot("token"),
+		),
+	}
+}
+
 func (d *AssetDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
 	// Prevent panic if the provider has not been configured.
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	d.client = client
+	d.client = providerData.Client
+}
+
+// assetDataSourceWarningFields maps the fields of the asset API to the attributes of the asset data source, for API warnings.
+var assetDataSourceWarningFields = map[string]path.Path{
+	"name":                path.Root("domain"),
+	"tags":                path.Root("tags"),
+	"dns_records":         path.Root("dns_records"),
+	"technologies":        path.Root("technologies"),
+	"verified":            path.Root("verified"),
+	"verification_method": path.Root("verification_method"),
 }
 
 func (d *AssetDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -81,21 +180,85 @@ func (d *AssetDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := d.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
-
-	// For the purposes of this example code, hardcoding a response value to
-	// save into the Terraform state.
-	data.Id = types.StringValue("example-id")
-
-	// Write logs using the tflog package
-	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, "read a data source")
+	var asset *Asset
+	clientCtx, raw := withRawResponse(ctx, data.IncludeRaw, data.ExposeHeaders)
+	clientCtx, warnings := withAPIWarnings(clientCtx)
+	defer func() { resp.Diagnostics.Append(apiWarningDiagnostics(warnings, assetDataSourceWarningFields)...) }()
+
+	if !data.Token.IsNull() {
+		var err error
+
+		asset, err = d.client.GetAsset(clientCtx, data.Token.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read asset, got error: %s", err))
+			return
+		}
+	} else {
+		// The API has no lookup by name, so find the asset among all of them.
+		assets, err := d.client.ListAssets(clientCtx)
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list assets, got error: %s", err))
+			return
+		}
+
+		index := -1
+		for i := range assets {
+			if assets[i].Name == data.Domain.ValueString() {
+				asset = &assets[i]
+				index = i
+				break
+			}
+		}
+
+		if asset == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("domain"),
+				"Asset Not Found",
+				fmt.Sprintf("No asset with the domain %q was found.", data.Domain.ValueString()),
+			)
+			return
+		}
+
+		// The raw response is the listing of all assets, of which only the asset is kept.
+		if data.IncludeRaw.ValueBool() {
+			var items []json.RawMessage
+			if err := json.Unmarshal(raw.Body, &items); err != nil || index >= len(items) {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read raw asset, got error: %v", err))
+				return
+			}
+
+			raw.Body = items[index]
+		}
+	}
+
+	data.Domain = types.StringValue(asset.Name)
+	data.Token = types.StringValue(asset.Token)
+
+	tags, diags := types.SetValueFrom(ctx, types.StringType, asset.Tags)
+	resp.Diagnostics.Append(diags...)
+	data.Tags = tags
+
+	records, diags := dnsRecordsValue(ctx, asset.DNSRecords)
+	resp.Diagnostics.Append(diags...)
+	data.DNSRecords = records
+
+	technologies, diags := technologiesValue(ctx, asset.Technologies)
+	resp.Diagnostics.Append(diags...)
+	data.Technologies = technologies
+
+	data.Verified = types.BoolPointerValue(asset.Verified)
+	data.VerificationMethod = types.StringNull()
+	if asset.VerificationMethod != "" {
+		data.VerificationMethod = types.StringValue(asset.VerificationMethod)
+	}
+
+	data.RawJSON = rawJSONValue(raw, data.IncludeRaw)
+
+	responseHeaders, diags := responseHeadersValue(ctx, raw, data.ExposeHeaders)
+	resp.Diagnostics.Append(diags...)
+	data.ResponseHeaders = responseHeaders
+
+	tflog.Trace(ctx, "read an asset", map[string]any{"token": asset.Token})
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -0,0 +1,427 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func assetAPIHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/domains/":
+			w.Write([]byte(`[
+				{"token": "aaaa1111", "name": "example.com", "tags": ["production"]},
+				{"token": "bbbb2222", "name": "example.org"}
+			]`))
+		case "/v2/domains/aaaa1111/":
+			w.Write([]byte(`{
+				"token": "aaaa1111",
+				"name": "example.com",
+				"tags": ["production"],
+				"dns_records": [
+					{"type": "A", "name": "example.com", "value": "93.184.216.34", "ttl": 3600},
+					{"type": "AAAA", "name": "example.com", "value": "2606:2800:220:1:248:1893:25c8:1946", "ttl": 3600},
+					{"type": "CNAME", "name": "www.example.com", "value": "example.com", "ttl": 300},
+					{"type": "MX", "name": "example.com", "value": "10 mail.example.com"}
+				]
+			}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// readAssetDataSource validates the configuration and reads the asset data source.
+func readAssetDataSource(t *testing.T, values map[string]tftypes.Value) (*provider.AssetDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	return readAssetDataSourceWith(t, newTestProviderData(t, assetAPIHandler()), values)
+}
+
+// readAssetDataSourceWith is like readAssetDataSource, using the provider data.
+func readAssetDataSourceWith(t *testing.T, providerData *provider.DetectifyProviderData, values map[string]tftypes.Value) (*provider.AssetDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	ctx := context.Background()
+	d := provider.NewAssetDataSource()
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    schemaValue(t, schemaResp.Schema, values),
+	}
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	validateResp := &datasource.ValidateConfigResponse{}
+	for _, v := range d.(datasource.DataSourceWithConfigValidators).ConfigValidators(ctx) {
+		v.ValidateDataSource(ctx, datasource.ValidateConfigRequest{Config: config}, validateResp)
+	}
+
+	if validateResp.Diagnostics.HasError() {
+		resp.Diagnostics = validateResp.Diagnostics
+		return nil, resp
+	}
+
+	configureResp := &datasource.ConfigureResponse{}
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{
+		ProviderData: providerData,
+	}, configureResp)
+	require.False(t, configureResp.Diagnostics.HasError())
+
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		return nil, resp
+	}
+
+	var data provider.AssetDataSourceModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+
+	return &data, resp
+}
+
+func TestAssetDataSourceByToken(t *testing.T) {
+	data, resp := readAssetDataSource(t, map[string]tftypes.Value{
+		"token": tftypes.NewValue(tftypes.String, "aaaa1111"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+	require.Equal(t, "example.com", data.Domain.ValueString())
+	require.Equal(t, "aaaa1111", data.Token.ValueString())
+	require.Len(t, data.Tags.Elements(), 1)
+}
+
+func TestAssetDataSourceByDomain(t *testing.T) {
+	data, resp := readAssetDataSource(t, map[string]tftypes.Value{
+		"domain": tftypes.NewValue(tftypes.String, "example.org"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+	require.Equal(t, "example.org", data.Domain.ValueString())
+	require.Equal(t, "bbbb2222", data.Token.ValueString())
+	require.Empty(t, data.Tags.Elements())
+	require.False(t, data.DNSRecords.IsNull())
+	require.Empty(t, data.DNSRecords.Elements())
+}
+
+func TestAssetDataSourceDNSRecords(t *testing.T) {
+	data, resp := readAssetDataSource(t, map[string]tftypes.Value{
+		"token": tftypes.NewValue(tftypes.String, "aaaa1111"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+	var records []struct {
+		Type  string `tfsdk:"type"`
+		Name  string `tfsdk:"name"`
+		Value string `tfsdk:"value"`
+		TTL   int64  `tfsdk:"ttl"`
+	}
+	require.False(t, data.DNSRecords.ElementsAs(context.Background(), &records, false).HasError())
+
+	require.Len(t, records, 4)
+	require.Equal(t, "A", records[0].Type)
+	require.Equal(t, "93.184.216.34", records[0].Value)
+	require.Equal(t, int64(3600), records[0].TTL)
+	require.Equal(t, "AAAA", records[1].Type)
+	require.Equal(t, "CNAME", records[2].Type)
+	require.Equal(t, "www.example.com", records[2].Name)
+	require.Equal(t, int64(300), records[2].TTL)
+	require.Equal(t, "MX", records[3].Type)
+	require.Equal(t, int64(0), records[3].TTL)
+}
+
+func TestAssetDataSourceTechnologies(t *testing.T) {
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/domains/aaaa1111/":
+			w.Write([]byte(`{
+				"token": "aaaa1111",
+				"name": "example.com",
+				"technologies": [
+					{"name": "nginx", "version": "1.25.3", "category": "web-server"},
+					{"name": "React", "category": "javascript-framework"}
+				]
+			}`))
+		case "/v2/domains/bbbb2222/":
+			w.Write([]byte(`{"token": "bbbb2222", "name": "example.org"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+
+	data, resp := readAssetDataSourceWith(t, providerData, map[string]tftypes.Value{
+		"token": tftypes.NewValue(tftypes.String, "aaaa1111"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+	var technologies []struct {
+		Name     string  `tfsdk:"name"`
+		Version  *string `tfsdk:"version"`
+		Category string  `tfsdk:"category"`
+	}
+	require.False(t, data.Technologies.ElementsAs(context.Background(), &technologies, false).HasError())
+
+	require.Len(t, technologies, 2)
+	require.Equal(t, "nginx", technologies[0].Name)
+	require.Equal(t, ptr("1.25.3"), technologies[0].Version)
+	require.Equal(t, "web-server", technologies[0].Category)
+	require.Equal(t, "React", technologies[1].Name)
+	require.Nil(t, technologies[1].Version)
+	require.Equal(t, "javascript-framework", technologies[1].Category)
+
+	// An asset without fingerprinted technologies has an empty list.
+	data, resp = readAssetDataSourceWith(t, providerData, map[string]tftypes.Value{
+		"token": tftypes.NewValue(tftypes.String, "bbbb2222"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+	require.False(t, data.Technologies.IsNull())
+	require.Empty(t, data.Technologies.Elements())
+}
+
+func TestAssetDataSourceDomainNotFound(t *testing.T) {
+	_, resp := readAssetDataSource(t, map[string]tftypes.Value{
+		"domain": tftypes.NewValue(tftypes.String, "example.net"),
+	})
+
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Asset Not Found", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestAssetDataSourceTokenAndDomain(t *testing.T) {
+	_, resp := readAssetDataSource(t, map[string]tftypes.Value{
+		"domain": tftypes.NewValue(tftypes.String, "example.com"),
+		"token":  tftypes.NewValue(tftypes.String, "aaaa1111"),
+	})
+
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Invalid Attribute Combination", resp.Diagnostics.Errors()[0].Summary())
+}
+
+func TestAssetDataSourceConditionalRequest(t *testing.T) {
+	var mu sync.Mutex
+	var ifNoneMatch []string
+
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		mu.Unlock()
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"token": "aaaa1111", "name": "example.com", "tags": ["production"]}`))
+	}))
+
+	for i := 0; i < 2; i++ {
+		data, resp := readAssetDataSourceWith(t, providerData, map[string]tftypes.Value{
+			"token": tftypes.NewValue(tftypes.String, "aaaa1111"),
+		})
+		require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+		require.Equal(t, "example.com", data.Domain.ValueString())
+		require.Len(t, data.Tags.Elements(), 1)
+	}
+
+	require.Equal(t, []string{"", `"v1"`}, ifNoneMatch)
+}
+
+func TestAssetDataSourceRawJSON(t *testing.T) {
+	t.Run("by token", func(t *testing.T) {
+		data, resp := readAssetDataSource(t, map[string]tftypes.Value{
+			"token":       tftypes.NewValue(tftypes.String, "aaaa1111"),
+			"include_raw": tftypes.NewValue(tftypes.Bool, true),
+		})
+		require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+		require.JSONEq(t, `{
+			"token": "aaaa1111",
+			"name": "example.com",
+			"tags": ["production"],
+			"dns_records": [
+				{"type": "A", "name": "example.com", "value": "93.184.216.34", "ttl": 3600},
+				{"type": "AAAA", "name": "example.com", "value": "2606:2800:220:1:248:1893:25c8:1946", "ttl": 3600},
+				{"type": "CNAME", "name": "www.example.com", "value": "example.com", "ttl": 300},
+				{"type": "MX", "name": "example.com", "value": "10 mail.example.com"}
+			]
+		}`, data.RawJSON.ValueString())
+	})
+
+	t.Run("by domain", func(t *testing.T) {
+		data, resp := readAssetDataSource(t, map[string]tftypes.Value{
+			"domain":      tftypes.NewValue(tftypes.String, "example.org"),
+			"include_raw": tftypes.NewValue(tftypes.Bool, true),
+		})
+		require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+		// Only the asset is kept of the listing of all assets.
+		require.JSONEq(t, `{"token": "bbbb2222", "name": "example.org"}`, data.RawJSON.ValueString())
+	})
+
+	t.Run("not included", func(t *testing.T) {
+		data, resp := readAssetDataSource(t, map[string]tftypes.Value{
+			"token": tftypes.NewValue(tftypes.String, "aaaa1111"),
+		})
+		require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+		require.True(t, data.RawJSON.IsNull())
+	})
+
+	t.Run("sensitive", func(t *testing.T) {
+		ctx := context.Background()
+		checked := 0
+		for _, newDataSource := range provider.New("test")().DataSources(ctx) {
+			var resp datasource.SchemaResponse
+			newDataSource().Schema(ctx, datasource.SchemaRequest{}, &resp)
+
+			if attribute, ok := resp.Schema.Attributes["raw_json"]; ok {
+				require.True(t, attribute.IsSensitive())
+				checked++
+			}
+		}
+		require.Equal(t, 4, checked)
+	})
+}
+
+func TestAssetDataSourceExposeHeaders(t *testing.T) {
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "42")
+		w.Header().Set("X-Request-Id", "req-0001")
+		w.Header().Add("X-Trace", "a")
+		w.Header().Add("X-Trace", "b")
+		assetAPIHandler().ServeHTTP(w, r)
+	}))
+
+	t.Run("exposed", func(t *testing.T) {
+		data, resp := readAssetDataSourceWith(t, providerData, map[string]tftypes.Value{
+			"token": tftypes.NewValue(tftypes.String, "aaaa1111"),
+			"expose_headers": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "x-ratelimit-remaining"),
+				tftypes.NewValue(tftypes.String, "X-Request-Id"),
+				tftypes.NewValue(tftypes.String, "X-Trace"),
+				tftypes.NewValue(tftypes.String, "X-Missing"),
+			}),
+		})
+		require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+		headers := map[string]string{}
+		require.False(t, data.ResponseHeaders.ElementsAs(context.Background(), &headers, false).HasError())
+		require.Equal(t, map[string]string{
+			"x-ratelimit-remaining": "42",
+			"X-Request-Id":          "req-0001",
+			"X-Trace":               "a, b",
+		}, headers)
+
+		// Exposing headers does not include the raw response.
+		require.True(t, data.RawJSON.IsNull())
+	})
+
+	t.Run("by domain", func(t *testing.T) {
+		data, resp := readAssetDataSourceWith(t, providerData, map[string]tftypes.Value{
+			"domain": tftypes.NewValue(tftypes.String, "example.org"),
+			"expose_headers": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "X-Request-Id"),
+			}),
+		})
+		require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+		require.Len(t, data.ResponseHeaders.Elements(), 1)
+		require.True(t, data.RawJSON.IsNull())
+	})
+
+	t.Run("not exposed", func(t *testing.T) {
+		data, resp := readAssetDataSourceWith(t, providerData, map[string]tftypes.Value{
+			"token": tftypes.NewValue(tftypes.String, "aaaa1111"),
+		})
+		require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+		require.True(t, data.ResponseHeaders.IsNull())
+	})
+}
+
+func TestAssetDataSourceAPIWarnings(t *testing.T) {
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{
+			"token": "aaaa1111",
+			"name": "example.com",
+			"verification_method": "file",
+			"warnings": [
+				{"field": "verification_method", "message": "The file verification method is deprecated, use dns-txt."},
+				{"field": "legacy_id", "message": "The legacy_id field will be removed."},
+				{"message": "This endpoint is deprecated, use /v3/assets/."}
+			]
+		}`))
+	}))
+
+	data, resp := readAssetDataSourceWith(t, providerData, map[string]tftypes.Value{
+		"token": tftypes.NewValue(tftypes.String, "aaaa1111"),
+	})
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+	require.Equal(t, "example.com", data.Domain.ValueString())
+
+	warnings := resp.Diagnostics.Warnings()
+	require.Len(t, warnings, 3)
+
+	// A warning about a field of an attribute is on the attribute.
+	require.Equal(t, "Detectify API Warning", warnings[0].Summary())
+	require.Contains(t, warnings[0].Detail(), "The file verification method is deprecated, use dns-txt.")
+	withPath, ok := warnings[0].(diag.DiagnosticWithPath)
+	require.True(t, ok)
+	require.Equal(t, path.Root("verification_method"), withPath.Path())
+
+	// Other warnings are not on an attribute.
+	require.Contains(t, warnings[1].Detail(), `"legacy_id"`)
+	_, ok = warnings[1].(diag.DiagnosticWithPath)
+	require.False(t, ok)
+	require.Equal(t, "This endpoint is deprecated, use /v3/assets/.", warnings[2].Detail())
+}
+
+func TestAssetDataSourceVerification(t *testing.T) {
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"token": "aaaa1111", "name": "verified.example.com", "verified": true, "verification_method": "dns-txt"},
+			{"token": "bbbb2222", "name": "unverified.example.com", "verified": false},
+			{"token": "cccc3333", "name": "unknown.example.com"}
+		]`))
+	}))
+
+	tests := map[string]struct {
+		verified *bool
+		method   *string
+	}{
+		"verified.example.com":   {verified: ptr(true), method: ptr("dns-txt")},
+		"unverified.example.com": {verified: ptr(false)},
+		"unknown.example.com":    {},
+	}
+
+	for domain, want := range tests {
+		t.Run(domain, func(t *testing.T) {
+			data, resp := readAssetDataSourceWith(t, providerData, map[string]tftypes.Value{
+				"domain": tftypes.NewValue(tftypes.String, domain),
+			})
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+			require.Equal(t, want.verified, data.Verified.ValueBoolPointer())
+			require.Equal(t, want.method, data.VerificationMethod.ValueStringPointer())
+		})
+	}
+}
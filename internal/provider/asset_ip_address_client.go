@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+)
+
+// IPAddress is an IP address associated with an asset, as represented by the Detectify API.
+type IPAddress struct {
+	Address string `json:"address"`
+	// Type is the IP version, ipv4 or ipv6.
+	Type      string `json:"type"`
+	FirstSeen string `json:"first_seen"`
+	LastSeen  string `json:"last_seen"`
+	// HostingProvider is empty if the address is not attributed to a hosting provider.
+	HostingProvider string `json:"hosting_provider,omitempty"`
+}
+
+// IPAddressesQuery are the options of an IP address listing. Options left empty are not sent.
+type IPAddressesQuery struct {
+	Type string
+}
+
+// ListAssetIPAddresses returns the IP addresses of the asset identified by token,
+// following the pages of the listing until the last one.
+func (c *Client) ListAssetIPAddresses(ctx context.Context, token string, q IPAddressesQuery) ([]IPAddress, error) {
+	values := url.Values{}
+	if q.Type != "" {
+		values.Set("type", q.Type)
+	}
+
+	return listPages[IPAddress](ctx, c, apiPath("domains", token, "ips"), values, "ip_addresses")
+}
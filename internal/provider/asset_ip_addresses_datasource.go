@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssetIPAddressesDataSource{}
+
+func NewAssetIPAddressesDataSource() datasource.DataSource {
+	return &AssetIPAddressesDataSource{}
+}
+
+// AssetIPAddressesDataSource defines the data source implementation.
+type AssetIPAddressesDataSource struct {
+	client          *Client
+	defaultPageSize int64
+}
+
+// AssetIPAddressesDataSourceModel describes the data source data model.
+type AssetIPAddressesDataSourceModel struct {
+	AssetToken  types.String `tfsdk:"asset_token"`
+	Type        types.String `tfsdk:"type"`
+	IPAddresses types.List   `tfsdk:"ip_addresses"`
+	PageSize    types.Int64  `tfsdk:"page_size"`
+}
+
+// ipAddressModel describes an IP address in the asset IP addresses data source.
+type ipAddressModel struct {
+	Address         types.String `tfsdk:"address"`
+	Type            types.String `tfsdk:"type"`
+	FirstSeen       types.String `tfsdk:"first_seen"`
+	LastSeen        types.String `tfsdk:"last_seen"`
+	HostingProvider types.String `tfsdk:"hosting_provider"`
+}
+
+// ipAddressAttrTypes are the attribute types of ipAddressModel.
+var ipAddressAttrTypes = map[string]attr.Type{
+	"address":          types.StringType,
+	"type":             types.StringType,
+	"first_seen":       types.StringType,
+	"last_seen":        types.StringType,
+	"hosting_provider": types.StringType,
+}
+
+func (d *AssetIPAddressesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_ip_addresses"
+}
+
+func (d *AssetIPAddressesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the IP addresses associated with an asset.",
+
+		Attributes: map[string]schema.Attribute{
+			"asset_token": schema.StringAttribute{
+				MarkdownDescription: "The token of the asset.",
+				Required:            true,
+			},
+			"type": schema.StringAttribute{
+				MarkdownDescription: "Only list addresses of the IP version, `ipv4` or `ipv6`. All addresses are listed by default.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("ipv4", "ipv6"),
+				},
+			},
+			"ip_addresses": schema.ListNestedAttribute{
+				MarkdownDescription: "The IP addresses, in the order returned by the API.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							MarkdownDescription: "The IP address.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The IP version, `ipv4` or `ipv6`.",
+							Computed:            true,
+						},
+						"first_seen": schema.StringAttribute{
+							MarkdownDescription: "When the address was first seen for the asset, as an RFC 3339 timestamp.",
+							Computed:            true,
+						},
+						"last_seen": schema.StringAttribute{
+							MarkdownDescription: "When the address was last seen for the asset, as an RFC 3339 timestamp.",
+							Computed:            true,
+						},
+						"hosting_provider": schema.StringAttribute{
+							MarkdownDescription: "The hosting provider the address belongs to. Null if it is not known.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"page_size": pageSizeAttribute(),
+		},
+	}
+}
+
+func (d *AssetIPAddressesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.defaultPageSize = providerData.DefaultPageSize
+}
+
+func (d *AssetIPAddressesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AssetIPAddressesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	q := IPAddressesQuery{Type: data.Type.ValueString()}
+
+	addresses, err := d.client.ListAssetIPAddresses(withPageSize(ctx, data.PageSize, d.defaultPageSize), data.AssetToken.ValueString(), q)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list asset IP addresses, got error: %s", err))
+		return
+	}
+
+	items := []ipAddressModel{}
+	for _, address := range addresses {
+		// The API may not support filtering, so addresses are always filtered to keep the result as requested.
+		if q.Type != "" && address.Type != q.Type {
+			continue
+		}
+
+		hostingProvider := types.StringNull()
+		if address.HostingProvider != "" {
+			hostingProvider = types.StringValue(address.HostingProvider)
+		}
+
+		items = append(items, ipAddressModel{
+			Address:         types.StringValue(address.Address),
+			Type:            types.StringValue(address.Type),
+			FirstSeen:       types.StringValue(address.FirstSeen),
+			LastSeen:        types.StringValue(address.LastSeen),
+			HostingProvider: hostingProvider,
+		})
+	}
+
+	var diags diag.Diagnostics
+	data.IPAddresses, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: ipAddressAttrTypes}, items)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Trace(ctx, "read asset IP addresses", map[string]any{"asset_token": data.AssetToken.ValueString(), "count": len(items)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,85 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+)
+
+func TestAccAssetIPAddressesDataSource(t *testing.T) {
+	api := newFakeAPI(t)
+
+	token := api.addAsset("example.com")
+	api.addIPAddress(token, provider.IPAddress{
+		Address:         "93.184.216.34",
+		Type:            "ipv4",
+		FirstSeen:       "2024-01-15T08:30:00Z",
+		LastSeen:        "2024-05-01T12:00:00Z",
+		HostingProvider: "Edgecast",
+	})
+	api.addIPAddress(token, provider.IPAddress{
+		Address:   "2606:2800:220:1:248:1893:25c8:1946",
+		Type:      "ipv6",
+		FirstSeen: "2024-01-15T08:30:00Z",
+		LastSeen:  "2024-05-01T12:00:00Z",
+	})
+	api.addIPAddress(token, provider.IPAddress{
+		Address:   "93.184.216.35",
+		Type:      "ipv4",
+		FirstSeen: "2024-02-01T00:00:00Z",
+		LastSeen:  "2024-03-01T00:00:00Z",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+data "detectify_asset_ip_addresses" "all" {
+  asset_token = "` + token + `"
+}
+
+data "detectify_asset_ip_addresses" "ipv6" {
+  asset_token = "` + token + `"
+  type        = "ipv6"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// The three addresses span two pages.
+					resource.TestCheckResourceAttr("data.detectify_asset_ip_addresses.all", "ip_addresses.#", "3"),
+					resource.TestCheckResourceAttr("data.detectify_asset_ip_addresses.all", "ip_addresses.0.address", "93.184.216.34"),
+					resource.TestCheckResourceAttr("data.detectify_asset_ip_addresses.all", "ip_addresses.0.type", "ipv4"),
+					resource.TestCheckResourceAttr("data.detectify_asset_ip_addresses.all", "ip_addresses.0.first_seen", "2024-01-15T08:30:00Z"),
+					resource.TestCheckResourceAttr("data.detectify_asset_ip_addresses.all", "ip_addresses.0.last_seen", "2024-05-01T12:00:00Z"),
+					resource.TestCheckResourceAttr("data.detectify_asset_ip_addresses.all", "ip_addresses.0.hosting_provider", "Edgecast"),
+					resource.TestCheckResourceAttr("data.detectify_asset_ip_addresses.all", "ip_addresses.1.type", "ipv6"),
+					resource.TestCheckNoResourceAttr("data.detectify_asset_ip_addresses.all", "ip_addresses.1.hosting_provider"),
+					resource.TestCheckResourceAttr("data.detectify_asset_ip_addresses.all", "ip_addresses.2.address", "93.184.216.35"),
+
+					resource.TestCheckResourceAttr("data.detectify_asset_ip_addresses.ipv6", "ip_addresses.#", "1"),
+					resource.TestCheckResourceAttr("data.detectify_asset_ip_addresses.ipv6", "ip_addresses.0.address", "2606:2800:220:1:248:1893:25c8:1946"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+data "detectify_asset_ip_addresses" "invalid" {
+  asset_token = "` + token + `"
+  type        = "ipv5"
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config: api.providerConfig() + `
+data "detectify_asset_ip_addresses" "missing" {
+  asset_token = "token9999"
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to list asset IP addresses`),
+			},
+		},
+	})
+}
@@ -0,0 +1,18 @@
+package provider
+
+import (
+	"context"
+)
+
+// AssetRelationship is a relationship of an asset to another asset, as represented by the Detectify API.
+type AssetRelationship struct {
+	AssetToken string `json:"asset_token"`
+	// Type is the kind of relationship, such as subdomain-of or related-by-ip.
+	Type string `json:"type"`
+}
+
+// ListAssetRelationships returns the relationships of the asset identified by token,
+// following the pages of the listing until the last one.
+func (c *Client) ListAssetRelationships(ctx context.Context, token string) ([]AssetRelationship, error) {
+	return listPages[AssetRelationship](ctx, c, apiPath("domains", token, "relationships"), nil, "relationships")
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssetRelationshipsDataSource{}
+
+func NewAssetRelationshipsDataSource() datasource.DataSource {
+	return &AssetRelationshipsDataSource{}
+}
+
+// AssetRelationshipsDataSource defines the data source implementation.
+type AssetRelationshipsDataSource struct {
+	client          *Client
+	defaultPageSize int64
+}
+
+// AssetRelationshipsDataSourceModel describes the data source data model.
+type AssetRelationshipsDataSourceModel struct {
+	AssetToken    types.String `tfsdk:"asset_token"`
+	Relationships types.List   `tfsdk:"relationships"`
+	PageSize      types.Int64  `tfsdk:"page_size"`
+}
+
+// assetRelationshipModel describes a relationship in the asset relationships data source.
+type assetRelationshipModel struct {
+	AssetToken types.String `tfsdk:"asset_token"`
+	Type       types.String `tfsdk:"type"`
+}
+
+// assetRelationshipAttrTypes are the attribute types of assetRelationshipModel.
+var assetRelationshipAttrTypes = map[string]attr.Type{
+	"asset_token": types.StringType,
+	"type":        types.StringType,
+}
+
+func (d *AssetRelationshipsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_relationships"
+}
+
+func (d *AssetRelationshipsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the assets related to an asset, such as the asset it is a subdomain of " +
+			"or assets sharing an IP address with it.",
+
+		Attributes: map[string]schema.Attribute{
+			"asset_token": schema.StringAttribute{
+				MarkdownDescription: "The token of the asset.",
+				Required:            true,
+			},
+			"relationships": schema.ListNestedAttribute{
+				MarkdownDescription: "The relationships of the asset, in the order returned by the API.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"asset_token": schema.StringAttribute{
+							MarkdownDescription: "The token of the related asset.",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The kind of relationship, such as `subdomain-of` or `related-by-ip`.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"page_size": pageSizeAttribute(),
+		},
+	}
+}
+
+func (d *AssetRelationshipsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.defaultPageSize = providerData.DefaultPageSize
+}
+
+func (d *AssetRelationshipsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AssetRelationshipsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	relationships, err := d.client.ListAssetRelationships(withPageSize(ctx, data.PageSize, d.defaultPageSize), data.AssetToken.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list asset relationships, got error: %s", err))
+		return
+	}
+
+	items := make([]assetRelationshipModel, len(relationships))
+	for i, relationship := range relationships {
+		items[i] = assetRelationshipModel{
+			AssetToken: types.StringValue(relationship.AssetToken),
+			Type:       types.StringValue(relationship.Type),
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.Relationships, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: assetRelationshipAttrTypes}, items)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Trace(ctx, "read asset relationships", map[string]any{"asset_token": data.AssetToken.ValueString(), "count": len(relationships)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
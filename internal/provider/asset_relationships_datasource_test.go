@@ -0,0 +1,148 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccAssetRelationshipsDataSource(t *testing.T) {
+	api := newFakeAPI(t)
+
+	parent := api.addAsset("example.com")
+	child := api.addAsset("app.example.com")
+	lonely := api.addAsset("example.org")
+
+	api.addRelationship(child, provider.AssetRelationship{AssetToken: parent, Type: "subdomain-of"})
+	for _, token := range []string{"token0101", "token0102", "token0103", "token0104"} {
+		api.addRelationship(child, provider.AssetRelationship{AssetToken: token, Type: "related-by-ip"})
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+data "detectify_asset_relationships" "child" {
+  asset_token = "` + child + `"
+}
+
+data "detectify_asset_relationships" "lonely" {
+  asset_token = "` + lonely + `"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// The five relationships of the child span three pages.
+					resource.TestCheckResourceAttr("data.detectify_asset_relationships.child", "relationships.#", "5"),
+					resource.TestCheckResourceAttr("data.detectify_asset_relationships.child", "relationships.0.asset_token", parent),
+					resource.TestCheckResourceAttr("data.detectify_asset_relationships.child", "relationships.0.type", "subdomain-of"),
+					resource.TestCheckResourceAttr("data.detectify_asset_relationships.child", "relationships.2.asset_token", "token0102"),
+					resource.TestCheckResourceAttr("data.detectify_asset_relationships.child", "relationships.4.asset_token", "token0104"),
+					resource.TestCheckResourceAttr("data.detectify_asset_relationships.child", "relationships.4.type", "related-by-ip"),
+
+					resource.TestCheckResourceAttr("data.detectify_asset_relationships.lonely", "relationships.#", "0"),
+					func(*terraform.State) error {
+						api.mu.Lock()
+						defer api.mu.Unlock()
+
+						// Each read requests three pages for the child and one for the other asset.
+						if api.relationshipRequests%4 != 0 {
+							return fmt.Errorf("unexpected number of relationship page requests: %d", api.relationshipRequests)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+data "detectify_asset_relationships" "missing" {
+  asset_token = "token9999"
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to list asset relationships`),
+			},
+		},
+	})
+}
+
+func TestListAssetRelationshipsRepeatedCursor(t *testing.T) {
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"relationships": [{"asset_token": "bbbb2222", "type": "related-by-ip"}], "next_cursor": "same"}`))
+	}))
+
+	_, err := providerData.Client.ListAssetRelationships(context.Background(), "aaaa1111")
+	require.ErrorContains(t, err, `cursor "same" was returned more than once`)
+}
+
+func TestAccAssetRelationshipsDataSourcePageSize(t *testing.T) {
+	api := newFakeAPI(t)
+
+	parent := api.addAsset("example.com")
+	children := []string{api.addAsset("app.example.com"), api.addAsset("www.example.com")}
+	for _, child := range children {
+		for i := 0; i < 5; i++ {
+			api.addRelationship(child, provider.AssetRelationship{AssetToken: parent, Type: "subdomain-of"})
+		}
+	}
+
+	providerConfig := fmt.Sprintf(`
+provider "detectify" {
+  api_key           = "10840b0f938942feafb7186de74b9682"
+  base_url           = %q
+  allow_insecure_url = true
+  default_page_size  = 3
+}
+`, api.server.URL)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Replace(providerConfig, "default_page_size  = 3", "default_page_size  = 0", 1) + `
+data "detectify_asset_relationships" "test" {
+  asset_token = "` + children[0] + `"
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be between 1 and 1000`),
+			},
+			{
+				Config: providerConfig + `
+data "detectify_asset_relationships" "inherited" {
+  asset_token = "` + children[0] + `"
+}
+
+data "detectify_asset_relationships" "overridden" {
+  asset_token = "` + children[1] + `"
+  page_size   = 4
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.detectify_asset_relationships.inherited", "relationships.#", "5"),
+					resource.TestCheckResourceAttr("data.detectify_asset_relationships.overridden", "relationships.#", "5"),
+					func(*terraform.State) error {
+						api.mu.Lock()
+						defer api.mu.Unlock()
+
+						if got := api.relationshipPageSizes[children[0]]; got != "3" {
+							return fmt.Errorf("expected the provider default page size, got %q", got)
+						}
+						if got := api.relationshipPageSizes[children[1]]; got != "4" {
+							return fmt.Errorf("expected the data source page size, got %q", got)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
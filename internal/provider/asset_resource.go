@@ -2,23 +2,37 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/http"
+	"regexp"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var (
-	_ resource.Resource                = &AssetResource{}
-	_ resource.ResourceWithImportState = &AssetResource{}
+	_ resource.Resource                     = &AssetResource{}
+	_ resource.ResourceWithConfigValidators = &AssetResource{}
+	_ resource.ResourceWithImportState      = &AssetResource{}
+	_ resource.ResourceWithModifyPlan       = &AssetResource{}
 )
 
 func NewAssetResource() resource.Resource {
@@ -27,14 +41,151 @@ func NewAssetResource() resource.Resource {
 
 // AssetResource defines the resource implementation.
 type AssetResource struct {
-	client *http.Client
+	client    *Client
+	teamToken string
 }
 
+// assetWarningFields maps the fields of the asset API to the attributes of the asset resource, for API warnings.
+var assetWarningFields = map[string]path.Path{
+	"name":            path.Root("domain"),
+	"tags":            path.Root("tags"),
+	"team_token":      path.Root("team_token"),
+	"criticality":     path.Root("criticality"),
+	"notes":           path.Root("description"),
+	"status":          path.Root("status"),
+	"dns_records":     path.Root("dns_records"),
+	"technologies":    path.Root("technologies"),
+	"scan_frequency":  path.Root("scan_frequency"),
+	"test_categories": path.Root("scan_settings").AtName("test_categories"),
+	"allowlist":       path.Root("subdomain_allowlist"),
+	"blocklist":       path.Root("subdomain_blocklist"),
+}
+
+// scanFrequencies are the allowed values of the scan frequency of an asset.
+var scanFrequencies = []string{"daily", "weekly", "biweekly", "monthly"}
+
+// criticalities are the allowed values of the business criticality of an asset.
+var criticalities = []string{"low", "medium", "high", "critical"}
+
 // AssetResourceModel describes the resource data model.
 type AssetResourceModel struct {
-	ConfigurableAttribute types.String `tfsdk:"configurable_attribute"`
-	Defaulted             types.String `tfsdk:"defaulted"`
-	Id                    types.String `tfsdk:"id"`
+	Domain    types.String `tfsdk:"domain"`
+	Token     types.String `tfsdk:"token"`
+	TeamToken types.String `tfsdk:"team_token"`
+	Tags      types.Set    `tfsdk:"tags"`
+
+	ScanFrequency types.String `tfsdk:"scan_frequency"`
+	Criticality   types.String `tfsdk:"criticality"`
+	Description   types.String `tfsdk:"description"`
+	Status        types.String `tfsdk:"status"`
+	Metadata      types.Map    `tfsdk:"metadata"`
+	References    types.Map    `tfsdk:"references"`
+	DNSRecords    types.List   `tfsdk:"dns_records"`
+	Technologies  types.List   `tfsdk:"technologies"`
+
+	LastScannedAt  types.String `tfsdk:"last_scanned_at"`
+	LastScanStatus types.String `tfsdk:"last_scan_status"`
+
+	ScanSettings types.Object `tfsdk:"scan_settings"`
+
+	MonitorSubdomains  types.Bool `tfsdk:"monitor_subdomains"`
+	SubdomainAllowlist types.Set  `tfsdk:"subdomain_allowlist"`
+	SubdomainBlocklist types.Set  `tfsdk:"subdomain_blocklist"`
+
+	ScanCredentials types.Object `tfsdk:"scan_credentials"`
+
+	DeletionProtection types.Bool `tfsdk:"deletion_protection"`
+	AdoptExisting      types.Bool `tfsdk:"adopt_existing"`
+}
+
+// assetScanSettingsModel describes the scan_settings of an asset.
+type assetScanSettingsModel struct {
+	ScanFrequency     types.String `tfsdk:"scan_frequency"`
+	MonitoringEnabled types.Bool   `tfsdk:"monitoring_enabled"`
+	TestCategories    types.Set    `tfsdk:"test_categories"`
+}
+
+// assetScanSettingsAttrTypes are the attribute types of assetScanSettingsModel.
+var assetScanSettingsAttrTypes = map[string]attr.Type{
+	"scan_frequency":     types.StringType,
+	"monitoring_enabled": types.BoolType,
+	"test_categories":    types.SetType{ElemType: types.StringType},
+}
+
+// assetScanCredentialsModel describes the scan_credentials of an asset.
+type assetScanCredentialsModel struct {
+	Username types.String `tfsdk:"username"`
+	Password types.String `tfsdk:"password"`
+	Token    types.String `tfsdk:"token"`
+}
+
+// assetScanCredentialsAttrTypes are the attribute types of assetScanCredentialsModel.
+var assetScanCredentialsAttrTypes = map[string]attr.Type{
+	"username": types.StringType,
+	"password": types.StringType,
+	"token":    types.StringType,
+}
+
+// dnsRecordModel describes a DNS record of an asset.
+type dnsRecordModel struct {
+	Type  types.String `tfsdk:"type"`
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+	TTL   types.Int64  `tfsdk:"ttl"`
+}
+
+// dnsRecordAttrTypes are the attribute types of dnsRecordModel.
+var dnsRecordAttrTypes = map[string]attr.Type{
+	"type":  types.StringType,
+	"name":  types.StringType,
+	"value": types.StringType,
+	"ttl":   types.Int64Type,
+}
+
+// technologyModel describes a technology fingerprinted on an asset.
+type technologyModel struct {
+	Name     types.String `tfsdk:"name"`
+	Version  types.String `tfsdk:"version"`
+	Category types.String `tfsdk:"category"`
+}
+
+// technologyAttrTypes are the attribute types of technologyModel.
+var technologyAttrTypes = map[string]attr.Type{
+	"name":     types.StringType,
+	"version":  types.StringType,
+	"category": types.StringType,
+}
+
+// technologiesValue returns a list of the technologies, empty if there are none.
+func technologiesValue(ctx context.Context, technologies []Technology) (types.List, diag.Diagnostics) {
+	models := make([]technologyModel, len(technologies))
+	for i, technology := range technologies {
+		models[i] = technologyModel{
+			Name:     types.StringValue(technology.Name),
+			Version:  types.StringNull(),
+			Category: types.StringValue(technology.Category),
+		}
+		if technology.Version != "" {
+			models[i].Version = types.StringValue(technology.Version)
+		}
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: technologyAttrTypes}, models)
+}
+
+// dnsRecordsValue returns a list of the DNS records, empty if there are none.
+func dnsRecordsValue(ctx context.Context, records []DNSRecord) (types.List, diag.Diagnostics) {
+	models := make([]dnsRecordModel, len(records))
+	for i, record := range records {
+		models[i] = dnsRecordModel{
+			Type:  types.StringValue(record.Type),
+			Name:  types.StringValue(record.Name),
+			Value: types.StringValue(record.Value),
+			TTL:   types.Int64Value(record.TTL),
+		}
+	}
+
+	return types.ListValueFrom(ctx, types.ObjectType{AttrTypes: dnsRecordAttrTypes}, models)
 }
 
 func (r *AssetResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -44,50 +195,312 @@ func (r *AssetResource) Metadata(ctx context.Context, req resource.MetadataReque
 func (r *AssetResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
-		MarkdownDescription: "Example resource",
+		MarkdownDescription: "Manages an asset monitored by Detectify.",
 
 		Attributes: map[string]schema.Attribute{
-			"name": schema.StringAttribute{
-				MarkdownDescription: "The name of the asset, typically a hostname.",
-				Required:            true,
-			},
-			"defaulted": schema.StringAttribute{
-				MarkdownDescription: "Example configurable attribute with default value",
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The domain name of the asset, typically a hostname. It must be lower case without a trailing dot.",
 				Optional:            true,
 				Computed:            true,
-				Default:             stringdefault.StaticString("example value when not configured"),
+				Validators: []validator.String{
+					domainValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
 			},
 			"token": schema.StringAttribute{
+				MarkdownDescription: "The asset token. Set this to manage an existing asset instead of creating a new one.",
+				Optional:            true,
 				Computed:            true,
-				MarkdownDescription: "The generated asset token.",
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplaceIfConfigured(),
+				},
+			},
+			"team_token": schema.StringAttribute{
+				MarkdownDescription: "The token of the team owning the asset. Changing it moves the asset to the other team. " +
+					"Defaults to the `team_token` of the provider when the asset is created.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownOrNull{},
+				},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Tags attached to the asset.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"scan_frequency": schema.StringAttribute{
+				MarkdownDescription: "How often the asset is scanned. One of `daily`, `weekly`, `biweekly` or `monthly`. " +
+					"Uses the account default if not set. Conflicts with `scan_settings.scan_frequency`.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(scanFrequencies...),
+					stringvalidator.ConflictsWith(path.MatchRoot("scan_settings").AtName("scan_frequency")),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"criticality": schema.StringAttribute{
+				MarkdownDescription: "The business criticality of the asset. One of `low`, `medium`, `high` or `critical`. " +
+					"Left unchanged if not set.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(criticalities...),
+				},
+				PlanModifiers: []planmodifier.String{
+					useStateForUnknownOrNull{},
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Notes on the asset, such as why it is monitored.",
+				Optional:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The lifecycle status of the asset. One of `active`, `paused` or `archived`. " +
+					"An archived asset can only be restored to `active`. Left unchanged if not set.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(assetStatuses...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					assetStatusTransitionModifier{},
+				},
+			},
+			"metadata": schema.MapAttribute{
+				MarkdownDescription: "Key-value metadata of the asset. Changed keys are set and removed individually, " +
+					"rather than replacing all of the metadata.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1), metadataKeyValidator{}),
+				},
+			},
+			"references": schema.MapAttribute{
+				MarkdownDescription: "Identifiers of the asset in other systems, such as a CMDB ID or a ticket link, by the name of the system. " +
+					"They are stored in the metadata of the asset, under keys prefixed with `" + referenceMetadataPrefix + "`, " +
+					"which are not included in `metadata`. Names may contain letters, digits, `_`, `.` and `-`.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Validators: []validator.Map{
+					mapvalidator.KeysAre(stringvalidator.RegexMatches(referenceNamePattern,
+						"must start with a letter or digit, and only contain letters, digits, `_`, `.` and `-`")),
+					mapvalidator.ValueStringsAre(stringvalidator.LengthBetween(1, maxReferenceLength)),
+				},
+			},
+			"dns_records": schema.ListNestedAttribute{
+				MarkdownDescription: "DNS records discovered for the asset.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type": schema.StringAttribute{
+							MarkdownDescription: "The record type, such as `A` or `CNAME`.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the record.",
+							Computed:            true,
+						},
+						"value": schema.StringAttribute{
+							MarkdownDescription: "The value of the record.",
+							Computed:            true,
+						},
+						"ttl": schema.Int64Attribute{
+							MarkdownDescription: "The time to live of the record, in seconds.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"technologies": schema.ListNestedAttribute{
+				MarkdownDescription: "Technologies fingerprinted on the asset, such as web servers and frameworks.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the technology, such as `nginx`.",
+							Computed:            true,
+						},
+						"version": schema.StringAttribute{
+							MarkdownDescription: "The version of the technology. Null if it could not be determined.",
+							Computed:            true,
+						},
+						"category": schema.StringAttribute{
+							MarkdownDescription: "The category of the technology, such as `web-server` or `javascript-framework`.",
+							Computed:            true,
+						},
+					},
 				},
 			},
+			"last_scanned_at": schema.StringAttribute{
+				MarkdownDescription: "When the asset was last scanned, as an RFC 3339 timestamp. Null if the asset has never been scanned.",
+				Computed:            true,
+			},
+			"last_scan_status": schema.StringAttribute{
+				MarkdownDescription: "The status of the last scan of the asset. Null if the asset has never been scanned.",
+				Computed:            true,
+			},
+			"scan_settings": schema.SingleNestedAttribute{
+				MarkdownDescription: "The scan settings of the asset, as an alternative to the `scan_frequency` and `monitor_subdomains` attributes.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"scan_frequency": schema.StringAttribute{
+						MarkdownDescription: "How often the asset is scanned. One of `daily`, `weekly`, `biweekly` or `monthly`. " +
+							"Uses the account default if not set.",
+						Optional: true,
+						Computed: true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(scanFrequencies...),
+						},
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"monitoring_enabled": schema.BoolAttribute{
+						MarkdownDescription: "Whether subdomains discovered for the asset are monitored. Left unchanged if not set.",
+						Optional:            true,
+						Computed:            true,
+						PlanModifiers: []planmodifier.Bool{
+							boolplanmodifier.UseStateForUnknown(),
+						},
+					},
+					"test_categories": schema.SetAttribute{
+						MarkdownDescription: "The names of the test categories the asset is scanned with, " +
+							"as listed by the `detectify_test_categories` data source. Left unchanged if not set.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.Set{
+							setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+						},
+					},
+				},
+			},
+			"monitor_subdomains": schema.BoolAttribute{
+				MarkdownDescription: "Whether subdomains discovered for the asset are monitored. Left unchanged if not set. " +
+					"Conflicts with `scan_settings.monitoring_enabled`.",
+				Optional: true,
+				Computed: true,
+				Validators: []validator.Bool{
+					boolvalidator.ConflictsWith(path.MatchRoot("scan_settings").AtName("monitoring_enabled")),
+				},
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"subdomain_allowlist": schema.SetAttribute{
+				MarkdownDescription: "Discovered subdomains that are always monitored.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(hostnameValidator{}),
+				},
+			},
+			"subdomain_blocklist": schema.SetAttribute{
+				MarkdownDescription: "Discovered subdomains that are never monitored.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(hostnameValidator{}),
+				},
+			},
+			"scan_credentials": schema.SingleNestedAttribute{
+				MarkdownDescription: "The credentials the asset is scanned with, for authenticated scanning: " +
+					"either a `username` and `password`, or a `token`. The API never returns the password or token, " +
+					"so only a change of the username or type of credentials made outside of Terraform is detected. " +
+					"The secrets are stored in the state, so protect it accordingly.",
+				Optional: true,
+				Attributes: map[string]schema.Attribute{
+					"username": schema.StringAttribute{
+						MarkdownDescription: "The username to log in with. Requires `password`. Conflicts with `token`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+							stringvalidator.ExactlyOneOf(path.MatchRelative().AtParent().AtName("token")),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("password")),
+						},
+					},
+					"password": schema.StringAttribute{
+						MarkdownDescription: "The password to log in with. Requires `username`.",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+							stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("username")),
+						},
+					},
+					"token": schema.StringAttribute{
+						MarkdownDescription: "The bearer token to authenticate with. Conflicts with `username`.",
+						Optional:            true,
+						Sensitive:           true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				MarkdownDescription: "Whether to prevent the asset from being deleted. " +
+					"When `true`, destroying the resource fails until this is set to `false` and applied. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"adopt_existing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to manage an existing asset with the same domain instead of failing, " +
+					"if the asset already exists when the resource is created. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
 		},
 	}
 }
 
+func (r *AssetResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.AtLeastOneOf(
+			path.MatchRoot("domain"),
+			path.MatchRoot("token"),
+		),
+		assetTagsValidator{},
+	}
+}
+
 func (r *AssetResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	client, ok := req.ProviderData.(*http.Client)
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Resource Configure Type",
-			fmt.Sprintf("Expected *http.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
 	}
 
-	r.client = client
+	r.client = providerData.Client
+	r.teamToken = providerData.TeamToken
 }
 
 func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	ctx, warnings := withAPIWarnings(ctx)
+	defer func() { resp.Diagnostics.Append(apiWarningDiagnostics(warnings, assetWarningFields)...) }()
+
 	var data AssetResourceModel
 
 	// Read Terraform plan data into the model
@@ -97,27 +510,142 @@ func (r *AssetResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create example, got error: %s", err))
-	//     return
-	// }
+	body, diags := data.request(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var asset *Asset
+	var err error
+
+	if data.Token.IsUnknown() || data.Token.IsNull() {
+		body.TeamToken = r.teamToken
+		if !data.TeamToken.IsUnknown() && !data.TeamToken.IsNull() {
+			body.TeamToken = data.TeamToken.ValueString()
+		}
+
+		// The API may add the asset asynchronously, in which case the request is followed to completion.
+		asset, err = r.client.CreateAsset(WithRespondAsync(ctx), body)
+
+		// Another resource, or an earlier attempt, may have created the asset already.
+		if IsConflict(err) {
+			if !data.AdoptExisting.ValueBool() {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("domain"),
+					"Asset Already Exists",
+					fmt.Sprintf("An asset with the domain %q already exists. Import it, set its token, "+
+						"or set adopt_existing to true to manage it with this resource.", body.Name),
+				)
+				return
+			}
+
+			asset, err = r.adopt(ctx, body)
+		}
+
+		// Read the new asset back, so that it is readable by the time the apply finishes.
+		if err == nil {
+			asset, err = r.client.WaitForAsset(ctx, asset.Token)
+		}
+	} else {
+		// An existing asset is adopted by its token.
+		body.Name = ""
+		asset, err = r.client.UpdateAsset(ctx, data.Token.ValueString(), body)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create asset, got error: %s", err))
+		return
+	}
 
-	// For the purposes of this example code, hardcoding a response value to
-	// save into the Terraform state.
-	data.Id = types.StringValue("example-id")
+	// Save the asset into Terraform state before changing it further, so that it is tracked, as tainted,
+	// if a later request fails, rather than left behind unmanaged. The model keeps the planned values to apply.
+	created := data
+	resp.Diagnostics.Append(created.update(ctx, asset)...)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &created)...)
 
-	// Write logs using the tflog package
-	// Documentation: https://terraform.io/plugin/log
-	tflog.Trace(ctx, "created a resource")
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	asset, err = r.applyTeam(ctx, asset, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to transfer asset, got error: %s", err))
+		return
+	}
+
+	asset, err = r.applyStatus(ctx, asset, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change asset status, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.update(ctx, asset)...)
+
+	settings, err := r.applySettings(ctx, asset.Token, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update asset settings, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateSettings(ctx, settings)...)
+
+	monitoring, err := r.applySubdomainMonitoring(ctx, asset.Token, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update subdomain monitoring, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateSubdomainMonitoring(ctx, monitoring)...)
+
+	metadata, err := r.applyMetadata(ctx, asset.Token, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update asset metadata, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateMetadata(ctx, metadata)...)
+
+	credentials, err := r.applyScanCredentials(ctx, asset.Token, &data, types.ObjectNull(assetScanCredentialsAttrTypes))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update scan credentials, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateScanCredentials(ctx, credentials)...)
+
+	tflog.Trace(ctx, "created an asset", map[string]any{"token": asset.Token})
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// adopt updates the existing asset with the domain of the request, for an asset that could not be created
+// because it already exists.
+func (r *AssetResource) adopt(ctx context.Context, body AssetRequest) (*Asset, error) {
+	existing, err := r.client.FindAssetByName(ctx, body.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing == nil {
+		return nil, fmt.Errorf("the asset %q already exists, but was not found among the assets available to the API key", body.Name)
+	}
+
+	tflog.Info(ctx, "Adopting existing asset", map[string]any{"token": existing.Token, "domain": existing.Name})
+
+	token := existing.Token
+	body.Name = ""
+	body.TeamToken = ""
+
+	return r.client.UpdateAsset(ctx, token, body)
+}
+
 func (r *AssetResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	ctx, warnings := withAPIWarnings(ctx)
+	defer func() { resp.Diagnostics.Append(apiWarningDiagnostics(warnings, assetWarningFields)...) }()
+
 	var data AssetResourceModel
 
 	// Read Terraform prior state data into the model
@@ -127,35 +655,149 @@ func (r *AssetResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read example, got error: %s", err))
-	//     return
-	// }
+	asset, err := r.client.GetAsset(ctx, data.Token.ValueString())
+	if IsNotFound(err) {
+		tflog.Warn(ctx, "asset not found, removing from state", map[string]any{"token": data.Token.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read asset, got error: %s", err))
+		return
+	}
+
+	// Deletion protection and adoption only exist in Terraform, so they are not known when importing.
+	if data.DeletionProtection.IsNull() {
+		data.DeletionProtection = types.BoolValue(false)
+	}
+
+	if data.AdoptExisting.IsNull() {
+		data.AdoptExisting = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(data.update(ctx, asset)...)
+
+	settings, err := r.client.GetAssetSettings(ctx, asset.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read asset settings, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateSettings(ctx, settings)...)
+
+	monitoring, err := r.client.GetSubdomainMonitoring(ctx, asset.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read subdomain monitoring, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateSubdomainMonitoring(ctx, monitoring)...)
+
+	metadata, err := r.client.GetAssetMetadata(ctx, asset.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read asset metadata, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateMetadata(ctx, metadata)...)
+
+	credentials, err := r.client.GetScanCredentials(ctx, asset.Token)
+	if IsNotFound(err) {
+		credentials, err = nil, nil
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read scan credentials, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateScanCredentials(ctx, credentials)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *AssetResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data AssetResourceModel
+	ctx, warnings := withAPIWarnings(ctx)
+	defer func() { resp.Diagnostics.Append(apiWarningDiagnostics(warnings, assetWarningFields)...) }()
 
-	// Read Terraform plan data into the model
+	var data, state AssetResourceModel
+
+	// Read Terraform plan data and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := data.patchRequest(ctx, &state)
+	resp.Diagnostics.Append(diags...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update example, got error: %s", err))
-	//     return
-	// }
+	// Only the changed attributes are sent, so that attributes changed elsewhere in the meantime are not overwritten.
+	var asset *Asset
+	var err error
+	if body.empty() {
+		asset, err = r.client.GetAsset(ctx, data.Token.ValueString())
+	} else {
+		asset, err = r.client.PatchAsset(ctx, data.Token.ValueString(), body)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update asset, got error: %s", err))
+		return
+	}
+
+	asset, err = r.applyTeam(ctx, asset, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to transfer asset, got error: %s", err))
+		return
+	}
+
+	asset, err = r.applyStatus(ctx, asset, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to change asset status, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.update(ctx, asset)...)
+
+	settings, err := r.applySettings(ctx, asset.Token, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update asset settings, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateSettings(ctx, settings)...)
+
+	monitoring, err := r.applySubdomainMonitoring(ctx, asset.Token, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update subdomain monitoring, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateSubdomainMonitoring(ctx, monitoring)...)
+
+	metadata, err := r.applyMetadata(ctx, asset.Token, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update asset metadata, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateMetadata(ctx, metadata)...)
+
+	credentials, err := r.applyScanCredentials(ctx, asset.Token, &data, state.ScanCredentials)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update scan credentials, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateScanCredentials(ctx, credentials)...)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -171,15 +813,653 @@ func (r *AssetResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
-	// If applicable, this is a great opportunity to initialize any necessary
-	// provider client data and make a call using it.
-	// httpResp, err := r.client.Do(httpReq)
-	// if err != nil {
-	//     resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete example, got error: %s", err))
-	//     return
-	// }
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("deletion_protection"),
+			"Asset Protected",
+			fmt.Sprintf("The asset %q has deletion protection enabled. "+
+				"Set deletion_protection to false and apply the change before destroying the asset.", data.Domain.ValueString()),
+		)
+		return
+	}
+
+	// The API may delete the asset asynchronously, in which case the request is followed to completion.
+	err := r.client.DeleteAsset(WithRespondAsync(ctx), data.Token.ValueString())
+	if err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete asset, got error: %s", err))
+		return
+	}
+}
+
+// ModifyPlan plans the settings that can be configured both as top-level attributes and in scan_settings,
+// so that a setting changed by one of them is planned for the other too.
+func (r *AssetResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to plan when the asset is destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config AssetResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	settings, ok, diags := plan.scanSettings(ctx)
+	resp.Diagnostics.Append(diags...)
+	if !ok || resp.Diagnostics.HasError() {
+		return
+	}
+
+	configured, _, diags := config.scanSettings(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	switch {
+	case !configured.ScanFrequency.IsNull():
+		plan.ScanFrequency = configured.ScanFrequency
+	case !config.ScanFrequency.IsNull():
+		settings.ScanFrequency = config.ScanFrequency
+	}
+
+	switch {
+	case !configured.MonitoringEnabled.IsNull():
+		plan.MonitorSubdomains = configured.MonitoringEnabled
+	case !config.MonitorSubdomains.IsNull():
+		settings.MonitoringEnabled = config.MonitorSubdomains
+	}
+
+	plan.ScanSettings, diags = types.ObjectValueFrom(ctx, assetScanSettingsAttrTypes, settings)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
 }
 
 func (r *AssetResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	resource.ImportStatePassthroughID(ctx, path.Root("token"), req, resp)
+}
+
+// applyTeam moves the asset to the team in the model if it is set and differs,
+// and returns the resulting asset.
+func (r *AssetResource) applyTeam(ctx context.Context, asset *Asset, data *AssetResourceModel) (*Asset, error) {
+	if data.TeamToken.IsUnknown() || data.TeamToken.IsNull() || data.TeamToken.ValueString() == asset.TeamToken {
+		return asset, nil
+	}
+
+	tflog.Debug(ctx, "Transferring asset", map[string]any{"token": asset.Token, "from": asset.TeamToken, "to": data.TeamToken.ValueString()})
+
+	return r.client.TransferAsset(ctx, asset.Token, data.TeamToken.ValueString())
+}
+
+// applyStatus moves the asset to the status in the model if it is set and differs,
+// and returns the resulting asset.
+func (r *AssetResource) applyStatus(ctx context.Context, asset *Asset, data *AssetResourceModel) (*Asset, error) {
+	if data.Status.IsUnknown() || data.Status.IsNull() || data.Status.ValueString() == asset.Status {
+		return asset, nil
+	}
+
+	tflog.Debug(ctx, "Changing asset status", map[string]any{"token": asset.Token, "from": asset.Status, "to": data.Status.ValueString()})
+
+	return r.client.SetAssetStatus(ctx, asset.Token, data.Status.ValueString())
+}
+
+// applySettings updates the scan settings of the asset if any are set in the model,
+// and returns the current settings.
+func (r *AssetResource) applySettings(ctx context.Context, token string, data *AssetResourceModel) (*AssetSettings, error) {
+	frequency := data.ScanFrequency
+	var categories *[]string
+
+	settings, ok, diags := data.scanSettings(ctx)
+	if diags.HasError() {
+		return nil, fmt.Errorf("reading scan settings: %v", diags)
+	}
+
+	if ok {
+		if !settings.ScanFrequency.IsUnknown() && !settings.ScanFrequency.IsNull() {
+			frequency = settings.ScanFrequency
+		}
+
+		if !settings.TestCategories.IsNull() {
+			names := []string{}
+			if diags := settings.TestCategories.ElementsAs(ctx, &names, false); diags.HasError() {
+				return nil, fmt.Errorf("reading test categories: %v", diags)
+			}
+			categories = &names
+		}
+	}
+
+	if (frequency.IsUnknown() || frequency.IsNull()) && categories == nil {
+		return r.client.GetAssetSettings(ctx, token)
+	}
+
+	return r.client.UpdateAssetSettings(ctx, token, AssetSettings{
+		ScanFrequency:  frequency.ValueString(),
+		TestCategories: categories,
+	})
+}
+
+// applySubdomainMonitoring updates the subdomain monitoring of the asset if it differs from the model,
+// and returns the current configuration. Lists that are not set in the model are cleared.
+func (r *AssetResource) applySubdomainMonitoring(ctx context.Context, token string, data *AssetResourceModel) (*SubdomainMonitoring, error) {
+	current, err := r.client.GetSubdomainMonitoring(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := SubdomainMonitoring{
+		Enabled:   current.Enabled,
+		Allowlist: []string{},
+		Blocklist: []string{},
+	}
+
+	if !data.MonitorSubdomains.IsUnknown() && !data.MonitorSubdomains.IsNull() {
+		desired.Enabled = data.MonitorSubdomains.ValueBool()
+	}
+
+	settings, ok, diags := data.scanSettings(ctx)
+	if ok && !settings.MonitoringEnabled.IsUnknown() && !settings.MonitoringEnabled.IsNull() {
+		desired.Enabled = settings.MonitoringEnabled.ValueBool()
+	}
+
+	diags.Append(data.SubdomainAllowlist.ElementsAs(ctx, &desired.Allowlist, false)...)
+	diags.Append(data.SubdomainBlocklist.ElementsAs(ctx, &desired.Blocklist, false)...)
+	if diags.HasError() {
+		return nil, fmt.Errorf("reading subdomain lists: %v", diags)
+	}
+
+	if desired.Enabled == current.Enabled && sameStrings(desired.Allowlist, current.Allowlist) && sameStrings(desired.Blocklist, current.Blocklist) {
+		return current, nil
+	}
+
+	return r.client.UpdateSubdomainMonitoring(ctx, token, desired)
+}
+
+// applyMetadata sets and removes the metadata keys of the asset that differ from the model, one key at a time,
+// and returns the resulting metadata. All keys are removed if the metadata is not set in the model.
+func (r *AssetResource) applyMetadata(ctx context.Context, token string, data *AssetResourceModel) (map[string]string, error) {
+	current, err := r.client.GetAssetMetadata(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := map[string]string{}
+	if diags := data.Metadata.ElementsAs(ctx, &desired, false); diags.HasError() {
+		return nil, fmt.Errorf("reading metadata: %v", diags)
+	}
+
+	references := map[string]string{}
+	if diags := data.References.ElementsAs(ctx, &references, false); diags.HasError() {
+		return nil, fmt.Errorf("reading references: %v", diags)
+	}
+
+	for name, value := range references {
+		desired[referenceMetadataPrefix+name] = value
+	}
+
+	for key, value := range desired {
+		if existing, ok := current[key]; ok && existing == value {
+			continue
+		}
+
+		if err := r.client.SetAssetMetadata(ctx, token, key, value); err != nil {
+			return nil, fmt.Errorf("setting %q: %w", key, err)
+		}
+
+		current[key] = value
+	}
+
+	for key := range current {
+		if _, ok := desired[key]; ok {
+			continue
+		}
+
+		if err := r.client.DeleteAssetMetadata(ctx, token, key); err != nil && !IsNotFound(err) {
+			return nil, fmt.Errorf("removing %q: %w", key, err)
+		}
+
+		delete(current, key)
+	}
+
+	return current, nil
+}
+
+// applyScanCredentials sets the scan credentials of the asset if they differ from the prior credentials,
+// or removes them if they are not set in the model, and returns the current credentials, nil if there are none.
+// The API does not return the secrets, so they are compared with the prior state instead of the API.
+func (r *AssetResource) applyScanCredentials(ctx context.Context, token string, data *AssetResourceModel, prior types.Object) (*ScanCredentials, error) {
+	if data.ScanCredentials.IsNull() {
+		if prior.IsNull() {
+			return nil, nil
+		}
+
+		if err := r.client.DeleteScanCredentials(ctx, token); err != nil && !IsNotFound(err) {
+			return nil, err
+		}
+
+		return nil, nil
+	}
+
+	var desired assetScanCredentialsModel
+	if diags := data.ScanCredentials.As(ctx, &desired, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return nil, fmt.Errorf("reading scan credentials: %v", diags)
+	}
+
+	body := ScanCredentialsRequest{
+		Type:     ScanCredentialsTypeBasic,
+		Username: desired.Username.ValueString(),
+		Password: desired.Password.ValueString(),
+	}
+	if !desired.Token.IsNull() {
+		body = ScanCredentialsRequest{Type: ScanCredentialsTypeToken, Token: desired.Token.ValueString()}
+	}
+
+	// The secrets are never logged, even by requests that fail.
+	ctx = tflog.MaskAllFieldValuesStrings(ctx, body.secrets()...)
+	ctx = tflog.MaskMessageStrings(ctx, body.secrets()...)
+
+	if data.ScanCredentials.Equal(prior) {
+		credentials, err := r.client.GetScanCredentials(ctx, token)
+		if err == nil || !IsNotFound(err) {
+			return credentials, err
+		}
+
+		// The credentials were removed outside of Terraform, so they are set again.
+	}
+
+	credentials, err := r.client.SetScanCredentials(ctx, token, body)
+	if err != nil {
+		return nil, errors.New(redactSecrets(err.Error(), body.secrets()))
+	}
+
+	return credentials, nil
+}
+
+// secrets returns the secrets of the request that are set.
+func (r ScanCredentialsRequest) secrets() []string {
+	var secrets []string
+	for _, s := range []string{r.Password, r.Token} {
+		if s != "" {
+			secrets = append(secrets, s)
+		}
+	}
+
+	return secrets
+}
+
+// redactSecrets returns s with the secrets replaced, such as in an error echoing the request.
+func redactSecrets(s string, secrets []string) string {
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, "***")
+	}
+
+	return s
+}
+
+// sameStrings reports whether a and b hold the same strings, regardless of order.
+func sameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	counts := make(map[string]int, len(a))
+	for _, s := range a {
+		counts[s]++
+	}
+
+	for _, s := range b {
+		counts[s]--
+		if counts[s] < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// request builds the API request body from the model.
+func (m *AssetResourceModel) request(ctx context.Context) (AssetRequest, diag.Diagnostics) {
+	body := AssetRequest{
+		Name:        m.Domain.ValueString(),
+		Tags:        []string{},
+		Criticality: m.Criticality.ValueString(),
+		Notes:       m.Description.ValueString(),
+	}
+
+	diags := m.Tags.ElementsAs(ctx, &body.Tags, false)
+
+	return body, diags
+}
+
+// patchRequest returns the request changing the attributes of the asset that differ from state.
+func (m *AssetResourceModel) patchRequest(ctx context.Context, state *AssetResourceModel) (AssetPatchRequest, diag.Diagnostics) {
+	var body AssetPatchRequest
+	var diags diag.Diagnostics
+
+	if !m.Tags.Equal(state.Tags) {
+		tags := []string{}
+		diags.Append(m.Tags.ElementsAs(ctx, &tags, false)...)
+		body.Tags = &tags
+	}
+
+	// The criticality is left unchanged if not set.
+	if m.Criticality.ValueString() != "" && !m.Criticality.Equal(state.Criticality) {
+		criticality := m.Criticality.ValueString()
+		body.Criticality = &criticality
+	}
+
+	if m.Description.ValueString() != state.Description.ValueString() {
+		notes := m.Description.ValueString()
+		body.Notes = &notes
+	}
+
+	return body, diags
+}
+
+// update sets the model values from the API representation of the asset.
+func (m *AssetResourceModel) update(ctx context.Context, asset *Asset) diag.Diagnostics {
+	m.Domain = types.StringValue(asset.Name)
+	m.Token = types.StringValue(asset.Token)
+
+	var diags diag.Diagnostics
+	m.Tags = stringSetValue(ctx, m.Tags, asset.Tags, &diags)
+
+	records, d := dnsRecordsValue(ctx, asset.DNSRecords)
+	diags.Append(d...)
+	m.DNSRecords = records
+
+	technologies, d := technologiesValue(ctx, asset.Technologies)
+	diags.Append(d...)
+	m.Technologies = technologies
+
+	m.Criticality = types.StringNull()
+	if asset.Criticality != "" {
+		m.Criticality = types.StringValue(asset.Criticality)
+	}
+
+	// The API does not distinguish unset notes from empty ones, so keep whichever is in the model.
+	if asset.Notes != "" || !m.Description.IsNull() {
+		m.Description = types.StringValue(asset.Notes)
+	}
+
+	m.TeamToken = types.StringNull()
+	if asset.TeamToken != "" {
+		m.TeamToken = types.StringValue(asset.TeamToken)
+	}
+
+	m.Status = types.StringNull()
+	if asset.Status != "" {
+		m.Status = types.StringValue(asset.Status)
+	}
+
+	m.LastScannedAt = types.StringPointerValue(asset.LastScannedAt)
+	m.LastScanStatus = types.StringPointerValue(asset.LastScanStatus)
+
+	return diags
+}
+
+// scanSettings returns the scan_settings of the model, and whether they are set.
+func (m *AssetResourceModel) scanSettings(ctx context.Context) (assetScanSettingsModel, bool, diag.Diagnostics) {
+	var settings assetScanSettingsModel
+	if m.ScanSettings.IsNull() || m.ScanSettings.IsUnknown() {
+		return settings, false, nil
+	}
+
+	diags := m.ScanSettings.As(ctx, &settings, basetypes.ObjectAsOptions{})
+
+	return settings, true, diags
+}
+
+// setScanSettings sets the scan_settings of the model.
+func (m *AssetResourceModel) setScanSettings(ctx context.Context, settings assetScanSettingsModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.ScanSettings, diags = types.ObjectValueFrom(ctx, assetScanSettingsAttrTypes, settings)
+
+	return diags
+}
+
+// updateSettings sets the model values from the API representation of the asset settings.
+// The scan_settings are only set if they are set in the model, and their test categories only if set in them.
+func (m *AssetResourceModel) updateSettings(ctx context.Context, settings *AssetSettings) diag.Diagnostics {
+	m.ScanFrequency = types.StringValue(settings.ScanFrequency)
+
+	scanSettings, ok, diags := m.scanSettings(ctx)
+	if !ok || diags.HasError() {
+		return diags
+	}
+
+	scanSettings.ScanFrequency = types.StringValue(settings.ScanFrequency)
+
+	if !scanSettings.TestCategories.IsNull() {
+		categories := []string{}
+		if settings.TestCategories != nil {
+			categories = *settings.TestCategories
+		}
+
+		var d diag.Diagnostics
+		scanSettings.TestCategories, d = types.SetValueFrom(ctx, types.StringType, categories)
+		diags.Append(d...)
+	}
+
+	diags.Append(m.setScanSettings(ctx, scanSettings)...)
+
+	return diags
+}
+
+// updateSubdomainMonitoring sets the model values from the API representation of the subdomain monitoring.
+func (m *AssetResourceModel) updateSubdomainMonitoring(ctx context.Context, monitoring *SubdomainMonitoring) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.MonitorSubdomains = types.BoolValue(monitoring.Enabled)
+	m.SubdomainAllowlist = stringSetValue(ctx, m.SubdomainAllowlist, monitoring.Allowlist, &diags)
+	m.SubdomainBlocklist = stringSetValue(ctx, m.SubdomainBlocklist, monitoring.Blocklist, &diags)
+
+	if scanSettings, ok, d := m.scanSettings(ctx); ok {
+		diags.Append(d...)
+		scanSettings.MonitoringEnabled = types.BoolValue(monitoring.Enabled)
+		diags.Append(m.setScanSettings(ctx, scanSettings)...)
+	}
+
+	return diags
+}
+
+// updateScanCredentials sets the model values from the API representation of the scan credentials, nil if there
+// are none. The secrets are not returned by the API, so the secrets of the model are kept if the type of
+// credentials is unchanged.
+func (m *AssetResourceModel) updateScanCredentials(ctx context.Context, credentials *ScanCredentials) diag.Diagnostics {
+	if credentials == nil {
+		m.ScanCredentials = types.ObjectNull(assetScanCredentialsAttrTypes)
+		return nil
+	}
+
+	current := assetScanCredentialsModel{
+		Username: types.StringNull(),
+		Password: types.StringNull(),
+		Token:    types.StringNull(),
+	}
+
+	var diags diag.Diagnostics
+	if !m.ScanCredentials.IsNull() && !m.ScanCredentials.IsUnknown() {
+		diags.Append(m.ScanCredentials.As(ctx, &current, basetypes.ObjectAsOptions{})...)
+	}
+
+	switch credentials.Type {
+	case ScanCredentialsTypeToken:
+		current.Username = types.StringNull()
+		current.Password = types.StringNull()
+	default:
+		if !current.Username.Equal(types.StringValue(credentials.Username)) {
+			current.Password = types.StringNull()
+		}
+		current.Username = types.StringValue(credentials.Username)
+		current.Token = types.StringNull()
+	}
+
+	value, d := types.ObjectValueFrom(ctx, assetScanCredentialsAttrTypes, current)
+	diags.Append(d...)
+	m.ScanCredentials = value
+
+	return diags
+}
+
+// updateMetadata sets the model values from the API representation of the asset metadata, with the
+// references split from the other keys, keeping a null map null when it would be empty.
+func (m *AssetResourceModel) updateMetadata(ctx context.Context, metadata map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	others := map[string]string{}
+	references := map[string]string{}
+	for key, value := range metadata {
+		if name, ok := strings.CutPrefix(key, referenceMetadataPrefix); ok {
+			references[name] = value
+		} else {
+			others[key] = value
+		}
+	}
+
+	if len(others) > 0 || !m.Metadata.IsNull() {
+		var d diag.Diagnostics
+		m.Metadata, d = types.MapValueFrom(ctx, types.StringType, others)
+		diags.Append(d...)
+	}
+
+	if len(references) > 0 || !m.References.IsNull() {
+		var d diag.Diagnostics
+		m.References, d = types.MapValueFrom(ctx, types.StringType, references)
+		diags.Append(d...)
+	}
+
+	return diags
+}
+
+// referenceMetadataPrefix is the prefix of the metadata keys holding the references of an asset.
+const referenceMetadataPrefix = "ref:"
+
+// maxReferenceLength is the maximum length of a reference, long enough for a link.
+const maxReferenceLength = 2048
+
+// referenceNamePattern matches the names of references, such as `cmdb` or `jira-ticket`.
+var referenceNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+var _ validator.String = metadataKeyValidator{}
+
+// metadataKeyValidator forbids metadata keys that are reserved for references.
+type metadataKeyValidator struct{}
+
+func (v metadataKeyValidator) Description(ctx context.Context) string {
+	return "keys must not start with `" + referenceMetadataPrefix + "`, which is reserved for references"
+}
+
+func (v metadataKeyValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v metadataKeyValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() || !strings.HasPrefix(req.ConfigValue.ValueString(), referenceMetadataPrefix) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Metadata Key",
+		fmt.Sprintf("Metadata key %q starts with %q, which is reserved for keys set with references.", req.ConfigValue.ValueString(), referenceMetadataPrefix),
+	)
+}
+
+// assetTagsValidator forbids empty strings in the tags of an asset.
+type assetTagsValidator struct{}
+
+func (v assetTagsValidator) Description(ctx context.Context) string {
+	return "tags must not contain empty strings"
+}
+
+func (v assetTagsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v assetTagsValidator) ValidateResource(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var tags types.Set
+
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("tags"), &tags)...)
+
+	if resp.Diagnostics.HasError() || tags.IsNull() || tags.IsUnknown() {
+		return
+	}
+
+	for _, elem := range tags.Elements() {
+		tag, ok := elem.(types.String)
+		if !ok || tag.IsUnknown() || tag.IsNull() || tag.ValueString() != "" {
+			continue
+		}
+
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tags").AtSetValue(elem),
+			"Invalid Asset Tag",
+			"Tags must not be empty strings.",
+		)
+	}
+}
+
+var _ planmodifier.String = useStateForUnknownOrNull{}
+
+// useStateForUnknownOrNull is like stringplanmodifier.UseStateForUnknown, but also keeps a null prior value,
+// for computed attributes the API may leave unset, such as the criticality of an asset. Otherwise they
+// are planned as unknown whenever the asset is updated. Only attributes the API does not change by itself
+// may use it: a value changed between the plan and the apply, such as by a scan, fails the apply.
+type useStateForUnknownOrNull struct{}
+
+func (m useStateForUnknownOrNull) Description(ctx context.Context) string {
+	return "the value, including a null value, is kept from the prior state unless configured"
+}
+
+func (m useStateForUnknownOrNull) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m useStateForUnknownOrNull) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	// There is no prior value to keep when the asset is created.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	if !req.PlanValue.IsUnknown() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	resp.PlanValue = req.StateValue
+}
+
+var _ planmodifier.String = assetStatusTransitionModifier{}
+
+// assetStatusTransitionModifier fails the plan if it moves the asset to a status the API cannot move it to
+// from its current status.
+type assetStatusTransitionModifier struct{}
+
+func (m assetStatusTransitionModifier) Description(ctx context.Context) string {
+	return "the status must be reachable from the current status of the asset"
+}
+
+func (m assetStatusTransitionModifier) MarkdownDescription(ctx context.Context) string {
+	return m.Description(ctx)
+}
+
+func (m assetStatusTransitionModifier) PlanModifyString(ctx context.Context, req planmodifier.StringRequest, resp *planmodifier.StringResponse) {
+	if req.StateValue.IsNull() || req.StateValue.IsUnknown() || req.PlanValue.IsNull() || req.PlanValue.IsUnknown() {
+		return
+	}
+
+	from, to := req.StateValue.ValueString(), req.PlanValue.ValueString()
+	if canTransitionAssetStatus(from, to) {
+		return
+	}
+
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Asset Status Transition",
+		fmt.Sprintf("An asset with the status %q can only be moved to %s, got: %q.", from, strings.Join(assetStatusTransitions[from], " or "), to),
+	)
 }
@@ -0,0 +1,1869 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+// assetConfig returns a configuration for the asset resource, with unset attributes as null.
+func assetConfig(t *testing.T, values map[string]tftypes.Value) tfsdk.Config {
+	t.Helper()
+
+	var resp fwresource.SchemaResponse
+	provider.NewAssetResource().Schema(context.Background(), fwresource.SchemaRequest{}, &resp)
+	require.False(t, resp.Diagnostics.HasError())
+
+	return tfsdk.Config{
+		Schema: resp.Schema,
+		Raw:    schemaValue(t, resp.Schema, values),
+	}
+}
+
+func validateAssetConfig(t *testing.T, config tfsdk.Config) *fwresource.ValidateConfigResponse {
+	t.Helper()
+
+	ctx := context.Background()
+	r, ok := provider.NewAssetResource().(fwresource.ResourceWithConfigValidators)
+	require.True(t, ok)
+
+	resp := &fwresource.ValidateConfigResponse{}
+	for _, v := range r.ConfigValidators(ctx) {
+		v.ValidateResource(ctx, fwresource.ValidateConfigRequest{Config: config}, resp)
+	}
+
+	return resp
+}
+
+func TestAssetResourceConfigValidators(t *testing.T) {
+	tests := map[string]struct {
+		values      map[string]tftypes.Value
+		expectError string
+	}{
+		"domain": {
+			values: map[string]tftypes.Value{
+				"domain": tftypes.NewValue(tftypes.String, "example.com"),
+			},
+		},
+		"token": {
+			values: map[string]tftypes.Value{
+				"token": tftypes.NewValue(tftypes.String, "5bd9a6d8cbf4b9a1e2f4f5b2b5b1f2a3"),
+			},
+		},
+		"neither domain nor token": {
+			values:      map[string]tftypes.Value{},
+			expectError: "Missing Attribute Configuration",
+		},
+		"tags": {
+			values: map[string]tftypes.Value{
+				"domain": tftypes.NewValue(tftypes.String, "example.com"),
+				"tags":   stringSet("production", "web"),
+			},
+		},
+		"empty tag": {
+			values: map[string]tftypes.Value{
+				"domain": tftypes.NewValue(tftypes.String, "example.com"),
+				"tags":   stringSet("production", ""),
+			},
+			expectError: "Invalid Asset Tag",
+		},
+		"unknown tags": {
+			values: map[string]tftypes.Value{
+				"domain": tftypes.NewValue(tftypes.String, "example.com"),
+				"tags":   tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, tftypes.UnknownValue),
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := validateAssetConfig(t, assetConfig(t, test.values))
+
+			if test.expectError == "" {
+				require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+				return
+			}
+
+			require.True(t, resp.Diagnostics.HasError())
+			require.Equal(t, test.expectError, resp.Diagnostics.Errors()[0].Summary())
+		})
+	}
+}
+
+func TestAccAssetResourceScanFrequency(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain         = "example.com"
+  scan_frequency = "hourly"
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_frequency", "weekly"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain         = "example.com"
+  scan_frequency = "daily"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_frequency", "daily"),
+				),
+			},
+			{
+				// Changes made outside of Terraform are reconciled.
+				PreConfig: func() { api.setScanFrequency("example.com", "monthly") },
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain         = "example.com"
+  scan_frequency = "daily"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_frequency", "daily"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceSubdomainMonitoring(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain              = "example.com"
+  subdomain_allowlist = ["not a hostname"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Host`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "monitor_subdomains", "false"),
+					resource.TestCheckNoResourceAttr("detectify_asset.test", "subdomain_allowlist"),
+					resource.TestCheckNoResourceAttr("detectify_asset.test", "subdomain_blocklist"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain              = "example.com"
+  monitor_subdomains  = true
+  subdomain_allowlist = ["www.example.com", "api.example.com"]
+  subdomain_blocklist = ["staging.example.com"]
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "monitor_subdomains", "true"),
+					resource.TestCheckTypeSetElemAttr("detectify_asset.test", "subdomain_allowlist.*", "www.example.com"),
+					resource.TestCheckTypeSetElemAttr("detectify_asset.test", "subdomain_allowlist.*", "api.example.com"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "subdomain_blocklist.#", "1"),
+				),
+			},
+			{
+				// Lists are reconciled as sets, so reordering is not a change.
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain              = "example.com"
+  monitor_subdomains  = true
+  subdomain_allowlist = ["api.example.com", "www.example.com"]
+  subdomain_blocklist = ["staging.example.com"]
+}
+`,
+				PlanOnly: true,
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain              = "example.com"
+  monitor_subdomains  = false
+  subdomain_allowlist = ["api.example.com"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "monitor_subdomains", "false"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "subdomain_allowlist.#", "1"),
+					resource.TestCheckNoResourceAttr("detectify_asset.test", "subdomain_blocklist"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceMetadata(t *testing.T) {
+	api := newFakeAPI(t)
+
+	// checkWrites checks the number of metadata keys set or removed since the previous check.
+	writes := 0
+	checkWrites := func(want int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			api.mu.Lock()
+			defer api.mu.Unlock()
+
+			if got := api.metadataWrites - writes; got != want {
+				return fmt.Errorf("expected %d metadata writes, got %d", want, got)
+			}
+			writes = api.metadataWrites
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkAssetsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain   = "example.com"
+  metadata = { "" = "empty" }
+}
+`,
+				ExpectError: regexp.MustCompile(`string length must be at least 1`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("detectify_asset.test", "metadata"),
+					checkWrites(0),
+				),
+			},
+			{
+				// Keys and values are escaped, so they may contain any characters.
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+  metadata = {
+    environment  = "production"
+    "team/owner" = "platform & security"
+    cost_center  = "4711"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "metadata.%", "3"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "metadata.team/owner", "platform & security"),
+					checkWrites(3),
+				),
+			},
+			{
+				// Only the changed key is written.
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+  metadata = {
+    environment  = "staging"
+    "team/owner" = "platform & security"
+    cost_center  = "4711"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "metadata.environment", "staging"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "metadata.cost_center", "4711"),
+					checkWrites(1),
+				),
+			},
+			{
+				// Only the removed key is written.
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+  metadata = {
+    environment  = "staging"
+    "team/owner" = "platform & security"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "metadata.%", "2"),
+					resource.TestCheckNoResourceAttr("detectify_asset.test", "metadata.cost_center"),
+					checkWrites(1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceReferences(t *testing.T) {
+	api := newFakeAPI(t)
+
+	// metadata returns the metadata of the asset in the fake API.
+	metadata := func() map[string]string {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		for token, asset := range api.assets {
+			if asset.Name == "example.com" {
+				return maps.Clone(api.metadata[token])
+			}
+		}
+		return nil
+	}
+
+	checkMetadata := func(want map[string]string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if got := metadata(); !maps.Equal(got, want) {
+				return fmt.Errorf("expected metadata %v, got %v", want, got)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkAssetsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain     = "example.com"
+  references = { "cmdb id" = "CI0012345" }
+}
+`,
+				ExpectError: regexp.MustCompile(`must start with a letter or digit`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain   = "example.com"
+  metadata = { "ref:cmdb" = "CI0012345" }
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Metadata Key`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain   = "example.com"
+  metadata = { owner = "web-team" }
+  references = {
+    cmdb   = "CI0012345"
+    ticket = "https://jira.example.com/browse/SEC-1"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "metadata.%", "1"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "references.%", "2"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "references.cmdb", "CI0012345"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "references.ticket", "https://jira.example.com/browse/SEC-1"),
+					checkMetadata(map[string]string{
+						"owner":      "web-team",
+						"ref:cmdb":   "CI0012345",
+						"ref:ticket": "https://jira.example.com/browse/SEC-1",
+					}),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain   = "example.com"
+  metadata = { owner = "web-team" }
+  references = {
+    cmdb = "CI0067890"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "references.%", "1"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "references.cmdb", "CI0067890"),
+					checkMetadata(map[string]string{"owner": "web-team", "ref:cmdb": "CI0067890"}),
+				),
+			},
+			{
+				// A reference changed outside of Terraform is reconciled.
+				PreConfig: func() {
+					api.mu.Lock()
+					defer api.mu.Unlock()
+
+					for token, asset := range api.assets {
+						if asset.Name == "example.com" {
+							api.metadata[token]["ref:cmdb"] = "CI0000000"
+							api.metadata[token]["ref:other"] = "unmanaged"
+						}
+					}
+				},
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain   = "example.com"
+  metadata = { owner = "web-team" }
+  references = {
+    cmdb = "CI0067890"
+  }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: checkMetadata(map[string]string{"owner": "web-team", "ref:cmdb": "CI0067890"}),
+			},
+			{
+				// Clearing the references keeps the other metadata.
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain   = "example.com"
+  metadata = { owner = "web-team" }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("detectify_asset.test", "references"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "metadata.%", "1"),
+					checkMetadata(map[string]string{"owner": "web-team"}),
+				),
+			},
+		},
+	})
+}
+
+// expectSensitive is a plan check that the attribute at the path of a resource is marked as sensitive in the plan,
+// so that Terraform redacts it from the plan output.
+type expectSensitive struct {
+	address string
+	path    []string
+}
+
+func (e expectSensitive) CheckPlan(ctx context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	for _, rc := range req.Plan.ResourceChanges {
+		if rc.Address != e.address {
+			continue
+		}
+
+		value := rc.Change.AfterSensitive
+		for _, name := range e.path {
+			attrs, ok := value.(map[string]any)
+			if !ok {
+				value = nil
+				break
+			}
+			value = attrs[name]
+		}
+
+		if value != true {
+			resp.Error = fmt.Errorf("%s.%s is not sensitive in the plan", e.address, strings.Join(e.path, "."))
+		}
+		return
+	}
+
+	resp.Error = fmt.Errorf("%s not found in the plan", e.address)
+}
+
+// beforeApply is a plan check calling the function once the plan is made, to change the API
+// between the plan and the apply, such as by a scan finishing.
+type beforeApply func()
+
+func (f beforeApply) CheckPlan(ctx context.Context, req plancheck.CheckPlanRequest, resp *plancheck.CheckPlanResponse) {
+	f()
+}
+
+func TestAccAssetResourceScanCredentials(t *testing.T) {
+	api := newFakeAPI(t)
+
+	// credentials returns the scan credentials of the asset in the fake API, nil if it has none.
+	credentials := func() *provider.ScanCredentialsRequest {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		for token, asset := range api.assets {
+			if asset.Name == "example.com" {
+				return api.credentials[token]
+			}
+		}
+		return nil
+	}
+
+	checkCredentials := func(want *provider.ScanCredentialsRequest) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if got := credentials(); !reflect.DeepEqual(got, want) {
+				return fmt.Errorf("expected scan credentials %+v, got %+v", want, got)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkAssetsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain           = "example.com"
+  scan_credentials = { username = "scanner", password = "hunter2", token = "t0ken" }
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain           = "example.com"
+  scan_credentials = { username = "scanner" }
+}
+`,
+				ExpectError: regexp.MustCompile(`"scan_credentials.password" must be specified`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain           = "example.com"
+  scan_credentials = { username = "scanner", password = "hunter2" }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						expectSensitive{address: "detectify_asset.test", path: []string{"scan_credentials", "password"}},
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_credentials.username", "scanner"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_credentials.password", "hunter2"),
+					resource.TestCheckNoResourceAttr("detectify_asset.test", "scan_credentials.token"),
+					checkCredentials(&provider.ScanCredentialsRequest{Type: "basic", Username: "scanner", Password: "hunter2"}),
+				),
+			},
+			{
+				// Rotating the password sets the credentials again, though the API never returns the password.
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain           = "example.com"
+  scan_credentials = { username = "scanner", password = "correct-horse" }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+						expectSensitive{address: "detectify_asset.test", path: []string{"scan_credentials", "password"}},
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_credentials.password", "correct-horse"),
+					checkCredentials(&provider.ScanCredentialsRequest{Type: "basic", Username: "scanner", Password: "correct-horse"}),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain           = "example.com"
+  scan_credentials = { token = "t0ken" }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						expectSensitive{address: "detectify_asset.test", path: []string{"scan_credentials", "token"}},
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("detectify_asset.test", "scan_credentials.username"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_credentials.token", "t0ken"),
+					checkCredentials(&provider.ScanCredentialsRequest{Type: "token", Token: "t0ken"}),
+				),
+			},
+			{
+				// Credentials changed outside of Terraform are set again.
+				PreConfig: func() {
+					api.mu.Lock()
+					defer api.mu.Unlock()
+
+					for token, asset := range api.assets {
+						if asset.Name == "example.com" {
+							api.credentials[token] = &provider.ScanCredentialsRequest{Type: "basic", Username: "intruder", Password: "secret"}
+						}
+					}
+				},
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain           = "example.com"
+  scan_credentials = { token = "t0ken" }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: checkCredentials(&provider.ScanCredentialsRequest{Type: "token", Token: "t0ken"}),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("detectify_asset.test", "scan_credentials"),
+					checkCredentials(nil),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceCriticality(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain      = "example.com"
+  criticality = "severe"
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain      = "example.com"
+  criticality = "high"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "criticality", "high"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain      = "example.com"
+  criticality = "critical"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "criticality", "critical"),
+				),
+			},
+			{
+				// Changes made outside of Terraform are reconciled.
+				PreConfig: func() { api.setCriticality("example.com", "low") },
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain      = "example.com"
+  criticality = "critical"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "criticality", "critical"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceStatus(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkAssetsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+  status = "deleted"
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				// New assets are active.
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "status", "active"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+  status = "paused"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "status", "paused"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+  status = "archived"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "status", "archived"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+  status = "paused"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Asset Status Transition`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+  status = "active"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "status", "active"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+  status = "paused"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "status", "paused"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceTeam(t *testing.T) {
+	api := newFakeAPI(t)
+
+	// checkTransfers checks the number of transfers since the previous check.
+	transfers := 0
+	checkTransfers := func(want int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			api.mu.Lock()
+			defer api.mu.Unlock()
+
+			if got := api.transfers - transfers; got != want {
+				return fmt.Errorf("expected %d transfers, got %d", want, got)
+			}
+			transfers = api.transfers
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkAssetsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain     = "example.com"
+  team_token = ""
+}
+`,
+				ExpectError: regexp.MustCompile(`string length must be at least 1`),
+			},
+			{
+				// The asset is created under the team, without a transfer.
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain     = "example.com"
+  team_token = "team-a"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "team_token", "team-a"),
+					checkTransfers(0),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain     = "example.com"
+  team_token = "team-b"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "team_token", "team-b"),
+					checkTransfers(1),
+				),
+			},
+			{
+				// Transfers made outside of Terraform are reverted.
+				PreConfig: func() { api.setTeam("example.com", "team-c") },
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain     = "example.com"
+  team_token = "team-b"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "team_token", "team-b"),
+					checkTransfers(1),
+				),
+			},
+			{
+				// The team is kept when it is no longer configured.
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "team_token", "team-b"),
+					checkTransfers(0),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceDescription(t *testing.T) {
+	api := newFakeAPI(t)
+
+	description := func(value string) string {
+		return api.providerConfig() + fmt.Sprintf(`
+resource "detectify_asset" "test" {
+  domain      = "example.com"
+  description = %q
+}
+`, value)
+	}
+
+	noDescription := api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: description("Main marketing site"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "description", "Main marketing site"),
+				),
+			},
+			{
+				Config: description("Handles customer logins"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "description", "Handles customer logins"),
+				),
+			},
+			{
+				// Changes made outside of Terraform are reconciled.
+				PreConfig: func() { api.setNotes("example.com", "Edited in the UI") },
+				Config:    description("Handles customer logins"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "description", "Handles customer logins"),
+				),
+			},
+			{
+				// An empty description is kept as empty, without a diff.
+				Config: description(""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "description", ""),
+				),
+			},
+			{
+				Config: description(""),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				// Removing the description clears it, without a diff.
+				Config: noDescription,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("detectify_asset.test", "description"),
+				),
+			},
+			{
+				Config: noDescription,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceLastScan(t *testing.T) {
+	api := newFakeAPI(t)
+	config := api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("detectify_asset.test", "last_scanned_at"),
+					resource.TestCheckNoResourceAttr("detectify_asset.test", "last_scan_status"),
+				),
+			},
+			{
+				PreConfig: func() { api.setLastScan("example.com", "2024-05-01T12:00:00Z", "completed") },
+				Config:    config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "last_scanned_at", "2024-05-01T12:00:00Z"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "last_scan_status", "completed"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceServerDefaults(t *testing.T) {
+	api := newFakeAPI(t)
+	config := func(description string) string {
+		return api.providerConfig() + fmt.Sprintf(`
+resource "detectify_asset" "test" {
+  domain      = "example.com"
+  description = %q
+}
+`, description)
+	}
+
+	// The values the API fills in for attributes that are not set are kept when planning,
+	// rather than being unknown or changed again by every apply.
+	expectKept := []plancheck.PlanCheck{
+		plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+		plancheck.ExpectKnownValue("detectify_asset.test", tfjsonpath.New("token"), knownvalue.StringExact("token0001")),
+		plancheck.ExpectKnownValue("detectify_asset.test", tfjsonpath.New("scan_frequency"), knownvalue.StringExact("weekly")),
+		plancheck.ExpectKnownValue("detectify_asset.test", tfjsonpath.New("status"), knownvalue.StringExact(provider.AssetStatusActive)),
+		plancheck.ExpectKnownValue("detectify_asset.test", tfjsonpath.New("monitor_subdomains"), knownvalue.Bool(false)),
+		plancheck.ExpectKnownValue("detectify_asset.test", tfjsonpath.New("team_token"), knownvalue.Null()),
+		plancheck.ExpectKnownValue("detectify_asset.test", tfjsonpath.New("criticality"), knownvalue.Null()),
+		// The last scan is changed by the API whenever a scan finishes, so it is not kept.
+		plancheck.ExpectUnknownValue("detectify_asset.test", tfjsonpath.New("last_scanned_at")),
+		plancheck.ExpectUnknownValue("detectify_asset.test", tfjsonpath.New("last_scan_status")),
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Marketing site"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_frequency", "weekly"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "status", provider.AssetStatusActive),
+				),
+			},
+			{
+				Config: config("Marketing site"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+			{
+				Config: config("Marketing site, owned by web"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: expectKept,
+				},
+			},
+			{
+				// A scan finishing between the plan and the apply does not make the apply inconsistent.
+				Config: config("Marketing site, owned by the web team"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+						beforeApply(func() { api.setLastScan("example.com", "2024-05-01T12:00:00Z", "completed") }),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "last_scanned_at", "2024-05-01T12:00:00Z"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "last_scan_status", "completed"),
+				),
+			},
+			{
+				Config: config("Marketing site, owned by the web team"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceTechnologies(t *testing.T) {
+	api := newFakeAPI(t)
+	config := api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "technologies.#", "0"),
+				),
+			},
+			{
+				// Technologies fingerprinted by a scan are read without planning changes.
+				PreConfig: func() {
+					api.setTechnologies("example.com",
+						provider.Technology{Name: "nginx", Version: "1.25.3", Category: "web-server"},
+						provider.Technology{Name: "React", Category: "javascript-framework"},
+					)
+				},
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "technologies.#", "2"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "technologies.0.name", "nginx"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "technologies.0.version", "1.25.3"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "technologies.0.category", "web-server"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "technologies.1.name", "React"),
+					resource.TestCheckNoResourceAttr("detectify_asset.test", "technologies.1.version"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "technologies.1.category", "javascript-framework"),
+				),
+			},
+			{
+				// Technologies fingerprinted between the plan and the apply of an update are read without failing the apply.
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain      = "example.com"
+  description = "Marketing site"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("detectify_asset.test", tfjsonpath.New("technologies")),
+						beforeApply(func() {
+							api.setTechnologies("example.com",
+								provider.Technology{Name: "nginx", Version: "1.27.0", Category: "web-server"},
+							)
+						}),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "technologies.#", "1"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "technologies.0.version", "1.27.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceDNSRecords(t *testing.T) {
+	api := newFakeAPI(t)
+	config := func(description string) string {
+		return api.providerConfig() + fmt.Sprintf(`
+resource "detectify_asset" "test" {
+  domain      = "example.com"
+  description = %q
+}
+`, description)
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("Marketing site"),
+				Check:  resource.TestCheckResourceAttr("detectify_asset.test", "dns_records.#", "0"),
+			},
+			{
+				// Records discovered by the API are read without planning changes.
+				PreConfig: func() {
+					api.setDNSRecords("example.com", provider.DNSRecord{Type: "A", Name: "example.com", Value: "192.0.2.10", TTL: 300})
+				},
+				Config: config("Marketing site"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "dns_records.#", "1"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "dns_records.0.value", "192.0.2.10"),
+				),
+			},
+			{
+				// Records discovered between the plan and the apply of an update are read without failing the apply.
+				Config: config("Marketing site, owned by the web team"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectUnknownValue("detectify_asset.test", tfjsonpath.New("dns_records")),
+						beforeApply(func() {
+							api.setDNSRecords("example.com",
+								provider.DNSRecord{Type: "A", Name: "example.com", Value: "192.0.2.20", TTL: 300},
+								provider.DNSRecord{Type: "AAAA", Name: "example.com", Value: "2001:db8::20", TTL: 300},
+							)
+						}),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "dns_records.#", "2"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "dns_records.1.type", "AAAA"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceEventualConsistency(t *testing.T) {
+	api := newFakeAPI(t)
+
+	// The first read of the created asset fails as if it had not been created.
+	api.staleReads = 1
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkAssetsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "domain", "example.com"),
+					resource.TestCheckResourceAttrSet("detectify_asset.test", "token"),
+					func(*terraform.State) error {
+						api.mu.Lock()
+						defer api.mu.Unlock()
+
+						for token, reads := range api.pending {
+							if reads > 0 {
+								return fmt.Errorf("asset %q was not read back after it was created", token)
+							}
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceAlreadyExists(t *testing.T) {
+	api := newFakeAPI(t)
+	token := api.addAsset("example.com", "legacy")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkAssetsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+}
+`,
+				ExpectError: regexp.MustCompile(`Asset Already Exists`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain         = "example.com"
+  tags           = ["production"]
+  adopt_existing = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "token", token),
+					resource.TestCheckResourceAttr("detectify_asset.test", "adopt_existing", "true"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "tags.#", "1"),
+					resource.TestCheckTypeSetElemAttr("detectify_asset.test", "tags.*", "production"),
+					func(*terraform.State) error {
+						api.mu.Lock()
+						defer api.mu.Unlock()
+
+						if len(api.assets) != 1 {
+							return fmt.Errorf("expected the existing asset to be adopted, got %d assets", len(api.assets))
+						}
+
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourcePartialCreate(t *testing.T) {
+	api := newFakeAPI(t)
+	api.failing["/settings/"] = true
+
+	config := api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain         = "example.com"
+  scan_frequency = "daily"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkAssetsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				// The asset is created, but its settings cannot be set.
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Unable to update asset settings`),
+			},
+			{
+				// The created asset is in the state, so it is replaced, rather than conflicting with a new one.
+				PreConfig: func() {
+					api.mu.Lock()
+					defer api.mu.Unlock()
+
+					require.Len(t, api.assets, 1)
+					delete(api.failing, "/settings/")
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_frequency", "daily"),
+					func(*terraform.State) error {
+						api.mu.Lock()
+						defer api.mu.Unlock()
+
+						require.Len(t, api.assets, 1)
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceDeletionProtection(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkAssetsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain              = "example.com"
+  deletion_protection = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain              = "example.com"
+  deletion_protection = true
+}
+`,
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Asset Protected`),
+			},
+			{
+				// The asset survived the blocked destroy, so disabling protection is an update.
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceImportBlock(t *testing.T) {
+	api := newFakeAPI(t)
+	token := api.addAsset("example.com", "production", "web")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + fmt.Sprintf(`
+import {
+  to = detectify_asset.test
+  id = %[1]q
+}
+
+resource "detectify_asset" "test" {
+  domain = "example.com"
+  tags   = ["production", "web"]
+}
+`, token),
+				// Read populates every attribute from the token alone, so the import plans no changes.
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionNoop),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "token", token),
+					resource.TestCheckResourceAttr("detectify_asset.test", "domain", "example.com"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "tags.#", "2"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_frequency", "weekly"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceImportFull(t *testing.T) {
+	api := newFakeAPI(t)
+	token := api.addAsset("example.com", "production", "web")
+
+	// Set everything the resource manages outside of Terraform.
+	api.mu.Lock()
+	api.assets[token].Criticality = "high"
+	api.assets[token].Notes = "Main marketing site"
+	api.assets[token].Status = provider.AssetStatusPaused
+	api.assets[token].TeamToken = "team-a"
+	api.settings[token].ScanFrequency = "daily"
+	api.monitoring[token] = &provider.SubdomainMonitoring{
+		Enabled:   true,
+		Allowlist: []string{"app.example.com"},
+		Blocklist: []string{"legacy.example.com"},
+	}
+	api.metadata[token] = map[string]string{"owner": "web-team", "cost-center": "1234"}
+	api.mu.Unlock()
+
+	config := api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain              = "example.com"
+  team_token          = "team-a"
+  tags                = ["production", "web"]
+  scan_frequency      = "daily"
+  criticality         = "high"
+  description         = "Main marketing site"
+  status              = "paused"
+  metadata            = { owner = "web-team", cost-center = "1234" }
+  monitor_subdomains  = true
+  subdomain_allowlist = ["app.example.com"]
+  subdomain_blocklist = ["legacy.example.com"]
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_5_0),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:             config,
+				ResourceName:       "detectify_asset.test",
+				ImportState:        true,
+				ImportStateId:      token,
+				ImportStatePersist: true,
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported resource, got %d", len(states))
+					}
+
+					expected := map[string]string{
+						"token":                 token,
+						"domain":                "example.com",
+						"team_token":            "team-a",
+						"tags.#":                "2",
+						"scan_frequency":        "daily",
+						"criticality":           "high",
+						"description":           "Main marketing site",
+						"status":                "paused",
+						"metadata.%":            "2",
+						"metadata.owner":        "web-team",
+						"metadata.cost-center":  "1234",
+						"monitor_subdomains":    "true",
+						"subdomain_allowlist.#": "1",
+						"subdomain_blocklist.#": "1",
+						"deletion_protection":   "false",
+						"adopt_existing":        "false",
+					}
+					for key, value := range expected {
+						if got := states[0].Attributes[key]; got != value {
+							return fmt.Errorf("expected %s to be %q, got %q", key, value, got)
+						}
+					}
+
+					return nil
+				},
+			},
+			{
+				// The first plan after the import has no changes.
+				Config: config,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+// TestAccAssetResourceLive runs the asset lifecycle against the real Detectify API,
+// configured through the DETECTIFY_* environment variables.
+func TestAccAssetResourceLive(t *testing.T) {
+	domain := os.Getenv("DETECTIFY_TEST_DOMAIN")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testAccLivePreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "detectify_asset" "test" {
+  domain = %q
+}
+`, domain),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "domain", domain),
+					resource.TestCheckResourceAttrSet("detectify_asset.test", "token"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+resource "detectify_asset" "test" {
+  domain = %q
+  tags   = ["terraform-acceptance-test"]
+}
+`, domain),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "tags.#", "1"),
+				),
+			},
+			{
+				ResourceName:                         "detectify_asset.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateIdFunc:                    testAccResourceToken("detectify_asset.test"),
+				ImportStateVerifyIdentifierAttribute: "token",
+			},
+		},
+	})
+}
+
+func TestAccAssetResourcePatch(t *testing.T) {
+	api := newFakeAPI(t)
+
+	// checkPatches checks the bodies of the requests changing the asset since the previous check.
+	patches := 0
+	checkPatches := func(want ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			api.mu.Lock()
+			defer api.mu.Unlock()
+
+			got := api.patches[patches:]
+			patches = len(api.patches)
+			if len(got) != len(want) {
+				return fmt.Errorf("expected %d patches, got %d: %v", len(want), len(got), got)
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					return fmt.Errorf("expected patch %s, got %s", want[i], got[i])
+				}
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkAssetsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain      = "example.com"
+  tags        = ["production"]
+  criticality = "high"
+  description = "Main site"
+}
+`,
+				Check: checkPatches(),
+			},
+			{
+				// Only the changed attribute is sent.
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain      = "example.com"
+  tags        = ["production"]
+  criticality = "high"
+  description = "Main marketing site"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "description", "Main marketing site"),
+					checkPatches(`{"notes":"Main marketing site"}`),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain      = "example.com"
+  tags        = ["production", "web"]
+  criticality = "critical"
+  description = "Main marketing site"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "criticality", "critical"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "tags.#", "2"),
+					checkPatches(`{"tags":["production","web"],"criticality":"critical"}`),
+				),
+			},
+			{
+				// Changes to other attributes do not change the asset itself.
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain         = "example.com"
+  tags           = ["production", "web"]
+  criticality    = "critical"
+  description    = "Main marketing site"
+  scan_frequency = "daily"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_frequency", "daily"),
+					checkPatches(),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceScanSettings(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkAssetsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain         = "example.com"
+  scan_frequency = "daily"
+
+  scan_settings = {
+    scan_frequency = "weekly"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+
+  scan_settings = {
+    scan_frequency  = "daily"
+    test_categories = ["xss", "sql-injection"]
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_settings.scan_frequency", "daily"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_settings.monitoring_enabled", "false"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_settings.test_categories.#", "2"),
+					resource.TestCheckTypeSetElemAttr("detectify_asset.test", "scan_settings.test_categories.*", "xss"),
+					resource.TestCheckTypeSetElemAttr("detectify_asset.test", "scan_settings.test_categories.*", "sql-injection"),
+					// The top-level attributes reflect the same settings.
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_frequency", "daily"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "monitor_subdomains", "false"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+
+  scan_settings = {
+    scan_frequency     = "monthly"
+    monitoring_enabled = true
+    test_categories    = ["xss"]
+  }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("detectify_asset.test", tfjsonpath.New("scan_frequency"), knownvalue.StringExact("monthly")),
+						plancheck.ExpectKnownValue("detectify_asset.test", tfjsonpath.New("monitor_subdomains"), knownvalue.Bool(true)),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_settings.scan_frequency", "monthly"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_settings.monitoring_enabled", "true"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_settings.test_categories.#", "1"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_frequency", "monthly"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "monitor_subdomains", "true"),
+				),
+			},
+			{
+				// Settings changed outside of Terraform are reverted.
+				PreConfig: func() {
+					api.setScanFrequency("example.com", "weekly")
+					api.setTestCategories("example.com", "xss", "open-redirect")
+				},
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+
+  scan_settings = {
+    scan_frequency     = "monthly"
+    monitoring_enabled = true
+    test_categories    = ["xss"]
+  }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_asset.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_settings.scan_frequency", "monthly"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_settings.test_categories.#", "1"),
+					resource.TestCheckTypeSetElemAttr("detectify_asset.test", "scan_settings.test_categories.*", "xss"),
+				),
+			},
+			{
+				// A top-level attribute changes the same setting as in scan_settings.
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain         = "example.com"
+  scan_frequency = "biweekly"
+
+  scan_settings = {
+    monitoring_enabled = true
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_frequency", "biweekly"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "scan_settings.scan_frequency", "biweekly"),
+					resource.TestCheckNoResourceAttr("detectify_asset.test", "scan_settings.test_categories"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain         = "example.com"
+  scan_frequency = "biweekly"
+
+  scan_settings = {
+    monitoring_enabled = true
+  }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectEmptyPlan(),
+					},
+				},
+			},
+		},
+	})
+}
+
+func TestAccAssetResourceAsync(t *testing.T) {
+	api := newFakeAPI(t)
+	api.async = true
+
+	config := api.providerConfig() + `
+resource "detectify_asset" "test" {
+  domain = "example.com"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(s *terraform.State) error {
+			if err := api.checkAssetsDestroyed(s); err != nil {
+				return err
+			}
+
+			api.mu.Lock()
+			defer api.mu.Unlock()
+
+			if len(api.asyncRequests) != 2 || api.asyncRequests[0] != "POST /v2/domains/" ||
+				!strings.HasPrefix(api.asyncRequests[1], "DELETE /v2/domains/") {
+				return fmt.Errorf("unexpected asynchronous requests: %q", api.asyncRequests)
+			}
+
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				// The asset is added asynchronously, and its state is read from the completed operation.
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset.test", "domain", "example.com"),
+					resource.TestCheckResourceAttrSet("detectify_asset.test", "token"),
+				),
+			},
+		},
+	})
+}
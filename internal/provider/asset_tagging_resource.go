@@ -0,0 +1,332 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// assetTaggingConcurrency is the number of assets the asset tagging resource reads at once.
+const assetTaggingConcurrency = 8
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &AssetTaggingResource{}
+
+func NewAssetTaggingResource() resource.Resource {
+	return &AssetTaggingResource{}
+}
+
+// AssetTaggingResource defines the resource implementation.
+type AssetTaggingResource struct {
+	client *Client
+}
+
+// AssetTaggingResourceModel describes the resource data model.
+type AssetTaggingResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	AssetTokens types.Set    `tfsdk:"asset_tokens"`
+	Tags        types.Set    `tfsdk:"tags"`
+}
+
+func (r *AssetTaggingResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_asset_tagging"
+}
+
+func (r *AssetTaggingResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Applies a set of tags to several assets, such as for policy-driven tagging. " +
+			"Other tags of the assets are left as they are. Removing an asset or a tag removes the tag from the asset, " +
+			"and destroying the resource removes the tags from all of its assets. Assets that cannot be tagged fail " +
+			"the apply without affecting the others, and are tagged again on the next apply. " +
+			"Do not manage the same tags with the `tags` of `detectify_asset` as well, or they will conflict.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the tagging, only known to Terraform.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"asset_tokens": schema.SetAttribute{
+				MarkdownDescription: "The tokens of the assets to tag. Assets that no longer exist are left out.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "The tags to apply to each of the assets.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+	}
+}
+
+func (r *AssetTaggingResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *AssetTaggingResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data AssetTaggingResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to generate tagging ID", err.Error())
+		return
+	}
+
+	data.ID = types.StringValue(id)
+
+	tokens, tags := data.values(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tagged := r.apply(ctx, tokens, tags, nil, nil, &resp.Diagnostics)
+	resp.Diagnostics.Append(data.setAssetTokens(ctx, tagged)...)
+
+	tflog.Trace(ctx, "tagged assets", map[string]any{"id": id, "count": len(tagged)})
+
+	// Save data into Terraform state, with only the assets that were tagged, so that the others are tagged again.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AssetTaggingResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data AssetTaggingResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokens, tags := data.values(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the assets that still have all of the tags are kept, so that the others are tagged again.
+	var tagged []string
+	assets, errs := r.client.GetAssets(ctx, tokens, assetTaggingConcurrency)
+	for i, token := range tokens {
+		if IsNotFound(errs[i]) {
+			tflog.Warn(ctx, "tagged asset not found, removing from state", map[string]any{"token": token})
+			continue
+		}
+
+		if errs[i] != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read asset %q, got error: %s", token, errs[i]))
+			continue
+		}
+
+		if hasTags(assets[i].Tags, tags) {
+			tagged = append(tagged, token)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(data.setAssetTokens(ctx, tagged)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AssetTaggingResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state AssetTaggingResourceModel
+
+	// Read Terraform plan data and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokens, tags := data.values(ctx, &resp.Diagnostics)
+	priorTokens, priorTags := state.values(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tagged := r.apply(ctx, tokens, tags, priorTokens, priorTags, &resp.Diagnostics)
+	resp.Diagnostics.Append(data.setAssetTokens(ctx, tagged)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *AssetTaggingResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data AssetTaggingResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tokens, tags := data.values(ctx, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, nil, nil, tokens, tags, &resp.Diagnostics)
+}
+
+// apply adds the tags to the assets identified by tokens, and removes the prior tags that are no longer wanted
+// from the prior assets, one asset at a time. An asset that fails adds an error diagnostic without stopping
+// the others. It returns the tokens of the assets that were tagged.
+func (r *AssetTaggingResource) apply(ctx context.Context, tokens, tags, priorTokens, priorTags []string, diags *diag.Diagnostics) []string {
+	var tagged []string
+
+	for _, token := range tokens {
+		remove := priorTags
+		if !slices.Contains(priorTokens, token) {
+			remove = nil
+		}
+
+		if err := r.retag(ctx, token, tags, remove); err != nil {
+			diags.AddAttributeError(
+				path.Root("asset_tokens"),
+				"Unable to Tag Asset",
+				fmt.Sprintf("Unable to tag asset %q, got error: %s", token, err),
+			)
+			continue
+		}
+
+		tagged = append(tagged, token)
+	}
+
+	for _, token := range priorTokens {
+		if slices.Contains(tokens, token) {
+			continue
+		}
+
+		if err := r.retag(ctx, token, nil, priorTags); err != nil && !IsNotFound(err) {
+			diags.AddAttributeError(
+				path.Root("asset_tokens"),
+				"Unable to Untag Asset",
+				fmt.Sprintf("Unable to remove tags from asset %q, got error: %s", token, err),
+			)
+		}
+	}
+
+	return tagged
+}
+
+// retag removes the tags in remove that are not in add from the asset, and adds the tags in add,
+// keeping its other tags. The asset is only changed if its tags differ.
+func (r *AssetTaggingResource) retag(ctx context.Context, token string, add, remove []string) error {
+	asset, err := r.client.GetAsset(ctx, token)
+	if err != nil {
+		return err
+	}
+
+	var desired []string
+	for _, tag := range asset.Tags {
+		if !slices.Contains(remove, tag) || slices.Contains(add, tag) {
+			desired = append(desired, tag)
+		}
+	}
+
+	for _, tag := range add {
+		if !slices.Contains(desired, tag) {
+			desired = append(desired, tag)
+		}
+	}
+
+	if sameStrings(desired, asset.Tags) {
+		return nil
+	}
+
+	if desired == nil {
+		desired = []string{}
+	}
+
+	_, err = r.client.PatchAsset(ctx, token, AssetPatchRequest{Tags: &desired})
+	return err
+}
+
+// hasTags reports whether all of tags are among the tags of an asset.
+func hasTags(assetTags, tags []string) bool {
+	for _, tag := range tags {
+		if !slices.Contains(assetTags, tag) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// values returns the asset tokens and tags of the model, sorted.
+func (m *AssetTaggingResourceModel) values(ctx context.Context, diags *diag.Diagnostics) ([]string, []string) {
+	var tokens, tags []string
+	diags.Append(m.AssetTokens.ElementsAs(ctx, &tokens, false)...)
+	diags.Append(m.Tags.ElementsAs(ctx, &tags, false)...)
+
+	sort.Strings(tokens)
+	sort.Strings(tags)
+
+	return tokens, tags
+}
+
+// setAssetTokens sets the asset tokens of the model.
+func (m *AssetTaggingResourceModel) setAssetTokens(ctx context.Context, tokens []string) diag.Diagnostics {
+	if tokens == nil {
+		tokens = []string{}
+	}
+
+	value, diags := types.SetValueFrom(ctx, types.StringType, tokens)
+	m.AssetTokens = value
+
+	return diags
+}
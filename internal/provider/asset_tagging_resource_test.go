@@ -0,0 +1,97 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccAssetTaggingResource(t *testing.T) {
+	api := newFakeAPI(t)
+	first := api.addAsset("example.com", "owner:web")
+	second := api.addAsset("example.org")
+	third := api.addAsset("example.net", "pci")
+
+	config := func(tokens, tags string) string {
+		return api.providerConfig() + fmt.Sprintf(`
+resource "detectify_asset_tagging" "test" {
+  asset_tokens = %s
+  tags         = %s
+}
+`, tokens, tags)
+	}
+
+	expectTags := func(token string, tags ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if len(tags) == 0 {
+				require.Empty(t, api.assetTags(token), "tags of asset %s", token)
+			} else {
+				require.Equal(t, tags, api.assetTags(token), "tags of asset %s", token)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			// Destroying removes the managed tags, keeping the other tags of the assets.
+			require.Equal(t, []string{"owner:web"}, api.assetTags(first))
+			require.Empty(t, api.assetTags(third))
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`[]`, `["pci"]`),
+				ExpectError: regexp.MustCompile(`set must contain at least 1 elements`),
+			},
+			{
+				Config: config(`["`+first+`", "`+second+`"]`, `["pci", "env:prod"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("detectify_asset_tagging.test", "id"),
+					resource.TestCheckResourceAttr("detectify_asset_tagging.test", "asset_tokens.#", "2"),
+					expectTags(first, "env:prod", "owner:web", "pci"),
+					expectTags(second, "env:prod", "pci"),
+					expectTags(third, "pci"),
+				),
+			},
+			{
+				// Removing an asset and a tag removes the tags from the asset, and the tag from the remaining assets.
+				Config: config(`["`+first+`", "`+third+`"]`, `["pci", "env:staging"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					expectTags(first, "env:staging", "owner:web", "pci"),
+					expectTags(second),
+					expectTags(third, "env:staging", "pci"),
+				),
+			},
+			{
+				// A tag removed outside of Terraform is added again.
+				PreConfig: func() { api.setTags(first, "owner:web", "pci") },
+				Config:    config(`["`+first+`", "`+third+`"]`, `["pci", "env:staging"]`),
+				Check:     expectTags(first, "env:staging", "owner:web", "pci"),
+			},
+			{
+				// An asset that cannot be tagged fails the apply, without stopping the others from being tagged.
+				Config:      config(`["`+first+`", "token9999", "`+third+`"]`, `["env:staging"]`),
+				ExpectError: regexp.MustCompile(`Unable to tag asset "token9999"`),
+			},
+			{
+				PreConfig: func() {
+					require.Equal(t, []string{"env:staging", "owner:web"}, api.assetTags(first))
+					require.Equal(t, []string{"env:staging"}, api.assetTags(third))
+				},
+				Config: config(`["`+first+`", "`+third+`"]`, `["env:staging"]`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_asset_tagging.test", "asset_tokens.#", "2"),
+					expectTags(first, "env:staging", "owner:web"),
+					expectTags(third, "env:staging"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssetsByTokensDataSource{}
+
+func NewAssetsByTokensDataSource() datasource.DataSource {
+	return &AssetsByTokensDataSource{}
+}
+
+// assetsByTokensConcurrency is the number of assets read at once by the data source.
+const assetsByTokensConcurrency = 8
+
+// AssetsByTokensDataSource defines the data source implementation.
+type AssetsByTokensDataSource struct {
+	client *Client
+}
+
+// AssetsByTokensDataSourceModel describes the data source data model.
+type AssetsByTokensDataSourceModel struct {
+	Tokens        types.List `tfsdk:"tokens"`
+	FailOnMissing types.Bool `tfsdk:"fail_on_missing"`
+	Assets        types.List `tfsdk:"assets"`
+}
+
+func (d *AssetsByTokensDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_assets_by_tokens"
+}
+
+func (d *AssetsByTokensDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Reads several assets by token at once, instead of declaring a `detectify_asset` data source for each. " +
+			"The assets are read concurrently.",
+
+		Attributes: map[string]schema.Attribute{
+			"tokens": schema.ListAttribute{
+				MarkdownDescription: "The tokens of the assets.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+				},
+			},
+			"fail_on_missing": schema.BoolAttribute{
+				MarkdownDescription: "Whether to fail when an asset is not found. " +
+					"When `false`, the assets that are not found are left out and a warning is reported for each of them. Defaults to `true`.",
+				Optional: true,
+			},
+			"assets": schema.ListNestedAttribute{
+				MarkdownDescription: "The assets, in the order of `tokens`.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "The domain name of the asset.",
+							Computed:            true,
+						},
+						"token": schema.StringAttribute{
+							MarkdownDescription: "The asset token.",
+							Computed:            true,
+						},
+						"tags": schema.SetAttribute{
+							MarkdownDescription: "Tags attached to the asset.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *AssetsByTokensDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *AssetsByTokensDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AssetsByTokensDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var tokens []string
+	resp.Diagnostics.Append(data.Tokens.ElementsAs(ctx, &tokens, false)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	failOnMissing := data.FailOnMissing.IsNull() || data.FailOnMissing.ValueBool()
+
+	assets, errs := d.client.GetAssets(ctx, tokens, assetsByTokensConcurrency)
+
+	items := []assetsItemModel{}
+	for i, asset := range assets {
+		err := errs[i]
+		switch {
+		case IsNotFound(err) && !failOnMissing:
+			resp.Diagnostics.AddWarning("Asset Not Found", fmt.Sprintf("No asset with token %q was found, so it is left out.", tokens[i]))
+			continue
+		case IsNotFound(err):
+			resp.Diagnostics.AddError("Asset Not Found", fmt.Sprintf("No asset with token %q was found.", tokens[i]))
+			continue
+		case err != nil:
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read asset %q, got error: %s", tokens[i], err))
+			continue
+		}
+
+		tags, diags := types.SetValueFrom(ctx, types.StringType, asset.Tags)
+		resp.Diagnostics.Append(diags...)
+
+		items = append(items, assetsItemModel{
+			Domain: types.StringValue(asset.Name),
+			Token:  types.StringValue(asset.Token),
+			Tags:   tags,
+		})
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var diags diag.Diagnostics
+	data.Assets, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: assetsItemAttrTypes}, items)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Trace(ctx, "read assets by tokens", map[string]any{"count": len(items), "missing": len(tokens) - len(items)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,45 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccAssetsByTokensDataSource(t *testing.T) {
+	api := newFakeAPI(t)
+	first := api.addAsset("example.com", "production")
+	second := api.addAsset("example.org")
+
+	config := func(failOnMissing string) string {
+		return api.providerConfig() + `
+data "detectify_assets_by_tokens" "test" {
+  tokens          = ["` + second + `", "missing0000", "` + first + `"]
+  fail_on_missing = ` + failOnMissing + `
+}
+`
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("null"),
+				ExpectError: regexp.MustCompile(`No asset with token "missing0000" was found`),
+			},
+			{
+				Config: config("false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.detectify_assets_by_tokens.test", "assets.#", "2"),
+					resource.TestCheckResourceAttr("data.detectify_assets_by_tokens.test", "assets.0.token", second),
+					resource.TestCheckResourceAttr("data.detectify_assets_by_tokens.test", "assets.0.domain", "example.org"),
+					resource.TestCheckResourceAttr("data.detectify_assets_by_tokens.test", "assets.1.token", first),
+					resource.TestCheckResourceAttr("data.detectify_assets_by_tokens.test", "assets.1.domain", "example.com"),
+					resource.TestCheckTypeSetElemAttr("data.detectify_assets_by_tokens.test", "assets.1.tags.*", "production"),
+				),
+			},
+		},
+	})
+}
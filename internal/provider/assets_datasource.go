@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AssetsDataSource{}
+
+func NewAssetsDataSource() datasource.DataSource {
+	return &AssetsDataSource{}
+}
+
+// AssetsDataSource defines the data source implementation.
+type AssetsDataSource struct {
+	client *Client
+}
+
+// AssetsDataSourceModel describes the data source data model.
+type AssetsDataSourceModel struct {
+	FailOnPartial types.Bool   `tfsdk:"fail_on_partial"`
+	Tags          types.Set    `tfsdk:"tags"`
+	Match         types.String `tfsdk:"match"`
+	CreatedAfter  types.String `tfsdk:"created_after"`
+	CreatedBefore types.String `tfsdk:"created_before"`
+	Assets        types.List   `tfsdk:"assets"`
+
+	IncludeRaw      types.Bool   `tfsdk:"include_raw"`
+	RawJSON         types.String `tfsdk:"raw_json"`
+	ExposeHeaders   types.List   `tfsdk:"expose_headers"`
+	ResponseHeaders types.Map    `tfsdk:"response_headers"`
+}
+
+// assetsItemModel describes an asset in the assets data source.
+type assetsItemModel struct {
+	Domain types.String `tfsdk:"domain"`
+	Token  types.String `tfsdk:"token"`
+	Tags   types.Set    `tfsdk:"tags"`
+}
+
+// assetsItemAttrTypes are the attribute types of assetsItemModel.
+var assetsItemAttrTypes = map[string]attr.Type{
+	"domain": types.StringType,
+	"token":  types.StringType,
+	"tags":   types.SetType{ElemType: types.StringType},
+}
+
+const (
+	assetsMatchAll = "all"
+	assetsMatchAny = "any"
+)
+
+func (d *AssetsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_assets"
+}
+
+func (d *AssetsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists all assets available to the API key.",
+
+		Attributes: map[string]schema.Attribute{
+			"fail_on_partial": schema.BoolAttribute{
+				MarkdownDescription: "Whether to fail when some assets cannot be read. " +
+					"When `false`, the assets that could be read are returned and a warning is reported for each of the others. Defaults to `true`.",
+				Optional: true,
+			},
+			"tags": schema.SetAttribute{
+				MarkdownDescription: "Tags to filter the assets by. By default, only assets with all of the tags are returned.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"match": schema.StringAttribute{
+				MarkdownDescription: "How assets are matched against `tags`: `all` returns assets with all of the tags, " +
+					"and `any` returns assets with at least one of them. Defaults to `" + assetsMatchAll + "`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(assetsMatchAll, assetsMatchAny),
+					stringvalidator.AlsoRequires(path.MatchRoot("tags")),
+				},
+			},
+			"created_after": schema.StringAttribute{
+				MarkdownDescription: "Only return assets created after this RFC 3339 timestamp, such as `2024-05-01T00:00:00Z`. " +
+					"Assets for which the API does not report a creation time are left out.",
+				Optional: true,
+				Validators: []validator.String{
+					timestampValidator{},
+				},
+			},
+			"created_before": schema.StringAttribute{
+				MarkdownDescription: "Only return assets created before this RFC 3339 timestamp, such as `2024-06-01T00:00:00Z`. " +
+					"Assets for which the API does not report a creation time are left out.",
+				Optional: true,
+				Validators: []validator.String{
+					timestampValidator{},
+				},
+			},
+			"assets": schema.ListNestedAttribute{
+				MarkdownDescription: "The assets.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "The domain name of the asset.",
+							Computed:            true,
+						},
+						"token": schema.StringAttribute{
+							MarkdownDescription: "The asset token.",
+							Computed:            true,
+						},
+						"tags": schema.SetAttribute{
+							MarkdownDescription: "Tags attached to the asset.",
+							ElementType:         types.StringType,
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"include_raw":      includeRawAttribute(),
+			"raw_json":         rawJSONAttribute(),
+			"expose_headers":   exposeHeadersAttribute(),
+			"response_headers": responseHeadersAttribute(),
+		},
+	}
+}
+
+func (d *AssetsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *AssetsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AssetsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clientCtx, raw := withRawResponse(ctx, data.IncludeRaw, data.ExposeHeaders)
+
+	assets, decodeErrs, err := d.client.ListAssetsPartial(clientCtx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list assets, got error: %s", err))
+		return
+	}
+
+	// Partial results are only accepted when explicitly allowed.
+	failOnPartial := data.FailOnPartial.IsNull() || data.FailOnPartial.ValueBool()
+
+	for _, err := range decodeErrs {
+		if failOnPartial {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read asset, got error: %s", err))
+		} else {
+			resp.Diagnostics.AddWarning("Asset Skipped", fmt.Sprintf("Unable to read asset, got error: %s", err))
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The API cannot filter by tags, so the assets are filtered here.
+	if !data.Tags.IsNull() {
+		var tags []string
+		resp.Diagnostics.Append(data.Tags.ElementsAs(ctx, &tags, false)...)
+
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		matchAny := data.Match.ValueString() == assetsMatchAny
+		assets = filterAssetsByTags(assets, tags, matchAny)
+	}
+
+	// The API cannot filter by creation time either.
+	if !data.CreatedAfter.IsNull() || !data.CreatedBefore.IsNull() {
+		var after, before time.Time
+		if !data.CreatedAfter.IsNull() {
+			after, _ = time.Parse(time.RFC3339, data.CreatedAfter.ValueString())
+		}
+		if !data.CreatedBefore.IsNull() {
+			before, _ = time.Parse(time.RFC3339, data.CreatedBefore.ValueString())
+		}
+
+		assets = filterAssetsByCreation(assets, after, before)
+	}
+
+	items := make([]assetsItemModel, len(assets))
+	for i, asset := range assets {
+		tags, diags := types.SetValueFrom(ctx, types.StringType, asset.Tags)
+		resp.Diagnostics.Append(diags...)
+
+		items[i] = assetsItemModel{
+			Domain: types.StringValue(asset.Name),
+			Token:  types.StringValue(asset.Token),
+			Tags:   tags,
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.Assets, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: assetsItemAttrTypes}, items)
+	resp.Diagnostics.Append(diags...)
+
+	data.RawJSON = rawJSONValue(raw, data.IncludeRaw)
+
+	responseHeaders, diags := responseHeadersValue(ctx, raw, data.ExposeHeaders)
+	resp.Diagnostics.Append(diags...)
+	data.ResponseHeaders = responseHeaders
+
+	tflog.Trace(ctx, "read assets", map[string]any{"count": len(assets), "skipped": len(decodeErrs)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterAssetsByTags returns the assets with all of the tags, or with any of them if matchAny is set.
+func filterAssetsByTags(assets []Asset, tags []string, matchAny bool) []Asset {
+	filtered := []Asset{}
+	for _, asset := range assets {
+		assetTags := make(map[string]bool, len(asset.Tags))
+		for _, tag := range asset.Tags {
+			assetTags[tag] = true
+		}
+
+		matches := 0
+		for _, tag := range tags {
+			if assetTags[tag] {
+				matches++
+			}
+		}
+
+		if (matchAny && matches > 0) || (!matchAny && matches == len(tags)) {
+			filtered = append(filtered, asset)
+		}
+	}
+
+	return filtered
+}
+
+// filterAssetsByCreation returns the assets created after after and before before.
+// A zero time leaves that end of the range open. Assets without a valid creation time are left out.
+func filterAssetsByCreation(assets []Asset, after, before time.Time) []Asset {
+	filtered := []Asset{}
+	for _, asset := range assets {
+		if asset.CreatedAt == nil {
+			continue
+		}
+
+		createdAt, err := time.Parse(time.RFC3339, *asset.CreatedAt)
+		if err != nil {
+			continue
+		}
+
+		if (!after.IsZero() && !createdAt.After(after)) || (!before.IsZero() && !createdAt.Before(before)) {
+			continue
+		}
+
+		filtered = append(filtered, asset)
+	}
+
+	return filtered
+}
@@ -0,0 +1,309 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+// partialAssetsHandler lists three assets, of which the second cannot be decoded.
+func partialAssetsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/domains/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(`[
+			{"token": "aaaa1111", "name": "example.com", "tags": ["production"]},
+			{"token": 2222, "name": "example.org"},
+			{"token": "cccc3333", "name": "example.net"}
+		]`))
+	})
+}
+
+// readAssetsDataSource reads the assets data source from handler.
+func readAssetsDataSource(t *testing.T, handler http.Handler, values map[string]tftypes.Value) (*provider.AssetsDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	ctx := context.Background()
+	d := provider.NewAssetsDataSource()
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    schemaValue(t, schemaResp.Schema, values),
+	}
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	configureResp := &datasource.ConfigureResponse{}
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{
+		ProviderData: newTestProviderData(t, handler),
+	}, configureResp)
+	require.False(t, configureResp.Diagnostics.HasError())
+
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		return nil, resp
+	}
+
+	var data provider.AssetsDataSourceModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+
+	return &data, resp
+}
+
+func TestAssetsDataSource(t *testing.T) {
+	data, resp := readAssetsDataSource(t, assetAPIHandler(), nil)
+	require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+	require.Empty(t, resp.Diagnostics)
+
+	require.Len(t, data.Assets.Elements(), 2)
+}
+
+func TestAssetsDataSourcePartial(t *testing.T) {
+	tests := map[string]struct {
+		failOnPartial tftypes.Value
+		wantError     bool
+	}{
+		"default": {
+			failOnPartial: tftypes.NewValue(tftypes.Bool, nil),
+			wantError:     true,
+		},
+		"fail": {
+			failOnPartial: tftypes.NewValue(tftypes.Bool, true),
+			wantError:     true,
+		},
+		"allow": {
+			failOnPartial: tftypes.NewValue(tftypes.Bool, false),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, resp := readAssetsDataSource(t, partialAssetsHandler(), map[string]tftypes.Value{
+				"fail_on_partial": tt.failOnPartial,
+			})
+
+			if tt.wantError {
+				require.True(t, resp.Diagnostics.HasError())
+				require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "index 1")
+				return
+			}
+
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+			require.Len(t, resp.Diagnostics.Warnings(), 1)
+			require.Equal(t, "Asset Skipped", resp.Diagnostics.Warnings()[0].Summary())
+			require.Contains(t, resp.Diagnostics.Warnings()[0].Detail(), "index 1")
+
+			require.Len(t, data.Assets.Elements(), 2)
+		})
+	}
+}
+
+// taggedAssetsHandler lists assets with overlapping tags.
+func taggedAssetsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/domains/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(`[
+			{"token": "aaaa1111", "name": "example.com", "tags": ["production", "web"]},
+			{"token": "bbbb2222", "name": "example.org", "tags": ["production"]},
+			{"token": "cccc3333", "name": "example.net", "tags": ["staging", "web"]},
+			{"token": "dddd4444", "name": "example.io"}
+		]`))
+	})
+}
+
+func TestAssetsDataSourceTags(t *testing.T) {
+	tests := map[string]struct {
+		tags         tftypes.Value
+		match        tftypes.Value
+		expectTokens []string
+	}{
+		"no filter": {
+			tags:         tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, nil),
+			match:        tftypes.NewValue(tftypes.String, nil),
+			expectTokens: []string{"aaaa1111", "bbbb2222", "cccc3333", "dddd4444"},
+		},
+		"all by default": {
+			tags:         stringSet("production", "web"),
+			match:        tftypes.NewValue(tftypes.String, nil),
+			expectTokens: []string{"aaaa1111"},
+		},
+		"all": {
+			tags:         stringSet("production"),
+			match:        tftypes.NewValue(tftypes.String, "all"),
+			expectTokens: []string{"aaaa1111", "bbbb2222"},
+		},
+		"any": {
+			tags:         stringSet("production", "staging"),
+			match:        tftypes.NewValue(tftypes.String, "any"),
+			expectTokens: []string{"aaaa1111", "bbbb2222", "cccc3333"},
+		},
+		"no matches": {
+			tags:         stringSet("development"),
+			match:        tftypes.NewValue(tftypes.String, "any"),
+			expectTokens: []string{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, resp := readAssetsDataSource(t, taggedAssetsHandler(), map[string]tftypes.Value{
+				"tags":  tt.tags,
+				"match": tt.match,
+			})
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+			var assets []struct {
+				Domain string   `tfsdk:"domain"`
+				Token  string   `tfsdk:"token"`
+				Tags   []string `tfsdk:"tags"`
+			}
+			require.False(t, data.Assets.ElementsAs(context.Background(), &assets, false).HasError())
+
+			tokens := []string{}
+			for _, asset := range assets {
+				tokens = append(tokens, asset.Token)
+			}
+
+			require.Equal(t, tt.expectTokens, tokens)
+		})
+	}
+}
+
+// datedAssetsHandler lists assets created at different times.
+func datedAssetsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/domains/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		w.Write([]byte(`[
+			{"token": "aaaa1111", "name": "example.com", "created_at": "2024-01-15T10:00:00Z"},
+			{"token": "bbbb2222", "name": "example.org", "created_at": "2024-03-01T00:00:00Z"},
+			{"token": "cccc3333", "name": "example.net", "created_at": "2024-05-20T16:30:00+02:00"},
+			{"token": "dddd4444", "name": "example.io"}
+		]`))
+	})
+}
+
+func TestAssetsDataSourceCreated(t *testing.T) {
+	null := tftypes.NewValue(tftypes.String, nil)
+
+	tests := map[string]struct {
+		createdAfter  tftypes.Value
+		createdBefore tftypes.Value
+		expectTokens  []string
+	}{
+		"no filter": {
+			createdAfter:  null,
+			createdBefore: null,
+			expectTokens:  []string{"aaaa1111", "bbbb2222", "cccc3333", "dddd4444"},
+		},
+		"after": {
+			createdAfter:  tftypes.NewValue(tftypes.String, "2024-02-01T00:00:00Z"),
+			createdBefore: null,
+			expectTokens:  []string{"bbbb2222", "cccc3333"},
+		},
+		"before": {
+			createdAfter:  null,
+			createdBefore: tftypes.NewValue(tftypes.String, "2024-03-01T00:00:00Z"),
+			expectTokens:  []string{"aaaa1111"},
+		},
+		"range": {
+			createdAfter:  tftypes.NewValue(tftypes.String, "2024-01-15T10:00:00Z"),
+			createdBefore: tftypes.NewValue(tftypes.String, "2024-05-20T14:30:00Z"),
+			expectTokens:  []string{"bbbb2222"},
+		},
+		"time zones": {
+			createdAfter:  tftypes.NewValue(tftypes.String, "2024-05-20T14:00:00Z"),
+			createdBefore: tftypes.NewValue(tftypes.String, "2024-05-20T15:00:00Z"),
+			expectTokens:  []string{"cccc3333"},
+		},
+		"no matches": {
+			createdAfter:  tftypes.NewValue(tftypes.String, "2025-01-01T00:00:00Z"),
+			createdBefore: null,
+			expectTokens:  []string{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, resp := readAssetsDataSource(t, datedAssetsHandler(), map[string]tftypes.Value{
+				"created_after":  tt.createdAfter,
+				"created_before": tt.createdBefore,
+			})
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+			var assets []struct {
+				Domain string   `tfsdk:"domain"`
+				Token  string   `tfsdk:"token"`
+				Tags   []string `tfsdk:"tags"`
+			}
+			require.False(t, data.Assets.ElementsAs(context.Background(), &assets, false).HasError())
+
+			tokens := []string{}
+			for _, asset := range assets {
+				tokens = append(tokens, asset.Token)
+			}
+
+			require.Equal(t, tt.expectTokens, tokens)
+		})
+	}
+}
+
+func TestAssetsDataSourceCreatedInvalid(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return cannedResponse(http.StatusOK, `[]`), nil
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"detectify": providerserver.NewProtocol6WithError(provider.NewWithHTTPTransport("test", rt)()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "detectify_assets" "test" {
+  created_after = "2024-05-01"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Timestamp"),
+			},
+			{
+				Config: `
+data "detectify_assets" "test" {
+  created_before = "yesterday"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Timestamp"),
+			},
+		},
+	})
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultCircuitBreakerWindow is the default duration within which consecutive failed requests trip the circuit breaker.
+const DefaultCircuitBreakerWindow = time.Minute
+
+// DefaultCircuitBreakerCooldown is the default duration requests fail immediately for once the circuit breaker trips.
+const DefaultCircuitBreakerCooldown = 30 * time.Second
+
+// ErrCircuitOpen is returned for requests that are not sent, as the circuit breaker tripped on failing requests.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// circuitBreaker stops requests to a failing API. Once threshold consecutive requests fail within the window,
+// requests fail immediately with ErrCircuitOpen until the cooldown has passed. A single request is then sent
+// to probe the API, while the others still fail immediately: if the probe fails too, the breaker trips again
+// right away, and if it succeeds, requests are sent as usual. A nil circuit breaker never trips.
+type circuitBreaker struct {
+	threshold int64
+	window    time.Duration
+	cooldown  time.Duration
+	// now returns the current time, replaced by tests.
+	now func() time.Time
+
+	mu sync.Mutex
+	// failures is the number of consecutive failed requests, the first of which failed at firstFailure.
+	failures     int64
+	firstFailure time.Time
+	// openUntil is when requests are sent again, zero if the breaker has not tripped.
+	openUntil time.Time
+	// probing is whether a request probing the API after the cooldown is in flight.
+	probing bool
+}
+
+// newCircuitBreaker returns a circuit breaker tripping on threshold consecutive failures,
+// or a nil circuit breaker if threshold is not positive.
+func newCircuitBreaker(threshold int64, window, cooldown time.Duration) *circuitBreaker {
+	if threshold <= 0 {
+		return nil
+	}
+
+	return &circuitBreaker{
+		threshold: threshold,
+		window:    window,
+		cooldown:  cooldown,
+		now:       time.Now,
+	}
+}
+
+// allow returns an error wrapping ErrCircuitOpen if requests are not to be sent.
+func (b *circuitBreaker) allow() error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.openUntil.IsZero() {
+		return nil
+	}
+
+	if wait := b.openUntil.Sub(b.now()); wait > 0 {
+		return fmt.Errorf("%w: %d consecutive requests to the Detectify API failed, not sending requests for another %s",
+			ErrCircuitOpen, b.failures, wait.Round(time.Second))
+	}
+
+	if b.probing {
+		return fmt.Errorf("%w: %d consecutive requests to the Detectify API failed, waiting for a request probing whether it recovered",
+			ErrCircuitOpen, b.failures)
+	}
+
+	b.probing = true
+
+	return nil
+}
+
+// abandon records that an allowed request was not sent, or was given up on, so that its outcome says nothing
+// about the API. If it was probing the API, the next request probes it instead.
+func (b *circuitBreaker) abandon() {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// record records the outcome of a sent request, tripping the breaker if too many requests failed.
+func (b *circuitBreaker) record(failed bool) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+
+	now := b.now()
+	if !failed {
+		b.failures = 0
+		b.openUntil = time.Time{}
+		return
+	}
+
+	// A failure probing the API after the cooldown trips the breaker again, however long ago the failures began.
+	probing := !b.openUntil.IsZero()
+	if b.failures == 0 || (!probing && now.Sub(b.firstFailure) > b.window) {
+		b.failures = 0
+		b.firstFailure = now
+	}
+
+	b.failures++
+	if probing || b.failures >= b.threshold {
+		b.openUntil = now.Add(b.cooldown)
+	}
+}
@@ -0,0 +1,841 @@
+package provider
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultBaseURL is the base URL of the Detectify API.
+const DefaultBaseURL = "https://api.detectify.com/rest"
+
+// DefaultMaxRetries is the default number of times a request failing with a transient error is retried.
+const DefaultMaxRetries = 3
+
+// DefaultRetryWait is the default wait before the first retry, doubled for each retry after it.
+const DefaultRetryWait = time.Second
+
+// DefaultAsyncPollInterval is the default wait between polls of the status of an asynchronous request.
+const DefaultAsyncPollInterval = 2 * time.Second
+
+// DefaultMaxResponseBytes is the default limit of the size of a response body.
+const DefaultMaxResponseBytes int64 = 64 << 20
+
+// CompressionMinBytes is the size from which request bodies are compressed, when compression is enabled.
+// Smaller bodies are sent as is, as compressing them saves little.
+const CompressionMinBytes = 1024
+
+// ErrResponseTooLarge is returned when a response body exceeds the size limit of the client.
+var ErrResponseTooLarge = errors.New("response body too large")
+
+// ErrRedirectRefused is returned when the API redirects a request outside of the base URL of the client.
+var ErrRedirectRefused = errors.New("redirect refused")
+
+// Client is a minimal client for the Detectify API.
+//
+// Responses to GET requests with an ETag or Last-Modified header are cached,
+// and revalidated with a conditional request when requested again. The cache
+// lives as long as the client, which is a single Terraform run.
+type Client struct {
+	httpClient       *http.Client
+	baseURL          string
+	maxResponseBytes int64
+	maxRetries       int
+	retryWait        time.Duration
+	// asyncPollInterval is the wait between polls of the status of an asynchronous request.
+	asyncPollInterval time.Duration
+	// compressMinBytes is the size from which request bodies are sent gzip compressed, or 0 to never compress them.
+	compressMinBytes int
+
+	mu    sync.Mutex
+	cache map[string]cachedResponse
+
+	// metrics records the retries of the client, if enabled.
+	metrics *metrics
+
+	// requestHook is called with each request before it is sent, if set.
+	requestHook func(*http.Request)
+
+	// signed is set if the transport signs requests with a timestamp in seconds.
+	signed bool
+
+	// testCategories are the test categories, once listed.
+	testCategoriesMu sync.Mutex
+	testCategories   []TestCategory
+
+	// scanProfileTemplates are the scan profile templates, once listed.
+	scanProfileTemplatesMu sync.Mutex
+	scanProfileTemplates   []ScanProfileTemplate
+}
+
+// cachedResponse is a response body with the validators needed to revalidate it.
+type cachedResponse struct {
+	etag         string
+	lastModified string
+	body         []byte
+}
+
+// NewClient returns a client sending requests to baseURL using httpClient.
+// Authentication is handled by the transport of httpClient.
+func NewClient(httpClient *http.Client, baseURL string) *Client {
+	return &Client{
+		httpClient:        httpClient,
+		baseURL:           baseURL,
+		maxResponseBytes:  DefaultMaxResponseBytes,
+		maxRetries:        DefaultMaxRetries,
+		retryWait:         DefaultRetryWait,
+		asyncPollInterval: DefaultAsyncPollInterval,
+		cache:             map[string]cachedResponse{},
+	}
+}
+
+// SetRetryPolicy sets the number of times a request failing with a transient error is retried,
+// and the wait before the first retry.
+func (c *Client) SetRetryPolicy(maxRetries int, wait time.Duration) {
+	c.maxRetries = maxRetries
+	c.retryWait = wait
+}
+
+// SetAsyncPollInterval sets the wait between polls of the status of a request accepted for
+// asynchronous processing, unless the API says how long to wait.
+func (c *Client) SetAsyncPollInterval(interval time.Duration) {
+	c.asyncPollInterval = interval
+}
+
+// SetMaxResponseBytes sets the limit of the size of a response body, in bytes.
+func (c *Client) SetMaxResponseBytes(n int64) {
+	c.maxResponseBytes = n
+}
+
+// SetRequestCompression sets the size, in bytes, from which request bodies are sent gzip compressed,
+// or disables compression if minBytes is 0. Requests are signed with the compressed body, as it is sent.
+func (c *Client) SetRequestCompression(minBytes int) {
+	c.compressMinBytes = minBytes
+}
+
+// SetRequestHook sets a function called with each request before it is sent, including retries,
+// or removes it if hook is nil. The hook may change the request, which is then signed as changed.
+// It is intended for tests and for debugging signature and path issues.
+func (c *Client) SetRequestHook(hook func(*http.Request)) {
+	c.requestHook = hook
+}
+
+// SetSignedRequests sets whether requests are signed with a timestamp in seconds by the transport of
+// the client. A retry of a signed request is then never sent within the same second as the attempt
+// before it, so that each attempt carries a fresh timestamp and signature rather than a replay.
+func (c *Client) SetSignedRequests(signed bool) {
+	c.signed = signed
+}
+
+// APIError is returned when the Detectify API responds with a non-2xx status code.
+type APIError struct {
+	StatusCode int
+	Body       string
+	// Message is the error message parsed from the body, if it was structured.
+	Message string
+}
+
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Message)
+	}
+
+	return fmt.Sprintf("unexpected status code %d: %s", e.StatusCode, e.Body)
+}
+
+// AuthError is returned when the Detectify API responds with 401 Unauthorized or 403 Forbidden,
+// explaining what to check for the authentication mode the request was sent with.
+type AuthError struct {
+	*APIError
+	// Signed is set if the request was signed with an HMAC signature.
+	Signed bool
+}
+
+func (e *AuthError) Error() string {
+	return e.APIError.Error() + ". " + e.Hint()
+}
+
+func (e *AuthError) Unwrap() error {
+	return e.APIError
+}
+
+// Hint explains the status of the response for the authentication mode of the request.
+func (e *AuthError) Hint() string {
+	mode := `by the API key only, without a signature (auth_mode "api_key")`
+	if e.Signed {
+		mode = `by the API key and an HMAC signature (auth_mode "hmac")`
+	}
+
+	if e.StatusCode == http.StatusUnauthorized {
+		return "The Detectify API rejected the credentials with status 401 Unauthorized, so the API key is wrong, revoked or missing. " +
+			"Requests are authenticated " + mode + ". Check the value of api_key, api_key_file or DETECTIFY_API_KEY."
+	}
+
+	if e.Signed {
+		return "The Detectify API accepted the API key, but denied access with status 403 Forbidden. " +
+			"Requests are authenticated " + mode + ", so either the signature is wrong or the API key lacks permission for the request. " +
+			"Check that the secret belongs to the API key, that signature_algorithm and sign_query_string match what the API key expects, " +
+			"and that the system clock is correct, as signatures carry a timestamp."
+	}
+
+	return "The Detectify API accepted the API key, but denied access with status 403 Forbidden. " +
+		"Requests are authenticated " + mode + ", so either the API key requires signed requests or it lacks permission for the request. " +
+		`If it requires signed requests, set secret and auth_mode "hmac".`
+}
+
+// maxSnippetBytes is the length of the start of a body included in a DecodeError.
+const maxSnippetBytes = 200
+
+// DecodeError is returned when a successful response cannot be decoded as JSON, such as an
+// HTML page returned by a proxy in front of the API.
+type DecodeError struct {
+	ContentType string
+	// Snippet is the start of the response body.
+	Snippet string
+	Err     error
+}
+
+func (e *DecodeError) Error() string {
+	contentType := e.ContentType
+	if contentType == "" {
+		contentType = "none"
+	}
+
+	return fmt.Sprintf("decoding response body: %s (Content-Type: %s, body: %q). "+
+		"The response may not come from the Detectify API, such as when a proxy intercepts requests or base_url is wrong",
+		e.Err, contentType, e.Snippet)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// newDecodeError returns a DecodeError for the body that failed to decode with err.
+func newDecodeError(contentType string, body []byte, err error) *DecodeError {
+	snippet := bytes.TrimSpace(body)
+	if len(snippet) > maxSnippetBytes {
+		snippet = snippet[:maxSnippetBytes]
+	}
+
+	return &DecodeError{
+		ContentType: contentType,
+		Snippet:     string(snippet),
+		Err:         err,
+	}
+}
+
+// errorBody is the structured error body returned by the Detectify API.
+type errorBody struct {
+	Error   string `json:"error"`
+	Message string `json:"message"`
+}
+
+// newAPIError returns an APIError for the response, parsing the body if it is structured.
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		Body:       string(body),
+	}
+
+	var parsed errorBody
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return apiErr
+	}
+
+	switch {
+	case parsed.Error != "" && parsed.Message != "":
+		apiErr.Message = parsed.Error + ": " + parsed.Message
+	case parsed.Message != "":
+		apiErr.Message = parsed.Message
+	default:
+		apiErr.Message = parsed.Error
+	}
+
+	return apiErr
+}
+
+// IsNotFound reports whether err is an APIError for a missing object.
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsConflict reports whether err is an APIError for a request conflicting with the current state of an object.
+func IsConflict(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// do sends a request with body encoded as JSON, and decodes the JSON response into v.
+// Either of body and v may be nil.
+//
+// Requests failing with a transient error are retried if the method is idempotent,
+// or if the context carries an idempotency key. A request accepted for asynchronous
+// processing, with a 202 response giving its status location, is followed to completion.
+func (c *Client) do(ctx context.Context, method, path string, body, v any) error {
+	var b []byte
+	if body != nil {
+		var err error
+		b, err = json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encoding request body: %w", err)
+		}
+	}
+
+	key := idempotencyKey(ctx)
+	retryable := isIdempotent(method) || key != ""
+
+	resp, err := c.sendRetrying(ctx, method, path, b, key, retryable)
+	if err != nil {
+		return err
+	}
+
+	if resp.location != "" {
+		if resp, err = c.awaitAsync(ctx, resp); err != nil {
+			return err
+		}
+	}
+
+	if raw := rawResponse(ctx); raw != nil {
+		raw.Body = resp.body
+		raw.Header = resp.header
+	}
+
+	if warnings := apiWarnings(ctx); warnings != nil {
+		warnings.add(resp.body)
+	}
+
+	if v == nil || len(resp.body) == 0 {
+		return nil
+	}
+
+	if err := json.Unmarshal(resp.body, v); err != nil {
+		return newDecodeError(resp.contentType, resp.body, err)
+	}
+
+	return nil
+}
+
+// sendRetrying sends a request, retrying it while it fails with a transient error if retryable is set.
+func (c *Client) sendRetrying(ctx context.Context, method, path string, body []byte, key string, retryable bool) (response, error) {
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, path, body, key)
+		if err == nil || !retryable || !isTransient(err) || attempt >= c.maxRetries {
+			return resp, err
+		}
+
+		wait := c.retryWait << attempt
+		if c.signed {
+			// The attempt was signed no later than now, so wait for the next second to sign the retry.
+			wait = max(wait, time.Until(time.Now().Truncate(time.Second).Add(time.Second)))
+		}
+		c.metrics.observeRetry()
+		tflog.Debug(ctx, "Retrying request", map[string]any{"method": method, "path": path, "attempt": attempt + 1, "wait": wait.String(), "error": err.Error()})
+
+		select {
+		case <-ctx.Done():
+			return response{}, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// awaitAsync polls the status location of a request accepted for asynchronous processing until it
+// completes, and returns the final response. The location is polled as long as it responds 202, waiting
+// as long as its Retry-After header says, or the poll interval of the client. A completed operation may
+// redirect to its result, which is followed.
+func (c *Client) awaitAsync(ctx context.Context, resp response) (response, error) {
+	// The status location is not itself processed asynchronously.
+	ctx = context.WithValue(ctx, respondAsyncContextKey{}, false)
+
+	for resp.location != "" {
+		path, err := c.locationPath(resp.location)
+		if err != nil {
+			return response{}, err
+		}
+
+		wait := c.asyncPollInterval
+		if resp.retryAfter > 0 {
+			wait = resp.retryAfter
+		}
+		tflog.Debug(ctx, "Waiting for asynchronous request", map[string]any{"location": path, "wait": wait.String()})
+
+		select {
+		case <-ctx.Done():
+			return response{}, ctx.Err()
+		case <-time.After(wait):
+		}
+
+		if resp, err = c.sendRetrying(ctx, http.MethodGet, path, nil, "", true); err != nil {
+			return response{}, err
+		}
+	}
+
+	return resp, nil
+}
+
+// apiPath returns the path of the API endpoint made of the parts, such as "domains" and the token of an asset.
+// Each part is escaped, so that tokens and other values given by users cannot change which endpoint is requested.
+func apiPath(parts ...string) string {
+	escaped := make([]string, len(parts))
+	for i, part := range parts {
+		escaped[i] = url.PathEscape(part)
+	}
+
+	return "/v2/" + strings.Join(escaped, "/") + "/"
+}
+
+// locationPath returns the path of a Location header value relative to the base URL of the client.
+// Absolute locations outside of the base URL are refused, so that credentials are never sent elsewhere.
+func (c *Client) locationPath(location string) (string, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return "", fmt.Errorf("parsing status location %q: %w", location, err)
+	}
+
+	if !u.IsAbs() {
+		return location, nil
+	}
+
+	if path, ok := strings.CutPrefix(location, c.baseURL+"/"); ok {
+		return "/" + path, nil
+	}
+
+	return "", fmt.Errorf("status location %q is outside of the API base URL %q", location, c.baseURL)
+}
+
+// response is a response read by send.
+type response struct {
+	body        []byte
+	contentType string
+	header      http.Header
+	// location is the status location of a request accepted for asynchronous processing, if it was.
+	location string
+	// retryAfter is how long to wait before polling the status location, if the response says.
+	retryAfter time.Duration
+}
+
+// jsonContentType is the media type of request and response bodies.
+const jsonContentType = "application/json"
+
+// send sends a single request and returns its response.
+func (c *Client) send(ctx context.Context, method, path string, body []byte, key string) (response, error) {
+	compressed := c.compressMinBytes > 0 && len(body) >= c.compressMinBytes
+	if compressed {
+		var err error
+		if body, err = gzipBody(body); err != nil {
+			return response{}, fmt.Errorf("compressing request body: %w", err)
+		}
+	}
+
+	var r io.Reader
+	if body != nil {
+		r = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, r)
+	if err != nil {
+		return response{}, fmt.Errorf("creating request: %w", err)
+	}
+
+	// The API may reject write requests without a JSON content type, so it is set even if there is no body.
+	req.Header.Set("Accept", jsonContentType)
+	if body != nil || isWrite(method) {
+		req.Header.Set("Content-Type", jsonContentType)
+	}
+
+	if compressed {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+
+	if key != "" {
+		req.Header.Set(idempotencyKeyHeader, key)
+	}
+
+	if respondAsync(ctx) {
+		req.Header.Set("Prefer", "respond-async")
+	}
+
+	cached, isCached := c.cached(method, path)
+	if isCached {
+		if cached.etag != "" {
+			req.Header.Set("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	if c.requestHook != nil {
+		c.requestHook(req)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return response{}, err
+	}
+	defer resp.Body.Close()
+
+	// Read one byte past the limit to tell a body of exactly the limit from a larger one.
+	data, err := io.ReadAll(io.LimitReader(resp.Body, c.maxResponseBytes+1))
+	if err != nil {
+		return response{}, fmt.Errorf("reading response body: %w", err)
+	}
+
+	if int64(len(data)) > c.maxResponseBytes {
+		return response{}, fmt.Errorf("%w: %s %s exceeded the limit of %d bytes", ErrResponseTooLarge, method, path, c.maxResponseBytes)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified && isCached:
+		data = cached.body
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return response{}, &AuthError{APIError: newAPIError(resp.StatusCode, data), Signed: c.signed}
+	case resp.StatusCode < 200 || resp.StatusCode > 299:
+		return response{}, newAPIError(resp.StatusCode, data)
+	case method == http.MethodGet:
+		c.store(path, resp.Header, data)
+	}
+
+	result := response{body: data, contentType: resp.Header.Get("Content-Type"), header: resp.Header}
+	if resp.StatusCode == http.StatusAccepted {
+		result.location = resp.Header.Get("Location")
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds > 0 {
+			result.retryAfter = time.Duration(seconds) * time.Second
+		}
+	}
+
+	return result, nil
+}
+
+// gzipBody returns the body compressed with gzip.
+func gzipBody(body []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(body); err != nil {
+		return nil, err
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// listPages returns the items of a paginated listing, following the pages until the last one.
+// Each page holds the items in the field, and the cursor of the next page in next_cursor,
+// which is empty on the last page. The cursor is sent in the cursor query parameter.
+func listPages[T any](ctx context.Context, c *Client, path string, query url.Values, field string) ([]T, error) {
+	return listPagesUpTo[T](ctx, c, path, query, field, 0)
+}
+
+// listPagesUpTo is like listPages, but stops following the pages once limit items are listed,
+// returning at most limit items. A limit of zero lists all items.
+func listPagesUpTo[T any](ctx context.Context, c *Client, path string, query url.Values, field string, limit int) ([]T, error) {
+	items := []T{}
+	seen := map[string]bool{}
+	cursor := ""
+	for {
+		values := url.Values{}
+		for k, v := range query {
+			values[k] = v
+		}
+		if cursor != "" {
+			values.Set("cursor", cursor)
+		}
+		if n := pageSize(ctx); n > 0 {
+			values.Set("page_size", strconv.FormatInt(n, 10))
+		}
+
+		pagePath := path
+		if len(values) > 0 {
+			pagePath += "?" + values.Encode()
+		}
+
+		var page map[string]json.RawMessage
+		if err := c.do(ctx, http.MethodGet, pagePath, nil, &page); err != nil {
+			return nil, err
+		}
+
+		if raw, ok := page[field]; ok {
+			var pageItems []T
+			if err := json.Unmarshal(raw, &pageItems); err != nil {
+				return nil, fmt.Errorf("decoding %s: %w", field, err)
+			}
+
+			items = append(items, pageItems...)
+		}
+
+		var next string
+		if raw, ok := page["next_cursor"]; ok {
+			if err := json.Unmarshal(raw, &next); err != nil {
+				return nil, fmt.Errorf("decoding next_cursor: %w", err)
+			}
+		}
+
+		if limit > 0 && len(items) >= limit {
+			return items[:limit], nil
+		}
+
+		if next == "" {
+			return items, nil
+		}
+
+		// A cursor that was already followed would page forever.
+		if seen[next] {
+			return nil, fmt.Errorf("listing %s: cursor %q was returned more than once", field, next)
+		}
+		seen[next] = true
+		cursor = next
+	}
+}
+
+// isIdempotent reports whether requests with the method can safely be repeated.
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+// isWrite reports whether requests with the method change resources, and are expected to have a body.
+func isWrite(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch:
+		return true
+	default:
+		return false
+	}
+}
+
+// isTransient reports whether a request failing with err may succeed if retried.
+func isTransient(err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrResponseTooLarge) ||
+		errors.Is(err, ErrCircuitOpen) || errors.Is(err, ErrRedirectRefused) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= 500
+	}
+
+	// A resolver failing temporarily may answer another attempt, but a host that does not exist stays missing.
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return dnsErr.IsTemporary || dnsErr.IsTimeout
+	}
+
+	if isTransientNetworkError(err) {
+		return true
+	}
+
+	// A certificate the server is not trusted with stays untrusted on another attempt.
+	var certErr *tls.CertificateVerificationError
+	var authorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var invalidErr x509.CertificateInvalidError
+	var alertErr tls.AlertError
+	if errors.As(err, &certErr) || errors.As(err, &authorityErr) || errors.As(err, &hostnameErr) ||
+		errors.As(err, &invalidErr) || errors.As(err, &alertErr) {
+		return false
+	}
+
+	// Other errors sending the request are transient only if they come from the network, such as a refused
+	// connection, and not from the request itself, such as an unsupported scheme or too many redirects.
+	var urlErr *url.Error
+	var opErr *net.OpError
+	return errors.As(err, &urlErr) && errors.As(err, &opErr)
+}
+
+// isTransientNetworkError reports whether err is a network error that another attempt may not run into,
+// such as a connection reset or closed by the server, which happens when an idle connection is closed as
+// a request is sent on it, or a timeout such as of the TLS handshake. Unlike errors sending the request,
+// these are also retried when reading the response body.
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNABORTED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+type idempotencyKeyContextKey struct{}
+
+// idempotencyKeyHeader is the header carrying the idempotency key of a request.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// WithIdempotencyKey returns a context whose requests are sent with the idempotency key,
+// allowing requests that are not idempotent by themselves to be retried.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyContextKey{}, key)
+}
+
+// idempotencyKey returns the idempotency key of the context, or an empty string.
+func idempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKeyContextKey{}).(string)
+	return key
+}
+
+type respondAsyncContextKey struct{}
+
+// WithRespondAsync returns a context whose requests ask the API to process them asynchronously,
+// with the Prefer: respond-async header, such as bulk operations that may take long. Requests the API
+// accepts for asynchronous processing are followed to completion as any other.
+func WithRespondAsync(ctx context.Context) context.Context {
+	return context.WithValue(ctx, respondAsyncContextKey{}, true)
+}
+
+// respondAsync reports whether the requests of the context ask for asynchronous processing.
+func respondAsync(ctx context.Context) bool {
+	async, _ := ctx.Value(respondAsyncContextKey{}).(bool)
+	return async
+}
+
+// RawResponse holds the unparsed body and the headers of a response.
+type RawResponse struct {
+	Body   []byte
+	Header http.Header
+}
+
+type pageSizeContextKey struct{}
+
+// MaxPageSize is the largest number of items the API returns in a page of a listing.
+const MaxPageSize = 1000
+
+// WithPageSize returns a context whose listings request pages of n items. The API decides
+// the page size if n is not positive.
+func WithPageSize(ctx context.Context, n int64) context.Context {
+	return context.WithValue(ctx, pageSizeContextKey{}, n)
+}
+
+// pageSize returns the page size of the context, or 0 if the API decides.
+func pageSize(ctx context.Context) int64 {
+	n, _ := ctx.Value(pageSizeContextKey{}).(int64)
+	return max(n, 0)
+}
+
+type rawResponseContextKey struct{}
+
+// WithRawResponse returns a context whose successful requests record their response body and headers in raw.
+// If several requests are made with the context, raw holds the response of the last one.
+func WithRawResponse(ctx context.Context, raw *RawResponse) context.Context {
+	return context.WithValue(ctx, rawResponseContextKey{}, raw)
+}
+
+// rawResponse returns the raw response of the context, or nil.
+func rawResponse(ctx context.Context) *RawResponse {
+	raw, _ := ctx.Value(rawResponseContextKey{}).(*RawResponse)
+	return raw
+}
+
+// APIWarning is a warning returned by the API in the warnings array of a response, such as that a field is deprecated.
+type APIWarning struct {
+	// Field is the name of the field the warning is about, empty if it is about the whole response.
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// APIWarnings collects the warnings of the responses to the requests made with a context.
+type APIWarnings struct {
+	mu       sync.Mutex
+	warnings []APIWarning
+}
+
+// Warnings returns the collected warnings in the order they were first returned, without duplicates.
+func (w *APIWarnings) Warnings() []APIWarning {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return slices.Clone(w.warnings)
+}
+
+// add adds the warnings of a response body. Bodies that are not JSON objects have no warnings.
+func (w *APIWarnings) add(body []byte) {
+	var envelope struct {
+		Warnings []APIWarning `json:"warnings"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, warning := range envelope.Warnings {
+		if warning.Message != "" && !slices.Contains(w.warnings, warning) {
+			w.warnings = append(w.warnings, warning)
+		}
+	}
+}
+
+type apiWarningsContextKey struct{}
+
+// WithAPIWarnings returns a context whose successful requests add the warnings of their responses to warnings.
+func WithAPIWarnings(ctx context.Context, warnings *APIWarnings) context.Context {
+	return context.WithValue(ctx, apiWarningsContextKey{}, warnings)
+}
+
+// apiWarnings returns the warnings collector of the context, or nil.
+func apiWarnings(ctx context.Context) *APIWarnings {
+	warnings, _ := ctx.Value(apiWarningsContextKey{}).(*APIWarnings)
+	return warnings
+}
+
+// cached returns the cached response for a request, if there is one.
+func (c *Client) cached(method, path string) (cachedResponse, bool) {
+	if method != http.MethodGet {
+		return cachedResponse{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.cache[path]
+	return cached, ok
+}
+
+// store caches the response to a GET request if it can be revalidated.
+func (c *Client) store(path string, header http.Header, body []byte) {
+	etag := header.Get("ETag")
+	lastModified := header.Get("Last-Modified")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if etag == "" && lastModified == "" {
+		delete(c.cache, path)
+		return
+	}
+
+	c.cache[path] = cachedResponse{
+		etag:         etag,
+		lastModified: lastModified,
+		body:         body,
+	}
+}
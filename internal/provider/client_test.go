@@ -0,0 +1,924 @@
+package provider_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientErrorBody(t *testing.T) {
+	tests := map[string]struct {
+		body        string
+		expectError string
+	}{
+		"structured": {
+			body:        `{"error": "Not Found", "message": "The domain does not exist"}`,
+			expectError: "unexpected status code 404: Not Found: The domain does not exist",
+		},
+		"message only": {
+			body:        `{"message": "The domain does not exist"}`,
+			expectError: "unexpected status code 404: The domain does not exist",
+		},
+		"error only": {
+			body:        `{"error": "Not Found"}`,
+			expectError: "unexpected status code 404: Not Found",
+		},
+		"unstructured": {
+			body:        `<html><body>Not Found</body></html>`,
+			expectError: "unexpected status code 404: <html><body>Not Found</body></html>",
+		},
+		"unrelated JSON": {
+			body:        `{"status": "missing"}`,
+			expectError: `unexpected status code 404: {"status": "missing"}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(test.body))
+			}))
+
+			_, err := providerData.Client.GetAsset(context.Background(), "aaaa1111")
+			require.EqualError(t, err, test.expectError)
+			require.True(t, provider.IsNotFound(err))
+
+			var apiErr *provider.APIError
+			require.True(t, errors.As(err, &apiErr))
+			require.Equal(t, test.body, apiErr.Body)
+		})
+	}
+}
+
+func TestClientDecodeError(t *testing.T) {
+	body := "<html><body><h1>502 Bad Gateway</h1>" + strings.Repeat("x", 500) + "</body></html>"
+
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(body))
+	}))
+
+	_, err := providerData.Client.GetAsset(context.Background(), "aaaa1111")
+	require.ErrorContains(t, err, "decoding response body: ")
+	require.ErrorContains(t, err, "Content-Type: text/html; charset=utf-8")
+	require.ErrorContains(t, err, "<html><body><h1>502 Bad Gateway</h1>")
+	require.ErrorContains(t, err, "proxy")
+	require.ErrorContains(t, err, "base_url")
+
+	var decodeErr *provider.DecodeError
+	require.True(t, errors.As(err, &decodeErr))
+	require.Equal(t, "text/html; charset=utf-8", decodeErr.ContentType)
+	require.Equal(t, body[:200], decodeErr.Snippet)
+	require.False(t, provider.IsNotFound(err))
+}
+
+func TestClientAuthError(t *testing.T) {
+	tests := map[string]struct {
+		statusCode    int
+		signed        bool
+		expectMessage []string
+	}{
+		"unauthorized": {
+			statusCode:    http.StatusUnauthorized,
+			expectMessage: []string{"rejected the credentials with status 401 Unauthorized", "API key is wrong", `auth_mode "api_key"`},
+		},
+		"unauthorized signed": {
+			statusCode:    http.StatusUnauthorized,
+			signed:        true,
+			expectMessage: []string{"rejected the credentials with status 401 Unauthorized", "API key is wrong", `auth_mode "hmac"`},
+		},
+		"forbidden": {
+			statusCode:    http.StatusForbidden,
+			expectMessage: []string{"denied access with status 403 Forbidden", `auth_mode "api_key"`, "requires signed requests"},
+		},
+		"forbidden signed": {
+			statusCode:    http.StatusForbidden,
+			signed:        true,
+			expectMessage: []string{"denied access with status 403 Forbidden", `auth_mode "hmac"`, "signature is wrong", "secret belongs to the API key"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.statusCode)
+				w.Write([]byte(`{"error": "` + http.StatusText(test.statusCode) + `"}`))
+			}))
+			providerData.Client.SetSignedRequests(test.signed)
+
+			_, err := providerData.Client.GetAsset(context.Background(), "aaaa1111")
+			require.ErrorContains(t, err, "unexpected status code "+strconv.Itoa(test.statusCode)+": "+http.StatusText(test.statusCode)+". ")
+			for _, message := range test.expectMessage {
+				require.ErrorContains(t, err, message)
+			}
+
+			var authErr *provider.AuthError
+			require.True(t, errors.As(err, &authErr))
+			require.Equal(t, test.signed, authErr.Signed)
+
+			var apiErr *provider.APIError
+			require.True(t, errors.As(err, &apiErr))
+			require.Equal(t, test.statusCode, apiErr.StatusCode)
+		})
+	}
+}
+
+func TestClientMaxResponseBytes(t *testing.T) {
+	// A list of assets of exactly 1024 bytes.
+	body := `[{"token": "aaaa1111", "name": "` + strings.Repeat("a", 1024-35) + `"}]`
+	require.Len(t, body, 1024)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	tests := map[string]struct {
+		maxResponseBytes tftypes.Value
+		expectError      bool
+	}{
+		"default": {
+			maxResponseBytes: tftypes.NewValue(tftypes.Number, nil),
+		},
+		"at limit": {
+			maxResponseBytes: tftypes.NewValue(tftypes.Number, 1024),
+		},
+		"over limit": {
+			maxResponseBytes: tftypes.NewValue(tftypes.Number, 1023),
+			expectError:      true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			providerData, diags := configureProvider(t, map[string]tftypes.Value{
+				"api_key":            tftypes.NewValue(tftypes.String, "10840b0f938942feafb7186de74b9682"),
+				"base_url":           tftypes.NewValue(tftypes.String, server.URL),
+				"max_response_bytes": test.maxResponseBytes,
+			})
+			require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+			assets, err := providerData.Client.ListAssets(context.Background())
+			if test.expectError {
+				require.ErrorIs(t, err, provider.ErrResponseTooLarge)
+				require.ErrorContains(t, err, "limit of 1023 bytes")
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, assets, 1)
+		})
+	}
+}
+
+// A request to an API that never responds must return as soon as the context is cancelled,
+// so that interrupting Terraform is not blocked on the request.
+func TestClientContextCancellation(t *testing.T) {
+	received := make(chan struct{})
+	release := make(chan struct{})
+	t.Cleanup(func() { close(release) })
+
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(received)
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errc := make(chan error, 1)
+	go func() {
+		_, err := providerData.Client.GetAsset(ctx, "aaaa1111")
+		errc <- err
+	}()
+
+	<-received
+	cancel()
+
+	select {
+	case err := <-errc:
+		require.ErrorIs(t, err, context.Canceled)
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not cancelled")
+	}
+}
+
+func TestClientRetry(t *testing.T) {
+	tests := map[string]struct {
+		call            func(ctx context.Context, c *provider.Client) error
+		idempotencyKey  string
+		status          int
+		expectAttempts  int
+		expectKeyHeader string
+	}{
+		"GET is retried": {
+			call: func(ctx context.Context, c *provider.Client) error {
+				_, err := c.GetAsset(ctx, "aaaa1111")
+				return err
+			},
+			status:         http.StatusServiceUnavailable,
+			expectAttempts: 3,
+		},
+		"GET is retried when rate limited": {
+			call: func(ctx context.Context, c *provider.Client) error {
+				_, err := c.ListAssets(ctx)
+				return err
+			},
+			status:         http.StatusTooManyRequests,
+			expectAttempts: 3,
+		},
+		"DELETE is retried": {
+			call: func(ctx context.Context, c *provider.Client) error {
+				return c.DeleteAsset(ctx, "aaaa1111")
+			},
+			status:         http.StatusBadGateway,
+			expectAttempts: 3,
+		},
+		"client errors are not retried": {
+			call: func(ctx context.Context, c *provider.Client) error {
+				_, err := c.GetAsset(ctx, "aaaa1111")
+				return err
+			},
+			status:         http.StatusBadRequest,
+			expectAttempts: 1,
+		},
+		"POST without idempotency key is not retried": {
+			call: func(ctx context.Context, c *provider.Client) error {
+				_, err := c.CreateAsset(ctx, provider.AssetRequest{Name: "example.com"})
+				return err
+			},
+			status:         http.StatusServiceUnavailable,
+			expectAttempts: 1,
+		},
+		"POST with idempotency key is retried": {
+			call: func(ctx context.Context, c *provider.Client) error {
+				_, err := c.CreateAsset(ctx, provider.AssetRequest{Name: "example.com"})
+				return err
+			},
+			idempotencyKey:  "create-example.com",
+			status:          http.StatusServiceUnavailable,
+			expectAttempts:  3,
+			expectKeyHeader: "create-example.com",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []*http.Request
+
+			providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				requests = append(requests, r)
+				mu.Unlock()
+
+				w.WriteHeader(test.status)
+			}))
+			providerData.Client.SetRetryPolicy(2, time.Millisecond)
+
+			ctx := context.Background()
+			if test.idempotencyKey != "" {
+				ctx = provider.WithIdempotencyKey(ctx, test.idempotencyKey)
+			}
+
+			err := test.call(ctx, providerData.Client)
+
+			var apiErr *provider.APIError
+			require.True(t, errors.As(err, &apiErr))
+			require.Equal(t, test.status, apiErr.StatusCode)
+
+			require.Len(t, requests, test.expectAttempts)
+			for _, r := range requests {
+				require.Equal(t, test.expectKeyHeader, r.Header.Get("Idempotency-Key"))
+			}
+		})
+	}
+}
+
+func TestClientRetrySucceeds(t *testing.T) {
+	var mu sync.Mutex
+	attempts := 0
+
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Write([]byte(`{"token": "aaaa1111", "name": "example.com"}`))
+	}))
+	providerData.Client.SetRetryPolicy(2, time.Millisecond)
+
+	asset, err := providerData.Client.GetAsset(context.Background(), "aaaa1111")
+	require.NoError(t, err)
+	require.Equal(t, "example.com", asset.Name)
+	require.Equal(t, 2, attempts)
+}
+
+// timeoutError is a network error reporting a timeout, like a TLS handshake timeout.
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "net/http: TLS handshake timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+// failingBody is a response body failing to be read with err.
+type failingBody struct{ err error }
+
+func (b failingBody) Read([]byte) (int, error) { return 0, b.err }
+func (b failingBody) Close() error             { return nil }
+
+func TestClientRetryNetworkErrors(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	connReset := &net.OpError{Op: "read", Net: "tcp", Err: os.NewSyscallError("read", syscall.ECONNRESET)}
+
+	tests := map[string]struct {
+		// fail returns the response or error of a failing attempt.
+		fail           func() (*http.Response, error)
+		method         string
+		expectAttempts int
+	}{
+		"connection reset": {
+			fail:           func() (*http.Response, error) { return nil, connReset },
+			expectAttempts: 2,
+		},
+		"idle connection closed": {
+			fail:           func() (*http.Response, error) { return nil, io.EOF },
+			expectAttempts: 2,
+		},
+		"TLS handshake timeout": {
+			fail:           func() (*http.Response, error) { return nil, timeoutError{} },
+			expectAttempts: 2,
+		},
+		"temporary DNS failure": {
+			fail: func() (*http.Response, error) {
+				return nil, &net.DNSError{Err: "server misbehaving", Name: "api.detectify.com", IsTemporary: true}
+			},
+			expectAttempts: 2,
+		},
+		"DNS timeout": {
+			fail: func() (*http.Response, error) {
+				return nil, &net.DNSError{Err: "i/o timeout", Name: "api.detectify.com", IsTimeout: true}
+			},
+			expectAttempts: 2,
+		},
+		"unknown host is not retried": {
+			fail: func() (*http.Response, error) {
+				return nil, &net.DNSError{Err: "no such host", Name: "api.detectify.com", IsNotFound: true}
+			},
+			expectAttempts: 1,
+		},
+		"connection refused": {
+			fail: func() (*http.Response, error) {
+				return nil, &net.OpError{Op: "dial", Net: "tcp", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}
+			},
+			expectAttempts: 2,
+		},
+		"unsupported scheme is not retried": {
+			fail:           func() (*http.Response, error) { return nil, errors.New(`unsupported protocol scheme "ftp"`) },
+			expectAttempts: 1,
+		},
+		"untrusted certificate is not retried": {
+			fail: func() (*http.Response, error) {
+				return nil, &tls.CertificateVerificationError{Err: x509.UnknownAuthorityError{}}
+			},
+			expectAttempts: 1,
+		},
+		"connection reset reading the body": {
+			fail: func() (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: failingBody{err: connReset}}, nil
+			},
+			expectAttempts: 2,
+		},
+		"body cut short": {
+			fail: func() (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: failingBody{err: io.ErrUnexpectedEOF}}, nil
+			},
+			expectAttempts: 2,
+		},
+		"other errors reading the body are not retried": {
+			fail: func() (*http.Response, error) {
+				return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: failingBody{err: errors.New("malformed chunked encoding")}}, nil
+			},
+			expectAttempts: 1,
+		},
+		"POST without idempotency key is not retried": {
+			fail:           func() (*http.Response, error) { return nil, connReset },
+			method:         http.MethodPost,
+			expectAttempts: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			attempts := 0
+			rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				attempts++
+				if attempts == 1 {
+					return test.fail()
+				}
+
+				return cannedResponse(http.StatusOK, `{"token": "aaaa1111", "name": "example.com"}`), nil
+			})
+
+			providerData, diags := configureProviderWith(context.Background(), t, provider.NewWithHTTPTransport("test", rt)(), nil)
+			require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+			providerData.Client.SetRetryPolicy(2, time.Millisecond)
+
+			var err error
+			if test.method == http.MethodPost {
+				_, err = providerData.Client.CreateAsset(context.Background(), provider.AssetRequest{Name: "example.com"})
+			} else {
+				_, err = providerData.Client.GetAsset(context.Background(), "aaaa1111")
+			}
+
+			require.Equal(t, test.expectAttempts, attempts)
+			if test.expectAttempts == 1 {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestClientPermanentSendErrorsNotRetried(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	t.Run("self-signed certificate", func(t *testing.T) {
+		var connections atomic.Int64
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				connections.Add(1)
+			}
+		}
+		server.Config.ErrorLog = log.New(io.Discard, "", 0)
+		server.StartTLS()
+		t.Cleanup(server.Close)
+
+		providerData, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url": tftypes.NewValue(tftypes.String, server.URL),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		providerData.Client.SetRetryPolicy(2, time.Millisecond)
+
+		_, err := providerData.Client.GetAsset(context.Background(), "aaaa1111")
+		var certErr *tls.CertificateVerificationError
+		require.ErrorAs(t, err, &certErr)
+		require.EqualValues(t, 1, connections.Load())
+	})
+
+	t.Run("too many redirects", func(t *testing.T) {
+		var requests atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			http.Redirect(w, r, r.URL.Path, http.StatusFound)
+		}))
+		t.Cleanup(server.Close)
+
+		providerData, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url": tftypes.NewValue(tftypes.String, server.URL),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		providerData.Client.SetRetryPolicy(2, time.Millisecond)
+
+		// The redirects of a single attempt are followed, and the attempt is not retried.
+		_, err := providerData.Client.GetAsset(context.Background(), "aaaa1111")
+		require.ErrorContains(t, err, "stopped after 10 redirects")
+		require.EqualValues(t, 10, requests.Load())
+	})
+
+	t.Run("redirect outside base URL", func(t *testing.T) {
+		var requests atomic.Int64
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			http.Redirect(w, r, "https://elsewhere.example.com/v2/domains/aaaa1111/", http.StatusFound)
+		}))
+		t.Cleanup(server.Close)
+
+		providerData, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url": tftypes.NewValue(tftypes.String, server.URL),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		providerData.Client.SetRetryPolicy(2, time.Millisecond)
+
+		_, err := providerData.Client.GetAsset(context.Background(), "aaaa1111")
+		require.ErrorIs(t, err, provider.ErrRedirectRefused)
+		require.EqualValues(t, 1, requests.Load())
+	})
+}
+
+func TestClientEscapesPathParts(t *testing.T) {
+	var paths []string
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.RequestURI())
+		w.Write([]byte(`{"token": "aaaa1111", "name": "example.com"}`))
+	}))
+
+	// A token with characters that are special in paths cannot change which endpoint is requested.
+	const token = "a/b?c#d"
+	_, err := providerData.Client.GetAsset(context.Background(), token)
+	require.NoError(t, err)
+	_, err = providerData.Client.GetAssetSettings(context.Background(), token)
+	require.NoError(t, err)
+	_, err = providerData.Client.GetScanProfile(context.Background(), token)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		"/v2/domains/a%2Fb%3Fc%23d/",
+		"/v2/domains/a%2Fb%3Fc%23d/settings/",
+		"/v2/profiles/a%2Fb%3Fc%23d/",
+	}, paths)
+}
+
+func TestClientRequestHook(t *testing.T) {
+	var headers []string
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Get("X-Test-Hook"))
+
+		if r.URL.Path == "/v2/domains/" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`{"token": "aaaa1111", "name": "example.com"}`))
+	}))
+
+	var observed []string
+	providerData.Client.SetRequestHook(func(r *http.Request) {
+		observed = append(observed, r.Method+" "+r.URL.Path)
+		r.Header.Set("X-Test-Hook", "called")
+	})
+
+	_, err := providerData.Client.GetAsset(context.Background(), "aaaa1111")
+	require.NoError(t, err)
+	_, err = providerData.Client.ListAssets(context.Background())
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"GET /v2/domains/aaaa1111/", "GET /v2/domains/"}, observed)
+	require.Equal(t, []string{"called", "called"}, headers)
+
+	// Requests are sent unchanged once the hook is removed.
+	providerData.Client.SetRequestHook(nil)
+
+	_, err = providerData.Client.ListAssets(context.Background())
+	require.NoError(t, err)
+	require.Len(t, observed, 2)
+	require.Equal(t, "", headers[2])
+}
+
+func TestClientContentType(t *testing.T) {
+	tests := map[string]struct {
+		call              func(context.Context, *provider.Client) error
+		expectContentType string
+	}{
+		"create": {
+			call: func(ctx context.Context, c *provider.Client) error {
+				_, err := c.CreateAsset(ctx, provider.AssetRequest{Name: "example.com"})
+				return err
+			},
+			expectContentType: "application/json",
+		},
+		"update": {
+			call: func(ctx context.Context, c *provider.Client) error {
+				_, err := c.UpdateAsset(ctx, "aaaa1111", provider.AssetRequest{Name: "example.com"})
+				return err
+			},
+			expectContentType: "application/json",
+		},
+		"patch": {
+			call: func(ctx context.Context, c *provider.Client) error {
+				_, err := c.PatchAsset(ctx, "aaaa1111", provider.AssetPatchRequest{Notes: ptr("Production")})
+				return err
+			},
+			expectContentType: "application/json",
+		},
+		"write without body": {
+			call: func(ctx context.Context, c *provider.Client) error {
+				return c.AttachScanProfile(ctx, "profile0001", "aaaa1111")
+			},
+			expectContentType: "application/json",
+		},
+		"read": {
+			call: func(ctx context.Context, c *provider.Client) error {
+				_, err := c.GetAsset(ctx, "aaaa1111")
+				return err
+			},
+			expectContentType: "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var received *http.Request
+			providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				w.Write([]byte(`{"token": "aaaa1111", "name": "example.com"}`))
+			}))
+
+			require.NoError(t, test.call(context.Background(), providerData.Client))
+			require.NotNil(t, received)
+
+			require.Equal(t, "application/json", received.Header.Get("Accept"))
+			require.Equal(t, test.expectContentType, received.Header.Get("Content-Type"))
+		})
+	}
+}
+
+func TestClientAPIWarnings(t *testing.T) {
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/domains/":
+			w.Write([]byte(`[{"token": "aaaa1111", "name": "example.com"}]`))
+		default:
+			w.Write([]byte(`{
+				"token": "aaaa1111",
+				"name": "example.com",
+				"warnings": [{"field": "notes", "message": "Use description instead."}]
+			}`))
+		}
+	}))
+
+	var warnings provider.APIWarnings
+	ctx := provider.WithAPIWarnings(context.Background(), &warnings)
+
+	// The warnings of all requests are collected, once each, and listings have none.
+	for i := 0; i < 2; i++ {
+		_, err := providerData.Client.GetAsset(ctx, "aaaa1111")
+		require.NoError(t, err)
+	}
+	_, err := providerData.Client.ListAssets(ctx)
+	require.NoError(t, err)
+
+	require.Equal(t, []provider.APIWarning{{Field: "notes", Message: "Use description instead."}}, warnings.Warnings())
+}
+
+func TestClientGetAssets(t *testing.T) {
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+
+		token := strings.Trim(strings.TrimPrefix(r.URL.Path, "/v2/domains/"), "/")
+		if token == "missing0000" {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, provider.Asset{Token: token, Name: token + ".example.com"})
+	}))
+
+	tokens := []string{"token0001", "token0002", "missing0000", "token0003", "token0004", "token0005", "token0006"}
+	assets, errs := providerData.Client.GetAssets(context.Background(), tokens, 3)
+
+	require.Len(t, assets, len(tokens))
+	require.Len(t, errs, len(tokens))
+	for i, token := range tokens {
+		if token == "missing0000" {
+			require.Nil(t, assets[i])
+			require.True(t, provider.IsNotFound(errs[i]), "unexpected error: %v", errs[i])
+			continue
+		}
+
+		require.NoError(t, errs[i])
+		require.Equal(t, token, assets[i].Token)
+	}
+
+	require.LessOrEqual(t, maxInFlight, 3)
+	require.Greater(t, maxInFlight, 1)
+}
+
+func TestClientRespondAsync(t *testing.T) {
+	for name, location := range map[string]func(baseURL string) string{
+		"relative": func(string) string { return "/v2/operations/op0001/" },
+		"absolute": func(baseURL string) string { return baseURL + "/v2/operations/op0001/" },
+	} {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var requests []*http.Request
+			polls := 0
+
+			var server *httptest.Server
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				requests = append(requests, r)
+
+				switch r.Method + " " + r.URL.Path {
+				case "POST /v2/domains/":
+					w.Header().Set("Location", location(server.URL))
+					writeJSON(w, http.StatusAccepted, map[string]string{"status": "pending"})
+				case "GET /v2/operations/op0001/":
+					polls++
+					if polls < 3 {
+						w.Header().Set("Location", location(server.URL))
+						writeJSON(w, http.StatusAccepted, map[string]string{"status": "running"})
+						return
+					}
+					http.Redirect(w, r, "/v2/domains/aaaa1111/", http.StatusSeeOther)
+				case "GET /v2/domains/aaaa1111/":
+					writeJSON(w, http.StatusOK, provider.Asset{Token: "aaaa1111", Name: "example.com"})
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			t.Cleanup(server.Close)
+
+			client := provider.NewClient(server.Client(), server.URL)
+			client.SetAsyncPollInterval(time.Millisecond)
+
+			asset, err := client.CreateAsset(provider.WithRespondAsync(context.Background()), provider.AssetRequest{Name: "example.com"})
+			require.NoError(t, err)
+			require.Equal(t, "aaaa1111", asset.Token)
+
+			require.Len(t, requests, 5)
+			require.Equal(t, "respond-async", requests[0].Header.Get("Prefer"))
+			for _, r := range requests[1:] {
+				require.Equal(t, http.MethodGet, r.Method)
+				require.Empty(t, r.Header.Values("Prefer"))
+			}
+		})
+	}
+}
+
+func TestClientRespondAsyncErrors(t *testing.T) {
+	tests := map[string]struct {
+		location string
+		status   int
+		expect   string
+	}{
+		"outside base URL": {
+			location: "https://example.com/v2/operations/op0001/",
+			status:   http.StatusAccepted,
+			expect:   `status location "https://example.com/v2/operations/op0001/" is outside of the API base URL`,
+		},
+		"failed": {
+			location: "/v2/operations/op0001/",
+			status:   http.StatusUnprocessableEntity,
+			expect:   "422",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					w.Header().Set("Location", test.location)
+					w.WriteHeader(http.StatusAccepted)
+					return
+				}
+
+				writeJSON(w, test.status, map[string]string{"error": "Unprocessable Entity", "message": "The operation failed"})
+			}))
+			providerData.Client.SetAsyncPollInterval(time.Millisecond)
+
+			_, err := providerData.Client.CreateAsset(context.Background(), provider.AssetRequest{Name: "example.com"})
+			require.ErrorContains(t, err, test.expect)
+		})
+	}
+
+	t.Run("canceled", func(t *testing.T) {
+		providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Location", "/v2/operations/op0001/")
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		providerData.Client.SetAsyncPollInterval(time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := providerData.Client.CreateAsset(ctx, provider.AssetRequest{Name: "example.com"})
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	// Cancelling the context, as Terraform does when interrupted, stops the wait for the next poll right away.
+	t.Run("canceled while waiting", func(t *testing.T) {
+		polled := make(chan struct{}, 1)
+		providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Location", "/v2/operations/op0001/")
+			if r.Method == http.MethodGet {
+				// The operation never completes, and asks to be polled again in an hour.
+				w.Header().Set("Retry-After", "3600")
+				select {
+				case polled <- struct{}{}:
+				default:
+				}
+			}
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		providerData.Client.SetAsyncPollInterval(time.Millisecond)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		errc := make(chan error, 1)
+		go func() {
+			_, err := providerData.Client.CreateAsset(ctx, provider.AssetRequest{Name: "example.com"})
+			errc <- err
+		}()
+
+		// Cancel once the response of the first poll is read, and the client waits for the next one.
+		<-polled
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+
+		select {
+		case err := <-errc:
+			require.ErrorIs(t, err, context.Canceled)
+		case <-time.After(5 * time.Second):
+			t.Fatal("the wait for the asynchronous request was not cancelled")
+		}
+	})
+}
+
+func TestClientWaitForAsset(t *testing.T) {
+	tests := map[string]struct {
+		notFound       int
+		expectAttempts int
+		expectNotFound bool
+	}{
+		"readable": {
+			notFound:       0,
+			expectAttempts: 1,
+		},
+		"readable after retries": {
+			notFound:       2,
+			expectAttempts: 3,
+		},
+		"never readable": {
+			notFound:       10,
+			expectAttempts: 3,
+			expectNotFound: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			attempts := 0
+
+			providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+
+				attempts++
+				if attempts <= test.notFound {
+					http.NotFound(w, r)
+					return
+				}
+
+				w.Write([]byte(`{"token": "aaaa1111", "name": "example.com"}`))
+			}))
+			providerData.Client.SetRetryPolicy(2, time.Millisecond)
+
+			asset, err := providerData.Client.WaitForAsset(context.Background(), "aaaa1111")
+			require.Equal(t, test.expectAttempts, attempts)
+
+			if test.expectNotFound {
+				require.True(t, provider.IsNotFound(err), "unexpected error: %v", err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, "example.com", asset.Name)
+		})
+	}
+}
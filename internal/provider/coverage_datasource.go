@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &CoverageDataSource{}
+
+func NewCoverageDataSource() datasource.DataSource {
+	return &CoverageDataSource{}
+}
+
+// CoverageDataSource defines the data source implementation.
+type CoverageDataSource struct {
+	client *Client
+}
+
+// CoverageDataSourceModel describes the data source data model.
+type CoverageDataSourceModel struct {
+	TotalAssets       types.Int64 `tfsdk:"total_assets"`
+	MonitoredAssets   types.Int64 `tfsdk:"monitored_assets"`
+	UnmonitoredAssets types.Int64 `tfsdk:"unmonitored_assets"`
+	VerifiedAssets    types.Int64 `tfsdk:"verified_assets"`
+}
+
+// coverage is the monitoring coverage of the assets of an account.
+type coverage struct {
+	total       int64
+	monitored   int64
+	unmonitored int64
+	verified    int64
+}
+
+// assetCoverage returns the monitoring coverage of the assets.
+// Assets are monitored while they are active, and assets that do not report a status are assumed to be.
+func assetCoverage(assets []Asset) coverage {
+	var c coverage
+	for _, asset := range assets {
+		c.total++
+
+		if asset.Status == "" || asset.Status == AssetStatusActive {
+			c.monitored++
+		} else {
+			c.unmonitored++
+		}
+
+		if asset.Verified != nil && *asset.Verified {
+			c.verified++
+		}
+	}
+
+	return c
+}
+
+func (d *CoverageDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_coverage"
+}
+
+func (d *CoverageDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Summarizes the monitoring coverage of all assets available to the API key, " +
+			"such as for dashboards and compliance reporting. The summary is computed from a single listing of the assets.",
+
+		Attributes: map[string]schema.Attribute{
+			"total_assets": schema.Int64Attribute{
+				MarkdownDescription: "The number of assets.",
+				Computed:            true,
+			},
+			"monitored_assets": schema.Int64Attribute{
+				MarkdownDescription: "The number of assets that are monitored, which are those with the status `active`. " +
+					"Assets the API reports no status for are counted as monitored.",
+				Computed: true,
+			},
+			"unmonitored_assets": schema.Int64Attribute{
+				MarkdownDescription: "The number of assets that are not monitored, such as paused or archived assets.",
+				Computed:            true,
+			},
+			"verified_assets": schema.Int64Attribute{
+				MarkdownDescription: "The number of assets whose ownership is verified.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *CoverageDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *CoverageDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data CoverageDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assets, err := d.client.ListAssets(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list assets, got error: %s", err))
+		return
+	}
+
+	summary := assetCoverage(assets)
+
+	data.TotalAssets = types.Int64Value(summary.total)
+	data.MonitoredAssets = types.Int64Value(summary.monitored)
+	data.UnmonitoredAssets = types.Int64Value(summary.unmonitored)
+	data.VerifiedAssets = types.Int64Value(summary.verified)
+
+	tflog.Trace(ctx, "read coverage", map[string]any{"total": summary.total})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,50 @@
+package provider_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+)
+
+func TestCoverageDataSource(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if r.URL.Path == "/rest/v2/account/" {
+			return cannedResponse(http.StatusOK, `{"name": "Example Inc"}`), nil
+		}
+
+		if r.URL.Path != "/rest/v2/domains/" {
+			return cannedResponse(http.StatusNotFound, `{"error": "Not Found"}`), nil
+		}
+
+		return cannedResponse(http.StatusOK, `[
+			{"token": "aaaa1111", "name": "example.com", "status": "active", "verified": true},
+			{"token": "bbbb2222", "name": "example.org", "status": "paused", "verified": true},
+			{"token": "cccc3333", "name": "example.net", "status": "archived", "verified": false},
+			{"token": "dddd4444", "name": "example.io"}
+		]`), nil
+	})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"detectify": providerserver.NewProtocol6WithError(provider.NewWithHTTPTransport("test", rt)()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: `data "detectify_coverage" "test" {}`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.detectify_coverage.test", "total_assets", "4"),
+					// Assets without a status are counted as monitored.
+					resource.TestCheckResourceAttr("data.detectify_coverage.test", "monitored_assets", "2"),
+					resource.TestCheckResourceAttr("data.detectify_coverage.test", "unmonitored_assets", "2"),
+					resource.TestCheckResourceAttr("data.detectify_coverage.test", "verified_assets", "2"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ function.Function = &IsValidDomainFunction{}
+	_ function.Function = &NormalizeDomainFunction{}
+)
+
+func NewIsValidDomainFunction() function.Function {
+	return &IsValidDomainFunction{}
+}
+
+// IsValidDomainFunction reports whether a string is a domain name that can be added as an asset.
+type IsValidDomainFunction struct{}
+
+func (f *IsValidDomainFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_domain"
+}
+
+func (f *IsValidDomainFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether a string is a valid asset domain",
+		MarkdownDescription: "Returns `true` if the domain is a valid asset domain after normalization, using the same rules as the provider. " +
+			"See `normalize_domain` for the normalization rules.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "The domain name to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *IsValidDomainFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain string
+
+	resp.Error = req.Arguments.Get(ctx, &domain)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, isValidDomain(domain))
+}
+
+func NewNormalizeDomainFunction() function.Function {
+	return &NormalizeDomainFunction{}
+}
+
+// NormalizeDomainFunction returns the normalized form of a domain name.
+type NormalizeDomainFunction struct{}
+
+func (f *NormalizeDomainFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "normalize_domain"
+}
+
+func (f *NormalizeDomainFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Normalizes a domain name",
+		MarkdownDescription: "Returns the domain in the form expected by the `domain` attribute of `detectify_asset`: " +
+			"lower case, without surrounding whitespace or a trailing dot. Fails if the result is not a valid domain.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "domain",
+				MarkdownDescription: "The domain name to normalize.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *NormalizeDomainFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var domain string
+
+	resp.Error = req.Arguments.Get(ctx, &domain)
+	if resp.Error != nil {
+		return
+	}
+
+	if !isValidDomain(domain) {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid domain: "+domain)
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, normalizeDomain(domain))
+}
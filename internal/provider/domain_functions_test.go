@@ -0,0 +1,66 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+// runFunction runs f with a single string argument, returning the result.
+func runFunction(t *testing.T, f function.Function, arg string, result attr.Value) *function.RunResponse {
+	t.Helper()
+
+	resp := &function.RunResponse{
+		Result: function.NewResultData(result),
+	}
+
+	f.Run(context.Background(), function.RunRequest{
+		Arguments: function.NewArgumentsData([]attr.Value{types.StringValue(arg)}),
+	}, resp)
+
+	return resp
+}
+
+func TestIsValidDomainFunction(t *testing.T) {
+	tests := map[string]bool{
+		"example.com":      true,
+		"www.example.com":  true,
+		"Example.COM":      true,
+		" example.com. ":   true,
+		"localhost":        false,
+		"":                 false,
+		"not a domain":     false,
+		"example..com":     false,
+		"https://test.com": false,
+	}
+
+	for domain, want := range tests {
+		resp := runFunction(t, provider.NewIsValidDomainFunction(), domain, types.BoolUnknown())
+		require.Nil(t, resp.Error, domain)
+		require.Equal(t, types.BoolValue(want), resp.Result.Value(), domain)
+	}
+}
+
+func TestNormalizeDomainFunction(t *testing.T) {
+	tests := map[string]string{
+		"example.com":      "example.com",
+		"Example.COM":      "example.com",
+		" www.example.com": "www.example.com",
+		"example.com.":     "example.com",
+	}
+
+	for domain, want := range tests {
+		resp := runFunction(t, provider.NewNormalizeDomainFunction(), domain, types.StringUnknown())
+		require.Nil(t, resp.Error, domain)
+		require.Equal(t, types.StringValue(want), resp.Result.Value(), domain)
+	}
+
+	resp := runFunction(t, provider.NewNormalizeDomainFunction(), "not a domain", types.StringUnknown())
+	require.NotNil(t, resp.Error)
+	require.Equal(t, int64(0), *resp.Error.FunctionArgument)
+}
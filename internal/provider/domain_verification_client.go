@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+)
+
+// Statuses of a domain verification.
+const (
+	VerificationStatusPending  = "pending"
+	VerificationStatusVerified = "verified"
+	VerificationStatusFailed   = "failed"
+)
+
+// verificationMethods are the ways ownership of a domain can be verified.
+var verificationMethods = []string{"dns-txt", "file"}
+
+// DomainVerification is the verification of the ownership of a domain, as represented by the Detectify API.
+type DomainVerification struct {
+	Domain string `json:"domain"`
+	// Method is how ownership is verified, one of dns-txt and file.
+	Method string `json:"method"`
+	// Token is the value to place in the DNS TXT record, or in the verification file.
+	Token  string `json:"token"`
+	Status string `json:"status"`
+}
+
+// DomainVerificationRequest is the request body used when starting the verification of a domain.
+type DomainVerificationRequest struct {
+	Domain string `json:"domain"`
+	Method string `json:"method"`
+}
+
+// domainVerificationPath returns the path of the verification of the domain.
+func domainVerificationPath(domain string) string {
+	return apiPath("verifications", domain)
+}
+
+// StartDomainVerification starts verifying the ownership of a domain, or returns the verification
+// if one is already in progress.
+func (c *Client) StartDomainVerification(ctx context.Context, body DomainVerificationRequest) (*DomainVerification, error) {
+	var verification DomainVerification
+	if err := c.do(ctx, http.MethodPost, "/v2/verifications/", body, &verification); err != nil {
+		return nil, err
+	}
+
+	return &verification, nil
+}
+
+// GetDomainVerification returns the verification of the ownership of the domain.
+func (c *Client) GetDomainVerification(ctx context.Context, domain string) (*DomainVerification, error) {
+	var verification DomainVerification
+	if err := c.do(ctx, http.MethodGet, domainVerificationPath(domain), nil, &verification); err != nil {
+		return nil, err
+	}
+
+	return &verification, nil
+}
+
+// CancelDomainVerification cancels the verification of the ownership of the domain. Domains that
+// are already verified stay verified.
+func (c *Client) CancelDomainVerification(ctx context.Context, domain string) error {
+	return c.do(ctx, http.MethodDelete, domainVerificationPath(domain), nil, nil)
+}
@@ -0,0 +1,407 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// DefaultVerificationTimeout is how long to wait for a domain to be verified, unless configured otherwise.
+const DefaultVerificationTimeout = 20 * time.Minute
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &DomainVerificationResource{}
+	_ resource.ResourceWithImportState = &DomainVerificationResource{}
+)
+
+func NewDomainVerificationResource() resource.Resource {
+	return &DomainVerificationResource{}
+}
+
+// DomainVerificationResource defines the resource implementation.
+type DomainVerificationResource struct {
+	client *Client
+}
+
+// DomainVerificationResourceModel describes the resource data model.
+type DomainVerificationResourceModel struct {
+	Domain            types.String `tfsdk:"domain"`
+	Method            types.String `tfsdk:"method"`
+	VerificationToken types.String `tfsdk:"verification_token"`
+	Status            types.String `tfsdk:"status"`
+
+	WaitForVerification types.Bool   `tfsdk:"wait_for_verification"`
+	PollInterval        types.String `tfsdk:"poll_interval"`
+	FailOnTimeout       types.Bool   `tfsdk:"fail_on_timeout"`
+	Timeouts            types.Object `tfsdk:"timeouts"`
+}
+
+// verificationTimeoutsModel describes the timeouts block of the domain verification resource.
+type verificationTimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Update types.String `tfsdk:"update"`
+}
+
+func (r *DomainVerificationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain_verification"
+}
+
+func (r *DomainVerificationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Verifies the ownership of a domain, and waits until it is verified. " +
+			"Place `verification_token` in a DNS TXT record or verification file, depending on `method`. " +
+			"To create the record with Terraform, first apply with `wait_for_verification` set to `false`, " +
+			"then set it to `true` to wait for the verification. Destroying the resource cancels a pending verification.",
+
+		Attributes: map[string]schema.Attribute{
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "The domain to verify. Changing it verifies the new domain instead.",
+				Required:            true,
+				Validators: []validator.String{
+					domainValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"method": schema.StringAttribute{
+				MarkdownDescription: "How ownership is verified, one of `dns-txt` and `file`. Defaults to `dns-txt`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("dns-txt"),
+				Validators: []validator.String{
+					stringvalidator.OneOf(verificationMethods...),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"verification_token": schema.StringAttribute{
+				MarkdownDescription: "The value to place in the DNS TXT record or verification file.",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the verification, one of `pending`, `verified` and `failed`.",
+				Computed:            true,
+			},
+			"wait_for_verification": schema.BoolAttribute{
+				MarkdownDescription: "Whether to wait until the domain is verified when applying. Defaults to `true`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"poll_interval": schema.StringAttribute{
+				MarkdownDescription: "How often the verification status is checked while waiting. Defaults to `10s`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("10s"),
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"fail_on_timeout": schema.BoolAttribute{
+				MarkdownDescription: "Whether applying fails if the domain is not verified within the timeout. " +
+					"A failed create leaves the resource in the state as tainted, so that the next apply replaces it and starts a new verification. " +
+					"If `false`, applying succeeds with a warning instead, keeping the resource with `status` `pending`. " +
+					"The verification is then not waited for again until the resource is updated, such as by changing `timeouts`. " +
+					"Defaults to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"timeouts": schema.SingleNestedBlock{
+				MarkdownDescription: "How long to wait for the domain to be verified. When the timeout passes, applying fails or warns depending on `fail_on_timeout`.",
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{
+						MarkdownDescription: "Timeout when creating the resource. Defaults to `20m`.",
+						Optional:            true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+					},
+					"update": schema.StringAttribute{
+						MarkdownDescription: "Timeout when updating the resource, such as to start waiting. Defaults to `20m`.",
+						Optional:            true,
+						Validators: []validator.String{
+							durationValidator{},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *DomainVerificationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *DomainVerificationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DomainVerificationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	verification, err := r.client.StartDomainVerification(ctx, DomainVerificationRequest{
+		Domain: data.Domain.ValueString(),
+		Method: data.Method.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to start domain verification, got error: %s", err))
+		return
+	}
+
+	data.update(verification)
+
+	tflog.Trace(ctx, "started a domain verification", map[string]any{"domain": verification.Domain})
+
+	timeout, diags := data.timeout(ctx, "create")
+	resp.Diagnostics.Append(diags...)
+
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(r.wait(ctx, &data, timeout)...)
+	}
+
+	// Save data into Terraform state, even if the verification did not finish, so that it can be cancelled.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainVerificationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DomainVerificationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	verification, err := r.client.GetDomainVerification(ctx, data.Domain.ValueString())
+	if IsNotFound(err) {
+		tflog.Warn(ctx, "domain verification not found, removing from state", map[string]any{"domain": data.Domain.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain verification, got error: %s", err))
+		return
+	}
+
+	data.update(verification)
+
+	// Attributes that only exist in Terraform are not known when importing.
+	if data.WaitForVerification.IsNull() {
+		data.WaitForVerification = types.BoolValue(true)
+	}
+
+	if data.PollInterval.IsNull() {
+		data.PollInterval = types.StringValue("10s")
+	}
+
+	if data.FailOnTimeout.IsNull() {
+		data.FailOnTimeout = types.BoolValue(true)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainVerificationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DomainVerificationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the waiting can change in place, so there is nothing to update in the API.
+	verification, err := r.client.GetDomainVerification(ctx, data.Domain.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read domain verification, got error: %s", err))
+		return
+	}
+
+	data.update(verification)
+
+	timeout, diags := data.timeout(ctx, "update")
+	resp.Diagnostics.Append(diags...)
+
+	if !resp.Diagnostics.HasError() {
+		resp.Diagnostics.Append(r.wait(ctx, &data, timeout)...)
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DomainVerificationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DomainVerificationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A verified domain stays verified, so there is nothing to cancel.
+	if data.Status.ValueString() == VerificationStatusVerified {
+		return
+	}
+
+	err := r.client.CancelDomainVerification(ctx, data.Domain.ValueString())
+	if err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to cancel domain verification, got error: %s", err))
+		return
+	}
+}
+
+func (r *DomainVerificationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("domain"), req, resp)
+}
+
+// wait polls the verification until the domain is verified, if waiting is enabled, updating the model
+// with the last status.
+func (r *DomainVerificationResource) wait(ctx context.Context, data *DomainVerificationResourceModel, timeout time.Duration) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !data.WaitForVerification.ValueBool() {
+		return diags
+	}
+
+	// The poll interval is validated by the schema.
+	interval, _ := time.ParseDuration(data.PollInterval.ValueString())
+
+	waitCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		switch data.Status.ValueString() {
+		case VerificationStatusVerified:
+			return diags
+		case VerificationStatusFailed:
+			diags.AddError(
+				"Verification Failed",
+				fmt.Sprintf("The ownership of %q could not be verified. Check that the verification token is in place, "+
+					"then replace the resource to try again.", data.Domain.ValueString()),
+			)
+			return diags
+		}
+
+		tflog.Debug(ctx, "Waiting for domain verification", map[string]any{"domain": data.Domain.ValueString(), "status": data.Status.ValueString()})
+
+		select {
+		case <-waitCtx.Done():
+			// Only the timeout of the wait is a timeout, as Terraform cancels the context when it is interrupted.
+			if err := ctx.Err(); err != nil {
+				diags.AddError("Client Error", fmt.Sprintf("Unable to wait for domain verification, got error: %s", err))
+				return diags
+			}
+
+			summary := "Verification Timed Out"
+			detail := fmt.Sprintf("The ownership of %q was not verified within %s. Check that the verification token is in place, "+
+				"or increase the timeout.", data.Domain.ValueString(), timeout)
+
+			if data.FailOnTimeout.ValueBool() {
+				diags.AddError(summary, detail)
+			} else {
+				diags.AddWarning(summary, detail+" The verification is kept pending, and is waited for again when the resource is updated.")
+			}
+			return diags
+		case <-time.After(interval):
+		}
+
+		verification, err := r.client.GetDomainVerification(waitCtx, data.Domain.ValueString())
+		if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+			continue
+		}
+
+		if err != nil {
+			diags.AddError("Client Error", fmt.Sprintf("Unable to read domain verification, got error: %s", err))
+			return diags
+		}
+
+		data.update(verification)
+	}
+}
+
+// timeout returns the configured timeout of the operation, or the default if it is not set.
+func (m *DomainVerificationResourceModel) timeout(ctx context.Context, operation string) (time.Duration, diag.Diagnostics) {
+	if m.Timeouts.IsNull() || m.Timeouts.IsUnknown() {
+		return DefaultVerificationTimeout, nil
+	}
+
+	var timeouts verificationTimeoutsModel
+	diags := m.Timeouts.As(ctx, &timeouts, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return 0, diags
+	}
+
+	value := timeouts.Create
+	if operation == "update" {
+		value = timeouts.Update
+	}
+
+	if value.IsNull() || value.IsUnknown() {
+		return DefaultVerificationTimeout, diags
+	}
+
+	// The timeouts are validated by the schema.
+	d, _ := time.ParseDuration(value.ValueString())
+
+	return d, diags
+}
+
+// update sets the model values from the API representation of the verification.
+func (m *DomainVerificationResourceModel) update(verification *DomainVerification) {
+	m.Domain = types.StringValue(verification.Domain)
+	m.Method = types.StringValue(verification.Method)
+	m.VerificationToken = types.StringValue(verification.Token)
+	m.Status = types.StringValue(verification.Status)
+}
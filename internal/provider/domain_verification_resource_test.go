@@ -0,0 +1,268 @@
+package provider_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	fwresource "github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccDomainVerificationResource(t *testing.T) {
+	api := newFakeAPI(t)
+	api.verificationPolls = 2
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_domain_verification" "test" {
+  domain = "example.com"
+  method = "email"
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				// The verification is pending for two polls before it is verified.
+				Config: api.providerConfig() + `
+resource "detectify_domain_verification" "test" {
+  domain        = "example.com"
+  poll_interval = "10ms"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_domain_verification.test", "status", "verified"),
+					resource.TestCheckResourceAttr("detectify_domain_verification.test", "method", "dns-txt"),
+					resource.TestMatchResourceAttr("detectify_domain_verification.test", "verification_token", regexp.MustCompile(`^detectify-verification=`)),
+				),
+			},
+			{
+				ResourceName:                         "detectify_domain_verification.test",
+				ImportState:                          true,
+				ImportStateId:                        "example.com",
+				ImportStateVerify:                    true,
+				ImportStateVerifyIdentifierAttribute: "domain",
+				ImportStateVerifyIgnore:              []string{"poll_interval"},
+			},
+		},
+	})
+}
+
+func TestAccDomainVerificationResourceWaitLater(t *testing.T) {
+	api := newFakeAPI(t)
+	api.verificationPolls = 3
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The token is known without waiting, so that it can be put in place.
+				Config: api.providerConfig() + `
+resource "detectify_domain_verification" "test" {
+  domain                = "example.com"
+  method                = "file"
+  wait_for_verification = false
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_domain_verification.test", "status", "pending"),
+					resource.TestCheckResourceAttrSet("detectify_domain_verification.test", "verification_token"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_domain_verification" "test" {
+  domain                = "example.com"
+  method                = "file"
+  wait_for_verification = true
+  poll_interval         = "10ms"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_domain_verification.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_domain_verification.test", "status", "verified"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDomainVerificationResourceTimeout(t *testing.T) {
+	api := newFakeAPI(t)
+	api.verificationPolls = 1000
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			api.mu.Lock()
+			defer api.mu.Unlock()
+
+			// The pending verification is cancelled.
+			for domain := range api.verifications {
+				return fmt.Errorf("verification of %q still exists", domain)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_domain_verification" "test" {
+  domain        = "example.com"
+  poll_interval = "10ms"
+
+  timeouts {
+    create = "100ms"
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`Verification Timed Out`),
+			},
+		},
+	})
+}
+
+func TestAccDomainVerificationResourceTimeoutWarning(t *testing.T) {
+	api := newFakeAPI(t)
+	api.verificationPolls = 30
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The timeout only warns, keeping the pending verification in the state.
+				Config: api.providerConfig() + `
+resource "detectify_domain_verification" "test" {
+  domain          = "example.com"
+  poll_interval   = "10ms"
+  fail_on_timeout = false
+
+  timeouts {
+    create = "100ms"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_domain_verification.test", "status", "pending"),
+					resource.TestCheckResourceAttr("detectify_domain_verification.test", "fail_on_timeout", "false"),
+					resource.TestCheckResourceAttrSet("detectify_domain_verification.test", "verification_token"),
+				),
+			},
+			{
+				// Updating the resource waits for the verification again.
+				Config: api.providerConfig() + `
+resource "detectify_domain_verification" "test" {
+  domain          = "example.com"
+  poll_interval   = "10ms"
+  fail_on_timeout = false
+
+  timeouts {
+    create = "100ms"
+    update = "1m"
+  }
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_domain_verification.test", plancheck.ResourceActionUpdate),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_domain_verification.test", "status", "verified"),
+				),
+			},
+		},
+	})
+}
+
+// Cancelling the context, as Terraform does when interrupted, stops the wait for the verification right away
+// with an error, rather than at the next poll or as a timeout.
+func TestDomainVerificationResourceCancel(t *testing.T) {
+	api := newFakeAPI(t)
+	api.verificationPolls = 1000
+
+	started := make(chan struct{})
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		api.ServeHTTP(w, r)
+		if r.Method == http.MethodPost {
+			close(started)
+		}
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	r := provider.NewDomainVerificationResource()
+	r.(fwresource.ResourceWithConfigure).Configure(ctx, fwresource.ConfigureRequest{ProviderData: providerData}, &fwresource.ConfigureResponse{})
+
+	var schemaResp fwresource.SchemaResponse
+	r.Schema(ctx, fwresource.SchemaRequest{}, &schemaResp)
+	require.False(t, schemaResp.Diagnostics.HasError())
+
+	req := fwresource.CreateRequest{
+		Plan: tfsdk.Plan{
+			Schema: schemaResp.Schema,
+			Raw: schemaValue(t, schemaResp.Schema, map[string]tftypes.Value{
+				"domain":                tftypes.NewValue(tftypes.String, "example.com"),
+				"method":                tftypes.NewValue(tftypes.String, "dns-txt"),
+				"verification_token":    tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"status":                tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"wait_for_verification": tftypes.NewValue(tftypes.Bool, true),
+				"poll_interval":         tftypes.NewValue(tftypes.String, "1h"),
+				"fail_on_timeout":       tftypes.NewValue(tftypes.Bool, true),
+			}),
+		},
+	}
+	resp := &fwresource.CreateResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	done := make(chan struct{})
+	go func() {
+		r.Create(ctx, req, resp)
+		close(done)
+	}()
+
+	// Cancel once the response of the start is read, and the resource waits for the next poll in an hour.
+	<-started
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the wait for the verification was not cancelled")
+	}
+
+	require.True(t, resp.Diagnostics.HasError())
+	require.Equal(t, "Client Error", resp.Diagnostics.Errors()[0].Summary())
+	require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "Unable to wait for domain verification, got error: context canceled")
+
+	// The started verification is still saved, so that it can be cancelled.
+	var status string
+	require.False(t, resp.State.GetAttribute(ctx, path.Root("status"), &status).HasError())
+	require.Equal(t, provider.VerificationStatusPending, status)
+}
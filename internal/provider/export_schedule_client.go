@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+)
+
+// exportFormats are the file formats findings can be exported in.
+var exportFormats = []string{"csv", "json", "sarif"}
+
+// exportFrequencies are how often a findings export can be run.
+var exportFrequencies = []string{"daily", "weekly", "monthly"}
+
+// findingSeverities are the severities of findings, from least to most severe.
+var findingSeverities = []string{"information", "low", "medium", "high", "critical"}
+
+// ExportSchedule is a recurring export of findings, as represented by the Detectify API.
+type ExportSchedule struct {
+	ID     string `json:"id"`
+	Format string `json:"format"`
+	// Destination is the email address or HTTPS URL the export is delivered to.
+	Destination string `json:"destination"`
+	Frequency   string `json:"frequency"`
+	// Filters limit the findings exported, all findings being exported if nil.
+	Filters   *ExportFilters `json:"filters,omitempty"`
+	NextRunAt string         `json:"next_run_at"`
+}
+
+// ExportFilters limit the findings included in an export. Filters that are empty do not limit the findings.
+type ExportFilters struct {
+	AssetTokens []string `json:"asset_tokens,omitempty"`
+	Severities  []string `json:"severities,omitempty"`
+}
+
+// ExportScheduleRequest is the request body used when creating or updating an export schedule.
+type ExportScheduleRequest struct {
+	Format      string         `json:"format"`
+	Destination string         `json:"destination"`
+	Frequency   string         `json:"frequency"`
+	Filters     *ExportFilters `json:"filters,omitempty"`
+}
+
+// GetExportSchedule returns the export schedule identified by id.
+func (c *Client) GetExportSchedule(ctx context.Context, id string) (*ExportSchedule, error) {
+	var schedule ExportSchedule
+	if err := c.do(ctx, http.MethodGet, apiPath("exports", "schedules", id), nil, &schedule); err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+// CreateExportSchedule adds a new export schedule.
+func (c *Client) CreateExportSchedule(ctx context.Context, body ExportScheduleRequest) (*ExportSchedule, error) {
+	var schedule ExportSchedule
+	if err := c.do(ctx, http.MethodPost, "/v2/exports/schedules/", body, &schedule); err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+// UpdateExportSchedule replaces the export schedule identified by id.
+func (c *Client) UpdateExportSchedule(ctx context.Context, id string, body ExportScheduleRequest) (*ExportSchedule, error) {
+	var schedule ExportSchedule
+	if err := c.do(ctx, http.MethodPut, apiPath("exports", "schedules", id), body, &schedule); err != nil {
+		return nil, err
+	}
+
+	return &schedule, nil
+}
+
+// DeleteExportSchedule removes the export schedule identified by id.
+func (c *Client) DeleteExportSchedule(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, apiPath("exports", "schedules", id), nil, nil)
+}
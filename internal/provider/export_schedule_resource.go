@@ -0,0 +1,318 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &ExportScheduleResource{}
+	_ resource.ResourceWithImportState = &ExportScheduleResource{}
+)
+
+func NewExportScheduleResource() resource.Resource {
+	return &ExportScheduleResource{}
+}
+
+// ExportScheduleResource defines the resource implementation.
+type ExportScheduleResource struct {
+	client *Client
+}
+
+// ExportScheduleResourceModel describes the resource data model.
+type ExportScheduleResourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	Format      types.String `tfsdk:"format"`
+	Destination types.String `tfsdk:"destination"`
+	Frequency   types.String `tfsdk:"frequency"`
+	Filters     types.Object `tfsdk:"filters"`
+	NextRunAt   types.String `tfsdk:"next_run_at"`
+}
+
+// exportFiltersModel describes the filters of an export schedule.
+type exportFiltersModel struct {
+	AssetTokens types.Set `tfsdk:"asset_tokens"`
+	Severities  types.Set `tfsdk:"severities"`
+}
+
+// exportFiltersAttrTypes are the attribute types of exportFiltersModel.
+var exportFiltersAttrTypes = map[string]attr.Type{
+	"asset_tokens": types.SetType{ElemType: types.StringType},
+	"severities":   types.SetType{ElemType: types.StringType},
+}
+
+func (r *ExportScheduleResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_export_schedule"
+}
+
+func (r *ExportScheduleResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a recurring export of findings, delivered by email or to an HTTPS URL.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The export schedule identifier.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"format": schema.StringAttribute{
+				MarkdownDescription: "The file format of the export, one of `" + strings.Join(exportFormats, "`, `") + "`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(exportFormats...),
+				},
+			},
+			"destination": schema.StringAttribute{
+				MarkdownDescription: "The email address or HTTPS URL to deliver the export to.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"frequency": schema.StringAttribute{
+				MarkdownDescription: "How often the export is run, one of `" + strings.Join(exportFrequencies, "`, `") + "`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(exportFrequencies...),
+				},
+			},
+			"filters": schema.SingleNestedAttribute{
+				MarkdownDescription: "Limits the findings exported. All findings are exported if not set.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"asset_tokens": schema.SetAttribute{
+						MarkdownDescription: "The tokens of the assets to export the findings of. Findings of all assets are exported if not set.",
+						ElementType:         types.StringType,
+						Optional:            true,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+						},
+					},
+					"severities": schema.SetAttribute{
+						MarkdownDescription: "The severities of the findings to export, any of `" + strings.Join(findingSeverities, "`, `") + "`. " +
+							"Findings of all severities are exported if not set.",
+						ElementType: types.StringType,
+						Optional:    true,
+						Validators: []validator.Set{
+							setvalidator.SizeAtLeast(1),
+							setvalidator.ValueStringsAre(stringvalidator.OneOf(findingSeverities...)),
+						},
+					},
+				},
+			},
+			"next_run_at": schema.StringAttribute{
+				MarkdownDescription: "When the export is next run, in RFC 3339 format.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (r *ExportScheduleResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *ExportScheduleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ExportScheduleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := data.request(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, err := r.client.CreateExportSchedule(ctx, body)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create export schedule, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.update(ctx, schedule)...)
+
+	tflog.Trace(ctx, "created an export schedule", map[string]any{"id": schedule.ID})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExportScheduleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ExportScheduleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, err := r.client.GetExportSchedule(ctx, data.ID.ValueString())
+	if IsNotFound(err) {
+		tflog.Warn(ctx, "export schedule not found, removing from state", map[string]any{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read export schedule, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.update(ctx, schedule)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExportScheduleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ExportScheduleResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := data.request(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	schedule, err := r.client.UpdateExportSchedule(ctx, data.ID.ValueString(), body)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update export schedule, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.update(ctx, schedule)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ExportScheduleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ExportScheduleResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteExportSchedule(ctx, data.ID.ValueString())
+	if err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete export schedule, got error: %s", err))
+		return
+	}
+}
+
+func (r *ExportScheduleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// request builds the request body from the model.
+func (m *ExportScheduleResourceModel) request(ctx context.Context) (ExportScheduleRequest, diag.Diagnostics) {
+	body := ExportScheduleRequest{
+		Format:      m.Format.ValueString(),
+		Destination: m.Destination.ValueString(),
+		Frequency:   m.Frequency.ValueString(),
+	}
+
+	if m.Filters.IsNull() || m.Filters.IsUnknown() {
+		return body, nil
+	}
+
+	var filters exportFiltersModel
+	diags := m.Filters.As(ctx, &filters, basetypes.ObjectAsOptions{})
+
+	body.Filters = &ExportFilters{}
+	if !filters.AssetTokens.IsNull() {
+		diags.Append(filters.AssetTokens.ElementsAs(ctx, &body.Filters.AssetTokens, false)...)
+	}
+	if !filters.Severities.IsNull() {
+		diags.Append(filters.Severities.ElementsAs(ctx, &body.Filters.Severities, false)...)
+	}
+
+	return body, diags
+}
+
+// update sets the model values from the API representation of the export schedule.
+func (m *ExportScheduleResourceModel) update(ctx context.Context, schedule *ExportSchedule) diag.Diagnostics {
+	m.ID = types.StringValue(schedule.ID)
+	m.Format = types.StringValue(schedule.Format)
+	m.Destination = types.StringValue(schedule.Destination)
+	m.Frequency = types.StringValue(schedule.Frequency)
+	m.NextRunAt = types.StringValue(schedule.NextRunAt)
+
+	m.Filters = types.ObjectNull(exportFiltersAttrTypes)
+	if schedule.Filters == nil {
+		return nil
+	}
+
+	// Empty filters are null, as they are not set in the configuration.
+	filters := exportFiltersModel{
+		AssetTokens: types.SetNull(types.StringType),
+		Severities:  types.SetNull(types.StringType),
+	}
+
+	var diags, d diag.Diagnostics
+	if len(schedule.Filters.AssetTokens) > 0 {
+		filters.AssetTokens, d = types.SetValueFrom(ctx, types.StringType, schedule.Filters.AssetTokens)
+		diags.Append(d...)
+	}
+	if len(schedule.Filters.Severities) > 0 {
+		filters.Severities, d = types.SetValueFrom(ctx, types.StringType, schedule.Filters.Severities)
+		diags.Append(d...)
+	}
+
+	m.Filters, d = types.ObjectValueFrom(ctx, exportFiltersAttrTypes, filters)
+	diags.Append(d...)
+
+	return diags
+}
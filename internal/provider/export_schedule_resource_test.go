@@ -0,0 +1,127 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccExportScheduleResource(t *testing.T) {
+	api := newFakeAPI(t)
+	asset := api.addAsset("example.com")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkExportSchedulesDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_export_schedule" "test" {
+  format      = "xlsx"
+  destination = "security@example.com"
+  frequency   = "weekly"
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_export_schedule" "test" {
+  format      = "csv"
+  destination = "security@example.com"
+  frequency   = "hourly"
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_export_schedule" "test" {
+  format      = "csv"
+  destination = "security@example.com"
+  frequency   = "weekly"
+  filters = {
+    severities = ["severe"]
+  }
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_export_schedule" "test" {
+  format      = "csv"
+  destination = "security@example.com"
+  frequency   = "weekly"
+  filters = {
+    asset_tokens = ["` + asset + `"]
+    severities   = ["high", "critical"]
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("detectify_export_schedule.test", "id"),
+					resource.TestCheckResourceAttr("detectify_export_schedule.test", "format", "csv"),
+					resource.TestCheckResourceAttr("detectify_export_schedule.test", "destination", "security@example.com"),
+					resource.TestCheckResourceAttr("detectify_export_schedule.test", "frequency", "weekly"),
+					resource.TestCheckResourceAttr("detectify_export_schedule.test", "next_run_at", "2026-10-19T00:00:00Z"),
+					resource.TestCheckResourceAttr("detectify_export_schedule.test", "filters.asset_tokens.#", "1"),
+					resource.TestCheckTypeSetElemAttr("detectify_export_schedule.test", "filters.asset_tokens.*", asset),
+					resource.TestCheckResourceAttr("detectify_export_schedule.test", "filters.severities.#", "2"),
+					resource.TestCheckTypeSetElemAttr("detectify_export_schedule.test", "filters.severities.*", "critical"),
+				),
+			},
+			{
+				// Changing the frequency and removing a filter updates the schedule in place.
+				Config: api.providerConfig() + `
+resource "detectify_export_schedule" "test" {
+  format      = "csv"
+  destination = "security@example.com"
+  frequency   = "daily"
+  filters = {
+    severities = ["high", "critical"]
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_export_schedule.test", "frequency", "daily"),
+					resource.TestCheckResourceAttr("detectify_export_schedule.test", "next_run_at", "2026-10-15T00:00:00Z"),
+					resource.TestCheckNoResourceAttr("detectify_export_schedule.test", "filters.asset_tokens"),
+					resource.TestCheckResourceAttr("detectify_export_schedule.test", "filters.severities.#", "2"),
+					func(*terraform.State) error {
+						api.mu.Lock()
+						defer api.mu.Unlock()
+
+						require.Len(t, api.exportSchedules, 1)
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "detectify_export_schedule.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Without filters, all findings are exported.
+				Config: api.providerConfig() + `
+resource "detectify_export_schedule" "test" {
+  format      = "sarif"
+  destination = "https://exports.example.com/detectify"
+  frequency   = "monthly"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_export_schedule.test", "format", "sarif"),
+					resource.TestCheckResourceAttr("detectify_export_schedule.test", "destination", "https://exports.example.com/detectify"),
+					resource.TestCheckResourceAttr("detectify_export_schedule.test", "frequency", "monthly"),
+					resource.TestCheckNoResourceAttr("detectify_export_schedule.test", "filters"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+)
+
+// FindingComment is a triage comment on a finding, as represented by the Detectify API.
+type FindingComment struct {
+	ID        string `json:"id"`
+	Text      string `json:"text"`
+	CreatedAt string `json:"created_at"`
+}
+
+// FindingCommentRequest is the request body used when commenting on a finding.
+type FindingCommentRequest struct {
+	Text string `json:"text"`
+}
+
+// findingCommentsPath returns the path of the comments of the finding identified by uuid.
+func findingCommentsPath(uuid string) string {
+	return apiPath("findings", uuid, "comments")
+}
+
+// CreateFindingComment adds a comment to the finding identified by uuid.
+func (c *Client) CreateFindingComment(ctx context.Context, uuid string, body FindingCommentRequest) (*FindingComment, error) {
+	var comment FindingComment
+	if err := c.do(ctx, http.MethodPost, findingCommentsPath(uuid), body, &comment); err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// GetFindingComment returns the comment identified by id on the finding identified by uuid.
+func (c *Client) GetFindingComment(ctx context.Context, uuid, id string) (*FindingComment, error) {
+	var comment FindingComment
+	if err := c.do(ctx, http.MethodGet, apiPath("findings", uuid, "comments", id), nil, &comment); err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+// DeleteFindingComment removes the comment identified by id from the finding identified by uuid.
+func (c *Client) DeleteFindingComment(ctx context.Context, uuid, id string) error {
+	return c.do(ctx, http.MethodDelete, apiPath("findings", uuid, "comments", id), nil, nil)
+}
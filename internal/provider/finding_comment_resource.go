@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &FindingCommentResource{}
+
+func NewFindingCommentResource() resource.Resource {
+	return &FindingCommentResource{}
+}
+
+// FindingCommentResource defines the resource implementation.
+type FindingCommentResource struct {
+	client *Client
+}
+
+// FindingCommentResourceModel describes the resource data model.
+type FindingCommentResourceModel struct {
+	FindingUUID types.String `tfsdk:"finding_uuid"`
+	Comment     types.String `tfsdk:"comment"`
+	ID          types.String `tfsdk:"id"`
+	CreatedAt   types.String `tfsdk:"created_at"`
+}
+
+func (r *FindingCommentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_finding_comment"
+}
+
+func (r *FindingCommentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Posts a triage comment on a finding, such as to record why it was accepted. " +
+			"Comments cannot be edited, so changing the comment replaces it.",
+
+		Attributes: map[string]schema.Attribute{
+			"finding_uuid": schema.StringAttribute{
+				MarkdownDescription: "The UUID of the finding.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"comment": schema.StringAttribute{
+				MarkdownDescription: "The text of the comment.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the comment.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"created_at": schema.StringAttribute{
+				MarkdownDescription: "When the comment was posted, as an RFC 3339 timestamp.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *FindingCommentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *FindingCommentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data FindingCommentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	comment, err := r.client.CreateFindingComment(ctx, data.FindingUUID.ValueString(), FindingCommentRequest{
+		Text: data.Comment.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create finding comment, got error: %s", err))
+		return
+	}
+
+	data.update(comment)
+
+	tflog.Trace(ctx, "created a finding comment", map[string]any{"finding_uuid": data.FindingUUID.ValueString(), "id": comment.ID})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FindingCommentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data FindingCommentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	comment, err := r.client.GetFindingComment(ctx, data.FindingUUID.ValueString(), data.ID.ValueString())
+	if IsNotFound(err) {
+		tflog.Warn(ctx, "Finding comment not found, removing from state", map[string]any{
+			"finding_uuid": data.FindingUUID.ValueString(),
+			"id":           data.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read finding comment, got error: %s", err))
+		return
+	}
+
+	data.update(comment)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *FindingCommentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All configurable attributes require replacement, so there is nothing to update.
+	resp.Diagnostics.AddError(
+		"Unexpected Update",
+		"Finding comments cannot be updated in place. Please report this issue to the provider developers.",
+	)
+}
+
+func (r *FindingCommentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data FindingCommentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteFindingComment(ctx, data.FindingUUID.ValueString(), data.ID.ValueString())
+	if err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete finding comment, got error: %s", err))
+		return
+	}
+}
+
+// update sets the model values from the API representation of the comment.
+func (m *FindingCommentResourceModel) update(comment *FindingComment) {
+	m.ID = types.StringValue(comment.ID)
+	m.Comment = types.StringValue(comment.Text)
+	m.CreatedAt = types.StringValue(comment.CreatedAt)
+}
@@ -0,0 +1,90 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccFindingCommentResource(t *testing.T) {
+	api := newFakeAPI(t)
+
+	const uuid = "3b241101-e2bb-4255-8caf-4136c566a962"
+	api.addFinding(uuid)
+
+	config := func(comment string) string {
+		return api.providerConfig() + fmt.Sprintf(`
+resource "detectify_finding_comment" "test" {
+  finding_uuid = %q
+  comment      = %q
+}
+`, uuid, comment)
+	}
+
+	// checkComments verifies the comments on the finding.
+	checkComments := func(texts ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			if got := api.findingComments(uuid); !slices.Equal(got, texts) {
+				return fmt.Errorf("expected comments %q, got %q", texts, got)
+			}
+
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             checkComments(),
+		Steps: []resource.TestStep{
+			{
+				Config:      config(""),
+				ExpectError: regexp.MustCompile(`string length must be at least 1`),
+			},
+			{
+				Config: config("Accepted risk"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_finding_comment.test", "comment", "Accepted risk"),
+					resource.TestCheckResourceAttrSet("detectify_finding_comment.test", "id"),
+					resource.TestCheckResourceAttr("detectify_finding_comment.test", "created_at", "2024-05-02T09:30:00Z"),
+					checkComments("Accepted risk"),
+				),
+			},
+			{
+				// Comments cannot be edited, so the old comment is removed when a new one is posted.
+				Config: config("Fixed in release 1.2"),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_finding_comment.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: checkComments("Fixed in release 1.2"),
+			},
+		},
+	})
+}
+
+func TestAccFindingCommentResourceMissingFinding(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_finding_comment" "test" {
+  finding_uuid = "00000000-0000-0000-0000-000000000000"
+  comment      = "Accepted risk"
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to create finding comment`),
+			},
+		},
+	})
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// Finding is a vulnerability found on an asset, as represented by the Detectify API.
+type Finding struct {
+	UUID      string `json:"uuid"`
+	Title     string `json:"title"`
+	Severity  string `json:"severity"`
+	FirstSeen string `json:"first_seen"`
+}
+
+// FindingsQuery are the options of a findings listing. Options left empty are not sent.
+type FindingsQuery struct {
+	OrderBy string
+	Order   string
+	// FirstSeenAfter and FirstSeenBefore are RFC 3339 timestamps bounding when findings were first seen.
+	FirstSeenAfter  string
+	FirstSeenBefore string
+}
+
+// ListFindings returns the findings of the asset identified by token.
+func (c *Client) ListFindings(ctx context.Context, token string, q FindingsQuery) ([]Finding, error) {
+	values := url.Values{}
+	if q.OrderBy != "" {
+		values.Set("order_by", q.OrderBy)
+	}
+	if q.Order != "" {
+		values.Set("order", q.Order)
+	}
+	if q.FirstSeenAfter != "" {
+		values.Set("first_seen_after", q.FirstSeenAfter)
+	}
+	if q.FirstSeenBefore != "" {
+		values.Set("first_seen_before", q.FirstSeenBefore)
+	}
+
+	path := apiPath("domains", token, "findings")
+	if len(values) > 0 {
+		path += "?" + values.Encode()
+	}
+
+	var findings []Finding
+	if err := c.do(ctx, http.MethodGet, path, nil, &findings); err != nil {
+		return nil, err
+	}
+
+	return findings, nil
+}
@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &FindingsDataSource{}
+
+func NewFindingsDataSource() datasource.DataSource {
+	return &FindingsDataSource{}
+}
+
+// FindingsDataSource defines the data source implementation.
+type FindingsDataSource struct {
+	client *Client
+}
+
+// FindingsDataSourceModel describes the data source data model.
+type FindingsDataSourceModel struct {
+	AssetToken types.String `tfsdk:"asset_token"`
+	OrderBy    types.String `tfsdk:"order_by"`
+	Order      types.String `tfsdk:"order"`
+	Findings   types.List   `tfsdk:"findings"`
+
+	FirstSeenAfter  types.String `tfsdk:"first_seen_after"`
+	FirstSeenBefore types.String `tfsdk:"first_seen_before"`
+
+	IncludeRaw      types.Bool   `tfsdk:"include_raw"`
+	RawJSON         types.String `tfsdk:"raw_json"`
+	ExposeHeaders   types.List   `tfsdk:"expose_headers"`
+	ResponseHeaders types.Map    `tfsdk:"response_headers"`
+}
+
+// findingModel describes a finding in the findings data source.
+type findingModel struct {
+	UUID      types.String `tfsdk:"uuid"`
+	Title     types.String `tfsdk:"title"`
+	Severity  types.String `tfsdk:"severity"`
+	FirstSeen types.String `tfsdk:"first_seen"`
+}
+
+// findingAttrTypes are the attribute types of findingModel.
+var findingAttrTypes = map[string]attr.Type{
+	"uuid":       types.StringType,
+	"title":      types.StringType,
+	"severity":   types.StringType,
+	"first_seen": types.StringType,
+}
+
+const (
+	defaultFindingsOrderBy = "first_seen"
+	defaultFindingsOrder   = "asc"
+)
+
+// findingsOrderBy are the fields findings can be ordered by.
+var findingsOrderBy = []string{"severity", "first_seen", "title"}
+
+// severityRanks orders severities from least to most severe. Unknown severities rank lowest.
+var severityRanks = map[string]int{
+	"information": 1,
+	"low":         2,
+	"medium":      3,
+	"high":        4,
+	"critical":    5,
+}
+
+func (d *FindingsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_findings"
+}
+
+func (d *FindingsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the findings of an asset.",
+
+		Attributes: map[string]schema.Attribute{
+			"asset_token": schema.StringAttribute{
+				MarkdownDescription: "The token of the asset.",
+				Required:            true,
+			},
+			"order_by": schema.StringAttribute{
+				MarkdownDescription: "The field to order findings by, one of `severity`, `first_seen` and `title`. " +
+					"Defaults to `" + defaultFindingsOrderBy + "`. Findings with equal values are ordered by UUID, " +
+					"and findings with a first seen time that cannot be parsed come last when ordering by `first_seen`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(findingsOrderBy...),
+				},
+			},
+			"order": schema.StringAttribute{
+				MarkdownDescription: "The direction to order findings in, `asc` or `desc`. Defaults to `" + defaultFindingsOrder + "`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("asc", "desc"),
+				},
+			},
+			"first_seen_after": schema.StringAttribute{
+				MarkdownDescription: "Only return findings first seen after this RFC 3339 timestamp, such as `2024-05-01T00:00:00Z`.",
+				Optional:            true,
+				Validators: []validator.String{
+					timestampValidator{},
+				},
+			},
+			"first_seen_before": schema.StringAttribute{
+				MarkdownDescription: "Only return findings first seen before this RFC 3339 timestamp, such as `2024-06-01T00:00:00Z`.",
+				Optional:            true,
+				Validators: []validator.String{
+					timestampValidator{},
+				},
+			},
+			"findings": schema.ListNestedAttribute{
+				MarkdownDescription: "The findings, in the requested order.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"uuid": schema.StringAttribute{
+							MarkdownDescription: "The finding identifier.",
+							Computed:            true,
+						},
+						"title": schema.StringAttribute{
+							MarkdownDescription: "The title of the finding.",
+							Computed:            true,
+						},
+						"severity": schema.StringAttribute{
+							MarkdownDescription: "The severity of the finding.",
+							Computed:            true,
+						},
+						"first_seen": schema.StringAttribute{
+							MarkdownDescription: "When the finding was first seen, as an RFC 3339 timestamp.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"include_raw":      includeRawAttribute(),
+			"raw_json":         rawJSONAttribute(),
+			"expose_headers":   exposeHeadersAttribute(),
+			"response_headers": responseHeadersAttribute(),
+		},
+	}
+}
+
+func (d *FindingsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *FindingsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data FindingsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	q := FindingsQuery{
+		OrderBy: defaultFindingsOrderBy,
+		Order:   defaultFindingsOrder,
+	}
+
+	if !data.OrderBy.IsNull() {
+		q.OrderBy = data.OrderBy.ValueString()
+	}
+
+	if !data.Order.IsNull() {
+		q.Order = data.Order.ValueString()
+	}
+
+	var after, before time.Time
+	if !data.FirstSeenAfter.IsNull() {
+		q.FirstSeenAfter = data.FirstSeenAfter.ValueString()
+		after, _ = time.Parse(time.RFC3339, q.FirstSeenAfter)
+	}
+
+	if !data.FirstSeenBefore.IsNull() {
+		q.FirstSeenBefore = data.FirstSeenBefore.ValueString()
+		before, _ = time.Parse(time.RFC3339, q.FirstSeenBefore)
+	}
+
+	clientCtx, raw := withRawResponse(ctx, data.IncludeRaw, data.ExposeHeaders)
+
+	findings, err := d.client.ListFindings(clientCtx, data.AssetToken.ValueString(), q)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list findings, got error: %s", err))
+		return
+	}
+
+	// The API may not support filtering by when findings were first seen, so they are also filtered here.
+	if !after.IsZero() || !before.IsZero() {
+		findings = filterFindingsByFirstSeen(findings, after, before)
+	}
+
+	// The API may not support ordering, so findings are always sorted to keep the result deterministic.
+	sortFindings(findings, q)
+
+	items := make([]findingModel, len(findings))
+	for i, finding := range findings {
+		items[i] = findingModel{
+			UUID:      types.StringValue(finding.UUID),
+			Title:     types.StringValue(finding.Title),
+			Severity:  types.StringValue(finding.Severity),
+			FirstSeen: types.StringValue(finding.FirstSeen),
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.Findings, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: findingAttrTypes}, items)
+	resp.Diagnostics.Append(diags...)
+
+	data.RawJSON = rawJSONValue(raw, data.IncludeRaw)
+
+	responseHeaders, diags := responseHeadersValue(ctx, raw, data.ExposeHeaders)
+	resp.Diagnostics.Append(diags...)
+	data.ResponseHeaders = responseHeaders
+
+	tflog.Trace(ctx, "read findings", map[string]any{"asset_token": data.AssetToken.ValueString(), "count": len(findings)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// filterFindingsByFirstSeen returns the findings first seen after after and before before.
+// Either bound is ignored if zero. Findings with a first seen time that cannot be parsed are left out.
+func filterFindingsByFirstSeen(findings []Finding, after, before time.Time) []Finding {
+	filtered := []Finding{}
+	for _, finding := range findings {
+		firstSeen, err := time.Parse(time.RFC3339, finding.FirstSeen)
+		if err != nil {
+			continue
+		}
+
+		if (!after.IsZero() && !firstSeen.After(after)) || (!before.IsZero() && !firstSeen.Before(before)) {
+			continue
+		}
+
+		filtered = append(filtered, finding)
+	}
+
+	return filtered
+}
+
+// sortFindings sorts the findings by the field and direction of the query, and then by UUID.
+// When ordering by first seen time, findings with a time that cannot be parsed go last in either direction.
+func sortFindings(findings []Finding, q FindingsQuery) {
+	compare := func(a, b Finding) int {
+		switch q.OrderBy {
+		case "severity":
+			return severityRanks[a.Severity] - severityRanks[b.Severity]
+		case "title":
+			return strings.Compare(a.Title, b.Title)
+		default:
+			// Timestamps are compared as times, as their offsets and fractional seconds may differ.
+			aFirstSeen, _ := time.Parse(time.RFC3339Nano, a.FirstSeen)
+			bFirstSeen, _ := time.Parse(time.RFC3339Nano, b.FirstSeen)
+			return aFirstSeen.Compare(bFirstSeen)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if q.OrderBy == "first_seen" {
+			_, errI := time.Parse(time.RFC3339Nano, findings[i].FirstSeen)
+			_, errJ := time.Parse(time.RFC3339Nano, findings[j].FirstSeen)
+			if (errI == nil) != (errJ == nil) {
+				return errI == nil
+			}
+		}
+
+		c := compare(findings[i], findings[j])
+		if q.Order == "desc" {
+			c = -c
+		}
+
+		if c == 0 {
+			return findings[i].UUID < findings[j].UUID
+		}
+
+		return c < 0
+	})
+}
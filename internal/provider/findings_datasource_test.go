@@ -0,0 +1,301 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+// findingsHandler returns the findings of asset aaaa1111 in no particular order,
+// ignoring ordering parameters, and records the query of each request.
+func findingsHandler(queries *[]url.Values, mu *sync.Mutex) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/domains/aaaa1111/findings/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		mu.Lock()
+		*queries = append(*queries, r.URL.Query())
+		mu.Unlock()
+
+		w.Write([]byte(`[
+			{"uuid": "3", "title": "Open redirect", "severity": "medium", "first_seen": "2024-03-01T00:00:00Z"},
+			{"uuid": "1", "title": "SQL injection", "severity": "critical", "first_seen": "2024-02-01T00:00:00Z"},
+			{"uuid": "4", "title": "Missing header", "severity": "information", "first_seen": "2024-01-01T00:00:00Z"},
+			{"uuid": "2", "title": "Cross-site scripting", "severity": "medium", "first_seen": "2024-02-01T00:00:00Z"}
+		]`))
+	})
+}
+
+func TestFindingsDataSourceOrder(t *testing.T) {
+	tests := map[string]struct {
+		orderBy   tftypes.Value
+		order     tftypes.Value
+		wantQuery url.Values
+		wantUUIDs []string
+	}{
+		"default": {
+			orderBy:   tftypes.NewValue(tftypes.String, nil),
+			order:     tftypes.NewValue(tftypes.String, nil),
+			wantQuery: url.Values{"order_by": {"first_seen"}, "order": {"asc"}},
+			wantUUIDs: []string{"4", "1", "2", "3"},
+		},
+		"first_seen desc": {
+			orderBy:   tftypes.NewValue(tftypes.String, "first_seen"),
+			order:     tftypes.NewValue(tftypes.String, "desc"),
+			wantQuery: url.Values{"order_by": {"first_seen"}, "order": {"desc"}},
+			wantUUIDs: []string{"3", "1", "2", "4"},
+		},
+		"severity desc": {
+			orderBy:   tftypes.NewValue(tftypes.String, "severity"),
+			order:     tftypes.NewValue(tftypes.String, "desc"),
+			wantQuery: url.Values{"order_by": {"severity"}, "order": {"desc"}},
+			wantUUIDs: []string{"1", "2", "3", "4"},
+		},
+		"title": {
+			orderBy:   tftypes.NewValue(tftypes.String, "title"),
+			order:     tftypes.NewValue(tftypes.String, nil),
+			wantQuery: url.Values{"order_by": {"title"}, "order": {"asc"}},
+			wantUUIDs: []string{"2", "4", "3", "1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var queries []url.Values
+			handler := findingsHandler(&queries, &mu)
+
+			// Reading twice gives the same order.
+			for i := 0; i < 2; i++ {
+				data, resp := readFindingsDataSource(t, handler, map[string]tftypes.Value{
+					"asset_token": tftypes.NewValue(tftypes.String, "aaaa1111"),
+					"order_by":    test.orderBy,
+					"order":       test.order,
+				})
+				require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+				var findings []struct {
+					UUID      string `tfsdk:"uuid"`
+					Title     string `tfsdk:"title"`
+					Severity  string `tfsdk:"severity"`
+					FirstSeen string `tfsdk:"first_seen"`
+				}
+				require.False(t, data.Findings.ElementsAs(context.Background(), &findings, false).HasError())
+
+				uuids := make([]string, len(findings))
+				for i, finding := range findings {
+					uuids[i] = finding.UUID
+				}
+				require.Equal(t, test.wantUUIDs, uuids)
+			}
+
+			require.Len(t, queries, 2)
+			require.Equal(t, test.wantQuery, queries[0])
+		})
+	}
+}
+
+func TestFindingsDataSourceOrderFirstSeenTimes(t *testing.T) {
+	// The times differ in offset and fractional seconds, so they do not sort lexically.
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[
+			{"uuid": "1", "title": "SQL injection", "severity": "critical", "first_seen": "2024-02-01T00:00:00.5Z"},
+			{"uuid": "2", "title": "Cross-site scripting", "severity": "medium", "first_seen": "2024-02-01T00:00:00Z"},
+			{"uuid": "3", "title": "Open redirect", "severity": "medium", "first_seen": "2024-02-01T01:30:00+02:00"},
+			{"uuid": "4", "title": "Missing header", "severity": "information", "first_seen": "yesterday"},
+			{"uuid": "5", "title": "Exposed panel", "severity": "high", "first_seen": "2024-01-31T23:45:00Z"}
+		]`))
+	})
+
+	tests := map[string]struct {
+		order     tftypes.Value
+		wantUUIDs []string
+	}{
+		// Findings with a time that cannot be parsed go last in either direction.
+		"asc": {
+			order:     tftypes.NewValue(tftypes.String, "asc"),
+			wantUUIDs: []string{"3", "5", "2", "1", "4"},
+		},
+		"desc": {
+			order:     tftypes.NewValue(tftypes.String, "desc"),
+			wantUUIDs: []string{"1", "2", "5", "3", "4"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, resp := readFindingsDataSource(t, handler, map[string]tftypes.Value{
+				"asset_token": tftypes.NewValue(tftypes.String, "aaaa1111"),
+				"order_by":    tftypes.NewValue(tftypes.String, "first_seen"),
+				"order":       test.order,
+			})
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+			var findings []struct {
+				UUID      string `tfsdk:"uuid"`
+				Title     string `tfsdk:"title"`
+				Severity  string `tfsdk:"severity"`
+				FirstSeen string `tfsdk:"first_seen"`
+			}
+			require.False(t, data.Findings.ElementsAs(context.Background(), &findings, false).HasError())
+
+			uuids := make([]string, len(findings))
+			for i, finding := range findings {
+				uuids[i] = finding.UUID
+			}
+			require.Equal(t, test.wantUUIDs, uuids)
+		})
+	}
+}
+
+func TestFindingsDataSourceFirstSeen(t *testing.T) {
+	null := tftypes.NewValue(tftypes.String, nil)
+
+	tests := map[string]struct {
+		after     tftypes.Value
+		before    tftypes.Value
+		wantQuery url.Values
+		wantUUIDs []string
+	}{
+		"after": {
+			after:     tftypes.NewValue(tftypes.String, "2024-01-15T00:00:00Z"),
+			before:    null,
+			wantQuery: url.Values{"order_by": {"first_seen"}, "order": {"asc"}, "first_seen_after": {"2024-01-15T00:00:00Z"}},
+			wantUUIDs: []string{"1", "2", "3"},
+		},
+		"before": {
+			after:     null,
+			before:    tftypes.NewValue(tftypes.String, "2024-02-01T00:00:00Z"),
+			wantQuery: url.Values{"order_by": {"first_seen"}, "order": {"asc"}, "first_seen_before": {"2024-02-01T00:00:00Z"}},
+			wantUUIDs: []string{"4"},
+		},
+		"window": {
+			after:  tftypes.NewValue(tftypes.String, "2024-01-31T20:00:00-03:00"),
+			before: tftypes.NewValue(tftypes.String, "2024-03-01T00:00:00Z"),
+			wantQuery: url.Values{
+				"order_by":          {"first_seen"},
+				"order":             {"asc"},
+				"first_seen_after":  {"2024-01-31T20:00:00-03:00"},
+				"first_seen_before": {"2024-03-01T00:00:00Z"},
+			},
+			wantUUIDs: []string{"1", "2"},
+		},
+		"no matches": {
+			after:     tftypes.NewValue(tftypes.String, "2025-01-01T00:00:00Z"),
+			before:    null,
+			wantQuery: url.Values{"order_by": {"first_seen"}, "order": {"asc"}, "first_seen_after": {"2025-01-01T00:00:00Z"}},
+			wantUUIDs: []string{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var mu sync.Mutex
+			var queries []url.Values
+
+			data, resp := readFindingsDataSource(t, findingsHandler(&queries, &mu), map[string]tftypes.Value{
+				"asset_token":       tftypes.NewValue(tftypes.String, "aaaa1111"),
+				"first_seen_after":  test.after,
+				"first_seen_before": test.before,
+			})
+			require.False(t, resp.Diagnostics.HasError(), "unexpected diagnostics: %v", resp.Diagnostics)
+
+			var findings []struct {
+				UUID      string `tfsdk:"uuid"`
+				Title     string `tfsdk:"title"`
+				Severity  string `tfsdk:"severity"`
+				FirstSeen string `tfsdk:"first_seen"`
+			}
+			require.False(t, data.Findings.ElementsAs(context.Background(), &findings, false).HasError())
+
+			uuids := []string{}
+			for _, finding := range findings {
+				uuids = append(uuids, finding.UUID)
+			}
+			require.Equal(t, test.wantUUIDs, uuids)
+
+			require.Len(t, queries, 1)
+			require.Equal(t, test.wantQuery, queries[0])
+		})
+	}
+}
+
+func TestAccFindingsDataSourceInvalidOrder(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+data "detectify_findings" "test" {
+  asset_token = "aaaa1111"
+  order_by    = "cvss"
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config: api.providerConfig() + `
+data "detectify_findings" "test" {
+  asset_token      = "aaaa1111"
+  first_seen_after = "2024-05-01"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Timestamp`),
+			},
+		},
+	})
+}
+
+// readFindingsDataSource reads the findings data source from handler.
+func readFindingsDataSource(t *testing.T, handler http.Handler, values map[string]tftypes.Value) (*provider.FindingsDataSourceModel, *datasource.ReadResponse) {
+	t.Helper()
+
+	ctx := context.Background()
+	d := provider.NewFindingsDataSource()
+
+	var schemaResp datasource.SchemaResponse
+	d.Schema(ctx, datasource.SchemaRequest{}, &schemaResp)
+
+	config := tfsdk.Config{
+		Schema: schemaResp.Schema,
+		Raw:    schemaValue(t, schemaResp.Schema, values),
+	}
+
+	resp := &datasource.ReadResponse{
+		State: tfsdk.State{
+			Schema: schemaResp.Schema,
+			Raw:    tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil),
+		},
+	}
+
+	configureResp := &datasource.ConfigureResponse{}
+	d.(datasource.DataSourceWithConfigure).Configure(ctx, datasource.ConfigureRequest{
+		ProviderData: newTestProviderData(t, handler),
+	}, configureResp)
+	require.False(t, configureResp.Diagnostics.HasError())
+
+	d.Read(ctx, datasource.ReadRequest{Config: config}, resp)
+	if resp.Diagnostics.HasError() {
+		return nil, resp
+	}
+
+	var data provider.FindingsDataSourceModel
+	require.False(t, resp.State.Get(ctx, &data).HasError())
+
+	return &data, resp
+}
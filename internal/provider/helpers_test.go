@@ -0,0 +1,1471 @@
+package provider_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+)
+
+// newTestProviderData returns provider data with a client sending requests to handler.
+func newTestProviderData(t *testing.T, handler http.Handler) *provider.DetectifyProviderData {
+	t.Helper()
+
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &provider.DetectifyProviderData{
+		Client: provider.NewClient(server.Client(), server.URL),
+	}
+}
+
+// roundTripFunc is an http.RoundTripper calling the function, to serve canned responses without a server.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// cannedResponse returns a JSON response with the status code and body.
+func cannedResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+// ptr returns a pointer to v.
+func ptr[T any](v T) *T {
+	return &v
+}
+
+// schemaValue returns an object value of the schema type, with unset attributes as null.
+func schemaValue(t *testing.T, s interface{ Type() attr.Type }, values map[string]tftypes.Value) tftypes.Value {
+	t.Helper()
+
+	typ := s.Type().TerraformType(context.Background()).(tftypes.Object)
+	attrs := make(map[string]tftypes.Value, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		if v, ok := values[name]; ok {
+			attrs[name] = v
+		} else {
+			attrs[name] = tftypes.NewValue(attrType, nil)
+		}
+	}
+
+	for name := range values {
+		if _, ok := typ.AttributeTypes[name]; !ok {
+			t.Fatalf("unknown attribute %q", name)
+		}
+	}
+
+	return tftypes.NewValue(typ, attrs)
+}
+
+// stringSet returns a set of strings value.
+func stringSet(values ...string) tftypes.Value {
+	elems := make([]tftypes.Value, len(values))
+	for i, v := range values {
+		elems[i] = tftypes.NewValue(tftypes.String, v)
+	}
+
+	return tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, elems)
+}
+
+// fakeAPI is an in-memory implementation of the parts of the Detectify API used by the provider.
+type fakeAPI struct {
+	mu       sync.Mutex
+	server   *httptest.Server
+	nextID   int
+	assets   map[string]*provider.Asset
+	settings map[string]*provider.AssetSettings
+	// monitoring is the subdomain monitoring of assets by token.
+	monitoring map[string]*provider.SubdomainMonitoring
+	metadata   map[string]map[string]string
+	// metadataWrites counts the requests setting or removing a metadata key.
+	metadataWrites int
+	// transfers counts the requests moving an asset to another team.
+	transfers int
+	// patches are the bodies of the requests changing some attributes of an asset.
+	patches  []string
+	profiles map[string]*provider.ScanProfile
+	scopes   map[string]*provider.ScanProfileScope
+	tokens   map[string]*provider.APIToken
+	reports  map[string]*provider.ScanReport
+
+	// staleReads is the number of reads of each created asset that fail as if it was not created yet.
+	staleReads int
+	pending    map[string]int
+
+	relationships map[string][]provider.AssetRelationship
+	// relationshipRequests counts the requests for pages of relationships.
+	relationshipRequests int
+	// relationshipPageSizes are the page sizes last requested for the relationships of each asset.
+	relationshipPageSizes map[string]string
+	ipAddresses           map[string][]provider.IPAddress
+
+	// scans are the scans of each asset and scan profile by token, most recent first.
+	scans map[string][]provider.Scan
+	// scanRequests counts the requests for pages of scans.
+	scanRequests int
+
+	// attachments are the tokens of the assets attached to each scan profile.
+	attachments map[string]map[string]bool
+
+	testCategories []provider.TestCategory
+
+	scanProfileTemplates []provider.ScanProfileTemplate
+
+	teams []provider.Team
+
+	// planTier is the plan tier of the account, which is not known if empty.
+	planTier string
+
+	// comments are the comments on each finding, by ID. Only findings in the map exist.
+	comments map[string]map[string]*provider.FindingComment
+
+	notifications map[string]*provider.Notification
+
+	exportSchedules map[string]*provider.ExportSchedule
+
+	// credentials are the scan credentials of assets by token, including the secrets the API does not return.
+	credentials map[string]*provider.ScanCredentialsRequest
+	// credentialWrites are the scan credentials set, in order.
+	credentialWrites []provider.ScanCredentialsRequest
+
+	verifications map[string]*provider.DomainVerification
+	// verificationPolls is the number of reads of a new verification that are still pending before it is verified.
+	verificationPolls int
+	pendingPolls      map[string]int
+
+	// failing are the path suffixes, such as "/settings/", of the requests changing something that fail.
+	failing map[string]bool
+
+	// async is whether asset creations and deletions asking to be processed asynchronously are, responding
+	// with the location of an operation that has completed by its first poll.
+	async      bool
+	operations map[string]*httptest.ResponseRecorder
+	// asyncRequests are the requests processed asynchronously, as method and path.
+	asyncRequests []string
+}
+
+// newFakeAPI starts a fake Detectify API, stopped when the test finishes.
+func newFakeAPI(t *testing.T) *fakeAPI {
+	t.Helper()
+
+	api := &fakeAPI{
+		assets:     map[string]*provider.Asset{},
+		settings:   map[string]*provider.AssetSettings{},
+		monitoring: map[string]*provider.SubdomainMonitoring{},
+		metadata:   map[string]map[string]string{},
+		profiles:   map[string]*provider.ScanProfile{},
+		scopes:     map[string]*provider.ScanProfileScope{},
+		tokens:     map[string]*provider.APIToken{},
+		reports:    map[string]*provider.ScanReport{},
+
+		pending:               map[string]int{},
+		relationships:         map[string][]provider.AssetRelationship{},
+		relationshipPageSizes: map[string]string{},
+		ipAddresses:           map[string][]provider.IPAddress{},
+		scans:                 map[string][]provider.Scan{},
+		attachments:           map[string]map[string]bool{},
+		comments:              map[string]map[string]*provider.FindingComment{},
+		notifications:         map[string]*provider.Notification{},
+		exportSchedules:       map[string]*provider.ExportSchedule{},
+		credentials:           map[string]*provider.ScanCredentialsRequest{},
+		verifications:         map[string]*provider.DomainVerification{},
+		pendingPolls:          map[string]int{},
+		failing:               map[string]bool{},
+		operations:            map[string]*httptest.ResponseRecorder{},
+	}
+	api.server = httptest.NewServer(api)
+	t.Cleanup(api.server.Close)
+
+	return api
+}
+
+// providerConfig returns a provider configuration using the fake API.
+func (api *fakeAPI) providerConfig() string {
+	return fmt.Sprintf(`
+provider "detectify" {
+  api_key            = "10840b0f938942feafb7186de74b9682"
+  base_url           = %q
+  allow_insecure_url = true
+}
+`, api.server.URL)
+}
+
+// addAsset adds an asset with the domain and tags outside of Terraform, and returns its token.
+func (api *fakeAPI) addAsset(domain string, tags ...string) string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.nextID++
+	token := fmt.Sprintf("token%04d", api.nextID)
+	api.assets[token] = &provider.Asset{Token: token, Name: domain, Tags: tags, Status: provider.AssetStatusActive}
+	api.settings[token] = &provider.AssetSettings{ScanFrequency: "weekly"}
+	api.monitoring[token] = &provider.SubdomainMonitoring{Allowlist: []string{}, Blocklist: []string{}}
+	api.metadata[token] = map[string]string{}
+
+	return token
+}
+
+// setScanFrequency changes the scan frequency of the asset with the domain, outside of Terraform.
+func (api *fakeAPI) setScanFrequency(domain, frequency string) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	for token, asset := range api.assets {
+		if asset.Name == domain {
+			api.settings[token].ScanFrequency = frequency
+		}
+	}
+}
+
+// setTestCategories changes the test categories the asset with the domain is scanned with, outside of Terraform.
+func (api *fakeAPI) setTestCategories(domain string, categories ...string) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	for token, asset := range api.assets {
+		if asset.Name == domain {
+			api.settings[token].TestCategories = &categories
+		}
+	}
+}
+
+// setTeam moves the asset with the domain to the team, outside of Terraform.
+func (api *fakeAPI) setTeam(domain, teamToken string) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	for _, asset := range api.assets {
+		if asset.Name == domain {
+			asset.TeamToken = teamToken
+		}
+	}
+}
+
+// setTechnologies sets the technologies fingerprinted on the asset with the domain, as if found by a scan.
+func (api *fakeAPI) setTechnologies(domain string, technologies ...provider.Technology) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	for _, asset := range api.assets {
+		if asset.Name == domain {
+			asset.Technologies = technologies
+		}
+	}
+}
+
+// setDNSRecords changes the DNS records discovered for the asset with the domain, outside of Terraform.
+func (api *fakeAPI) setDNSRecords(domain string, records ...provider.DNSRecord) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	for _, asset := range api.assets {
+		if asset.Name == domain {
+			asset.DNSRecords = records
+		}
+	}
+}
+
+// setCriticality changes the criticality of the asset with the domain, outside of Terraform.
+func (api *fakeAPI) setCriticality(domain, criticality string) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	for _, asset := range api.assets {
+		if asset.Name == domain {
+			asset.Criticality = criticality
+		}
+	}
+}
+
+// addScanReport adds the report of a scan, as if the scan was started outside of Terraform.
+func (api *fakeAPI) addScanReport(report provider.ScanReport) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.reports[report.ID] = &report
+}
+
+// addRelationship relates the asset identified by token to another asset, outside of Terraform.
+func (api *fakeAPI) addRelationship(token string, relationship provider.AssetRelationship) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.relationships[token] = append(api.relationships[token], relationship)
+}
+
+// addIPAddress associates an IP address with the asset identified by token, outside of Terraform.
+func (api *fakeAPI) addIPAddress(token string, address provider.IPAddress) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.ipAddresses[token] = append(api.ipAddresses[token], address)
+}
+
+// addScan records a scan of the asset or scan profile identified by token, as the most recent one.
+func (api *fakeAPI) addScan(token string, scan provider.Scan) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.scans[token] = append([]provider.Scan{scan}, api.scans[token]...)
+}
+
+// addTeam adds a team with the name to the account, and returns its token.
+func (api *fakeAPI) addTeam(name string) string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.nextID++
+	token := fmt.Sprintf("team%04d", api.nextID)
+	api.teams = append(api.teams, provider.Team{Token: token, Name: name})
+
+	return token
+}
+
+// addTestCategory adds a test category supported by the API.
+func (api *fakeAPI) addTestCategory(category provider.TestCategory) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.testCategories = append(api.testCategories, category)
+}
+
+// addScanProfileTemplate adds a scan profile template offered by the API.
+func (api *fakeAPI) addScanProfileTemplate(template provider.ScanProfileTemplate) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.scanProfileTemplates = append(api.scanProfileTemplates, template)
+}
+
+// addFinding adds a finding without comments, as if it was found by a scan.
+func (api *fakeAPI) addFinding(uuid string) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.comments[uuid] = map[string]*provider.FindingComment{}
+}
+
+// findingComments returns the text of the comments on the finding.
+func (api *fakeAPI) findingComments(uuid string) []string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	texts := []string{}
+	for _, comment := range api.comments[uuid] {
+		texts = append(texts, comment.Text)
+	}
+	sort.Strings(texts)
+
+	return texts
+}
+
+// setAttached attaches the asset to the scan profile, or detaches it, outside of Terraform.
+func (api *fakeAPI) setAttached(profileToken, assetToken string, attached bool) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	if api.attachments[profileToken] == nil {
+		api.attachments[profileToken] = map[string]bool{}
+	}
+
+	if attached {
+		api.attachments[profileToken][assetToken] = true
+	} else {
+		delete(api.attachments[profileToken], assetToken)
+	}
+}
+
+// setNotes changes the notes of the asset with the domain, outside of Terraform.
+func (api *fakeAPI) setNotes(domain, notes string) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	for _, asset := range api.assets {
+		if asset.Name == domain {
+			asset.Notes = notes
+		}
+	}
+}
+
+// setTags changes the tags of the asset with the token, outside of Terraform.
+func (api *fakeAPI) setTags(token string, tags ...string) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	api.assets[token].Tags = tags
+}
+
+// assetTags returns the tags of the asset with the token, sorted.
+func (api *fakeAPI) assetTags(token string) []string {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	tags := slices.Clone(api.assets[token].Tags)
+	sort.Strings(tags)
+
+	return tags
+}
+
+// setLastScan records a scan of the asset with the domain, outside of Terraform.
+func (api *fakeAPI) setLastScan(domain, scannedAt, status string) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	for _, asset := range api.assets {
+		if asset.Name == domain {
+			asset.LastScannedAt = &scannedAt
+			asset.LastScanStatus = &status
+		}
+	}
+}
+
+// checkNotificationsDestroyed verifies that no notifications remain, for use as a CheckDestroy function.
+func (api *fakeAPI) checkNotificationsDestroyed(*terraform.State) error {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	for id := range api.notifications {
+		return fmt.Errorf("notification %q still exists", id)
+	}
+
+	return nil
+}
+
+// checkExportSchedulesDestroyed verifies that no export schedules remain, for use as a CheckDestroy function.
+func (api *fakeAPI) checkExportSchedulesDestroyed(*terraform.State) error {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	for id := range api.exportSchedules {
+		return fmt.Errorf("export schedule %q still exists", id)
+	}
+
+	return nil
+}
+
+// serveAsync processes the asset creation or deletion, and responds with the location of an operation
+// returning its response.
+func (api *fakeAPI) serveAsync(w http.ResponseWriter, r *http.Request, parts []string) {
+	rec := httptest.NewRecorder()
+	if len(parts) == 2 {
+		api.serveAssets(rec, r)
+	} else {
+		api.serveAsset(rec, r, parts[2])
+	}
+
+	if rec.Code < 200 || rec.Code > 299 {
+		for k, v := range rec.Header() {
+			w.Header()[k] = v
+		}
+		w.WriteHeader(rec.Code)
+		w.Write(rec.Body.Bytes())
+		return
+	}
+
+	api.nextID++
+	id := fmt.Sprintf("op%04d", api.nextID)
+	api.operations[id] = rec
+	api.asyncRequests = append(api.asyncRequests, r.Method+" "+r.URL.Path)
+
+	w.Header().Set("Location", "/v2/operations/"+id+"/")
+	writeJSON(w, http.StatusAccepted, map[string]string{"status": "pending"})
+}
+
+// serveOperation responds with the response of the completed operation.
+func (api *fakeAPI) serveOperation(w http.ResponseWriter, r *http.Request, id string) {
+	rec, ok := api.operations[id]
+	if !ok || r.Method != http.MethodGet {
+		http.NotFound(w, r)
+		return
+	}
+
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+	w.WriteHeader(rec.Code)
+	w.Write(rec.Body.Bytes())
+}
+
+// checkAssetsDestroyed verifies that no assets remain, for use as a CheckDestroy function.
+func (api *fakeAPI) checkAssetsDestroyed(*terraform.State) error {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	for _, asset := range api.assets {
+		return fmt.Errorf("asset %q still exists", asset.Name)
+	}
+
+	return nil
+}
+
+func (api *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.mu.Lock()
+	defer api.mu.Unlock()
+
+	// Split the escaped path, so that escaped slashes stay within their part.
+	parts := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")
+	for i, part := range parts {
+		if unescaped, err := url.PathUnescape(part); err == nil {
+			parts[i] = unescaped
+		}
+	}
+
+	if r.Method != http.MethodGet {
+		for suffix := range api.failing {
+			if strings.HasSuffix(r.URL.Path, suffix) {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "Bad Request", "message": "The request failed"})
+				return
+			}
+		}
+	}
+
+	if api.async && r.Header.Get("Prefer") == "respond-async" && len(parts) >= 2 && parts[1] == "domains" &&
+		(r.Method == http.MethodPost && len(parts) == 2 || r.Method == http.MethodDelete && len(parts) == 3) {
+		api.serveAsync(w, r, parts)
+		return
+	}
+
+	switch {
+	case len(parts) == 3 && parts[1] == "operations":
+		api.serveOperation(w, r, parts[2])
+	case len(parts) == 2 && parts[1] == "domains":
+		api.serveAssets(w, r)
+	case len(parts) == 3 && parts[1] == "domains":
+		api.serveAsset(w, r, parts[2])
+	case len(parts) == 4 && parts[1] == "domains" && parts[3] == "transfer":
+		api.serveAssetTransfer(w, r, parts[2])
+	case len(parts) == 4 && parts[1] == "domains" && parts[3] == "status":
+		api.serveAssetStatus(w, r, parts[2])
+	case len(parts) == 4 && parts[1] == "domains" && parts[3] == "settings":
+		api.serveAssetSettings(w, r, parts[2])
+	case len(parts) == 4 && parts[1] == "domains" && parts[3] == "credentials":
+		api.serveScanCredentials(w, r, parts[2])
+	case len(parts) == 4 && parts[1] == "domains" && parts[3] == "subdomain-monitoring":
+		api.serveSubdomainMonitoring(w, r, parts[2])
+	case len(parts) == 4 && parts[1] == "domains" && parts[3] == "metadata":
+		api.serveAssetMetadata(w, r, parts[2])
+	case len(parts) == 5 && parts[1] == "domains" && parts[3] == "metadata":
+		api.serveAssetMetadataKey(w, r, parts[2], parts[4])
+	case len(parts) == 4 && parts[1] == "domains" && parts[3] == "relationships":
+		api.serveAssetRelationships(w, r, parts[2])
+	case len(parts) == 4 && parts[1] == "domains" && parts[3] == "ips":
+		api.serveAssetIPAddresses(w, r, parts[2])
+	case len(parts) == 4 && parts[1] == "domains" && parts[3] == "scans":
+		_, ok := api.assets[parts[2]]
+		api.serveScans(w, r, parts[2], ok)
+	case len(parts) == 4 && parts[1] == "profiles" && parts[3] == "scans":
+		_, ok := api.profiles[parts[2]]
+		api.serveScans(w, r, parts[2], ok)
+	case len(parts) == 2 && parts[1] == "profiles":
+		api.serveScanProfiles(w, r)
+	case len(parts) == 3 && parts[1] == "profiles":
+		api.serveScanProfile(w, r, parts[2])
+	case len(parts) == 4 && parts[1] == "profiles" && parts[3] == "scope":
+		api.serveScanProfileScope(w, r, parts[2])
+	case len(parts) == 5 && parts[1] == "profiles" && parts[3] == "assets":
+		api.serveScanProfileAttachment(w, r, parts[2], parts[4])
+	case len(parts) == 4 && parts[1] == "scans" && parts[3] == "report":
+		api.serveScanReport(w, r, parts[2])
+	case len(parts) == 2 && parts[1] == "test-categories":
+		api.serveTestCategories(w, r)
+	case len(parts) == 2 && parts[1] == "profile-templates":
+		api.serveScanProfileTemplates(w, r)
+	case len(parts) == 4 && parts[1] == "findings" && parts[3] == "comments":
+		api.serveFindingComments(w, r, parts[2])
+	case len(parts) == 5 && parts[1] == "findings" && parts[3] == "comments":
+		api.serveFindingComment(w, r, parts[2], parts[4])
+	case len(parts) == 2 && parts[1] == "teams":
+		api.serveTeams(w, r)
+	case len(parts) == 3 && parts[1] == "teams":
+		api.serveTeam(w, r, parts[2])
+	case len(parts) == 2 && parts[1] == "account":
+		api.serveAccount(w, r)
+	case len(parts) == 2 && parts[1] == "verifications":
+		api.serveVerifications(w, r)
+	case len(parts) == 3 && parts[1] == "verifications":
+		api.serveVerification(w, r, parts[2])
+	case len(parts) == 2 && parts[1] == "notifications":
+		api.serveNotifications(w, r)
+	case len(parts) == 3 && parts[1] == "notifications":
+		api.serveNotification(w, r, parts[2])
+	case len(parts) == 3 && parts[1] == "exports" && parts[2] == "schedules":
+		api.serveExportSchedules(w, r)
+	case len(parts) == 4 && parts[1] == "exports" && parts[2] == "schedules":
+		api.serveExportSchedule(w, r, parts[3])
+	case len(parts) == 2 && parts[1] == "keys":
+		api.serveAPITokens(w, r)
+	case len(parts) == 3 && parts[1] == "keys":
+		api.serveAPIToken(w, r, parts[2])
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (api *fakeAPI) serveAssets(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		assets := []*provider.Asset{}
+		for _, asset := range api.assets {
+			assets = append(assets, asset)
+		}
+		sort.Slice(assets, func(i, j int) bool { return assets[i].Token < assets[j].Token })
+		writeJSON(w, http.StatusOK, assets)
+	case http.MethodPost:
+		var body provider.AssetRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		for _, existing := range api.assets {
+			if existing.Name == body.Name {
+				writeJSON(w, http.StatusConflict, map[string]string{"error": "Conflict", "message": "The domain already exists"})
+				return
+			}
+		}
+
+		api.nextID++
+		asset := &provider.Asset{
+			Token:       fmt.Sprintf("token%04d", api.nextID),
+			Name:        body.Name,
+			Tags:        body.Tags,
+			Criticality: body.Criticality,
+			Notes:       body.Notes,
+			Status:      provider.AssetStatusActive,
+			TeamToken:   body.TeamToken,
+		}
+		api.assets[asset.Token] = asset
+		api.settings[asset.Token] = &provider.AssetSettings{ScanFrequency: "weekly"}
+		api.monitoring[asset.Token] = &provider.SubdomainMonitoring{Allowlist: []string{}, Blocklist: []string{}}
+		api.metadata[asset.Token] = map[string]string{}
+		api.pending[asset.Token] = api.staleReads
+		writeJSON(w, http.StatusCreated, asset)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *fakeAPI) serveAsset(w http.ResponseWriter, r *http.Request, token string) {
+	asset, ok := api.assets[token]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if api.pending[token] > 0 {
+			api.pending[token]--
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+			return
+		}
+		writeJSON(w, http.StatusOK, asset)
+	case http.MethodPut:
+		var body provider.AssetRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		asset.Tags = body.Tags
+		asset.Notes = body.Notes
+		if body.Criticality != "" {
+			asset.Criticality = body.Criticality
+		}
+		writeJSON(w, http.StatusOK, asset)
+	case http.MethodPatch:
+		raw, err := io.ReadAll(r.Body)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		var body provider.AssetPatchRequest
+		if err := json.Unmarshal(raw, &body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		api.patches = append(api.patches, string(raw))
+
+		if body.Tags != nil {
+			asset.Tags = *body.Tags
+		}
+		if body.Notes != nil {
+			asset.Notes = *body.Notes
+		}
+		if body.Criticality != nil {
+			asset.Criticality = *body.Criticality
+		}
+		writeJSON(w, http.StatusOK, asset)
+	case http.MethodDelete:
+		delete(api.assets, token)
+		delete(api.settings, token)
+		delete(api.monitoring, token)
+		delete(api.metadata, token)
+		delete(api.credentials, token)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *fakeAPI) serveTeams(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, append([]provider.Team{}, api.teams...))
+}
+
+func (api *fakeAPI) serveTeam(w http.ResponseWriter, r *http.Request, token string) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	for _, team := range api.teams {
+		if team.Token == token {
+			writeJSON(w, http.StatusOK, team)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+}
+
+func (api *fakeAPI) serveAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, provider.Account{Name: "Example Inc", PlanTier: api.planTier})
+}
+
+func (api *fakeAPI) serveFindingComments(w http.ResponseWriter, r *http.Request, uuid string) {
+	comments, ok := api.comments[uuid]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body provider.FindingCommentRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	api.nextID++
+	comment := &provider.FindingComment{
+		ID:        fmt.Sprintf("comment%04d", api.nextID),
+		Text:      body.Text,
+		CreatedAt: "2024-05-02T09:30:00Z",
+	}
+	comments[comment.ID] = comment
+	writeJSON(w, http.StatusCreated, comment)
+}
+
+func (api *fakeAPI) serveFindingComment(w http.ResponseWriter, r *http.Request, uuid, id string) {
+	comment, ok := api.comments[uuid][id]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, comment)
+	case http.MethodDelete:
+		delete(api.comments[uuid], comment.ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *fakeAPI) serveAssetTransfer(w http.ResponseWriter, r *http.Request, token string) {
+	asset, ok := api.assets[token]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body provider.AssetTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	api.transfers++
+	asset.TeamToken = body.TeamToken
+	writeJSON(w, http.StatusOK, asset)
+}
+
+func (api *fakeAPI) serveAssetStatus(w http.ResponseWriter, r *http.Request, token string) {
+	asset, ok := api.assets[token]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	if r.Method != http.MethodPut {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body provider.AssetStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	// Archived assets can only be restored to active.
+	if asset.Status == provider.AssetStatusArchived && body.Status != provider.AssetStatusActive {
+		writeJSON(w, http.StatusConflict, map[string]string{"error": "Conflict", "message": "An archived asset can only be restored to active"})
+		return
+	}
+
+	asset.Status = body.Status
+	writeJSON(w, http.StatusOK, asset)
+}
+
+func (api *fakeAPI) serveAssetSettings(w http.ResponseWriter, r *http.Request, token string) {
+	settings, ok := api.settings[token]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, settings)
+	case http.MethodPut:
+		var body provider.AssetSettings
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		if body.ScanFrequency != "" {
+			settings.ScanFrequency = body.ScanFrequency
+		}
+		if body.TestCategories != nil {
+			settings.TestCategories = body.TestCategories
+		}
+		writeJSON(w, http.StatusOK, settings)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *fakeAPI) serveSubdomainMonitoring(w http.ResponseWriter, r *http.Request, token string) {
+	monitoring, ok := api.monitoring[token]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, monitoring)
+	case http.MethodPut:
+		var body provider.SubdomainMonitoring
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		// The API returns hostnames in sorted order, regardless of the order they were sent in.
+		sort.Strings(body.Allowlist)
+		sort.Strings(body.Blocklist)
+		*monitoring = body
+		writeJSON(w, http.StatusOK, monitoring)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *fakeAPI) serveScanCredentials(w http.ResponseWriter, r *http.Request, token string) {
+	if _, ok := api.assets[token]; !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		credentials, ok := api.credentials[token]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+			return
+		}
+
+		writeJSON(w, http.StatusOK, scanCredentials(credentials))
+	case http.MethodPut:
+		var body provider.ScanCredentialsRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		api.credentials[token] = &body
+		api.credentialWrites = append(api.credentialWrites, body)
+		writeJSON(w, http.StatusOK, scanCredentials(&body))
+	case http.MethodDelete:
+		if _, ok := api.credentials[token]; !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+			return
+		}
+
+		delete(api.credentials, token)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// scanCredentials returns the scan credentials the API returns for the request, without the secrets.
+func scanCredentials(body *provider.ScanCredentialsRequest) provider.ScanCredentials {
+	return provider.ScanCredentials{Type: body.Type, Username: body.Username, UpdatedAt: "2024-03-01T12:00:00Z"}
+}
+
+func (api *fakeAPI) serveAssetMetadata(w http.ResponseWriter, r *http.Request, token string) {
+	metadata, ok := api.metadata[token]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, metadata)
+}
+
+func (api *fakeAPI) serveAssetMetadataKey(w http.ResponseWriter, r *http.Request, token, key string) {
+	metadata, ok := api.metadata[token]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		var body provider.MetadataValue
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		api.metadataWrites++
+		metadata[key] = body.Value
+		writeJSON(w, http.StatusOK, body)
+	case http.MethodDelete:
+		if _, ok := metadata[key]; !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+			return
+		}
+
+		api.metadataWrites++
+		delete(metadata, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// pageSize is the number of items in a page of a listing unless requested otherwise, small to exercise pagination.
+const pageSize = 2
+
+// writePage writes the page of items starting at the offset in the cursor query parameter.
+func writePage[T any](w http.ResponseWriter, r *http.Request, field string, items []T) {
+	size := pageSize
+	if n, err := strconv.Atoi(r.URL.Query().Get("page_size")); err == nil && n > 0 {
+		size = n
+	}
+
+	// The cursor is the offset of the page.
+	offset, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+	end := min(offset+size, len(items))
+
+	page := map[string]any{
+		field: append([]T{}, items[min(offset, end):end]...),
+	}
+	if end < len(items) {
+		page["next_cursor"] = strconv.Itoa(end)
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+func (api *fakeAPI) serveAssetRelationships(w http.ResponseWriter, r *http.Request, token string) {
+	if _, ok := api.assets[token]; !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	api.relationshipRequests++
+	api.relationshipPageSizes[token] = r.URL.Query().Get("page_size")
+	writePage(w, r, "relationships", api.relationships[token])
+}
+
+func (api *fakeAPI) serveAssetIPAddresses(w http.ResponseWriter, r *http.Request, token string) {
+	if _, ok := api.assets[token]; !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	addresses := []provider.IPAddress{}
+	for _, address := range api.ipAddresses[token] {
+		if typ := r.URL.Query().Get("type"); typ == "" || address.Type == typ {
+			addresses = append(addresses, address)
+		}
+	}
+
+	writePage(w, r, "ip_addresses", addresses)
+}
+
+// serveScans serves the scans of the asset or scan profile identified by token, which is not found unless exists is set.
+func (api *fakeAPI) serveScans(w http.ResponseWriter, r *http.Request, token string, exists bool) {
+	if !exists {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	api.scanRequests++
+	writePage(w, r, "scans", api.scans[token])
+}
+
+func (api *fakeAPI) serveTestCategories(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	writePage(w, r, "categories", api.testCategories)
+}
+
+func (api *fakeAPI) serveScanProfileTemplates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	writePage(w, r, "templates", api.scanProfileTemplates)
+}
+
+func (api *fakeAPI) serveVerifications(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var body provider.DomainVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	// A verification in progress is returned as is.
+	if verification, ok := api.verifications[body.Domain]; ok {
+		writeJSON(w, http.StatusOK, verification)
+		return
+	}
+
+	api.nextID++
+	verification := &provider.DomainVerification{
+		Domain: body.Domain,
+		Method: body.Method,
+		Token:  fmt.Sprintf("detectify-verification=%04d", api.nextID),
+		Status: provider.VerificationStatusPending,
+	}
+	api.verifications[body.Domain] = verification
+	api.pendingPolls[body.Domain] = api.verificationPolls
+	writeJSON(w, http.StatusCreated, verification)
+}
+
+func (api *fakeAPI) serveVerification(w http.ResponseWriter, r *http.Request, domain string) {
+	verification, ok := api.verifications[domain]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if verification.Status == provider.VerificationStatusPending {
+			if api.pendingPolls[domain] > 0 {
+				api.pendingPolls[domain]--
+			} else {
+				verification.Status = provider.VerificationStatusVerified
+			}
+		}
+		writeJSON(w, http.StatusOK, verification)
+	case http.MethodDelete:
+		delete(api.verifications, domain)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *fakeAPI) serveScanProfiles(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var body provider.ScanProfileRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		api.nextID++
+		profile := &provider.ScanProfile{
+			Token:    fmt.Sprintf("profile%04d", api.nextID),
+			Name:     body.Name,
+			Endpoint: body.Endpoint,
+		}
+		api.profiles[profile.Token] = profile
+		api.scopes[profile.Token] = &provider.ScanProfileScope{IncludedHosts: []string{}, ExcludedHosts: []string{}}
+		writeJSON(w, http.StatusCreated, profile)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *fakeAPI) serveScanProfile(w http.ResponseWriter, r *http.Request, token string) {
+	profile, ok := api.profiles[token]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, profile)
+	case http.MethodPut:
+		var body provider.ScanProfileRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		profile.Name = body.Name
+		writeJSON(w, http.StatusOK, profile)
+	case http.MethodDelete:
+		delete(api.profiles, token)
+		delete(api.scopes, token)
+		delete(api.attachments, token)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *fakeAPI) serveScanProfileScope(w http.ResponseWriter, r *http.Request, token string) {
+	scope, ok := api.scopes[token]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, scope)
+	case http.MethodPut:
+		var body provider.ScanProfileScope
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		// The API returns hosts in sorted order, regardless of the order they were sent in.
+		sort.Strings(body.IncludedHosts)
+		sort.Strings(body.ExcludedHosts)
+		*scope = body
+		writeJSON(w, http.StatusOK, scope)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *fakeAPI) serveScanProfileAttachment(w http.ResponseWriter, r *http.Request, profileToken, assetToken string) {
+	_, profileExists := api.profiles[profileToken]
+	_, assetExists := api.assets[assetToken]
+	if !profileExists || !assetExists {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	attached := api.attachments[profileToken][assetToken]
+
+	switch r.Method {
+	case http.MethodGet:
+		if !attached {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodPut:
+		if attached {
+			writeJSON(w, http.StatusConflict, map[string]string{"error": "Conflict", "message": "The asset is already attached"})
+			return
+		}
+		if api.attachments[profileToken] == nil {
+			api.attachments[profileToken] = map[string]bool{}
+		}
+		api.attachments[profileToken][assetToken] = true
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		if !attached {
+			writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+			return
+		}
+		delete(api.attachments[profileToken], assetToken)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *fakeAPI) serveAPITokens(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var body provider.APITokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		api.nextID++
+		apiToken := &provider.APIToken{
+			Token:  fmt.Sprintf("key%04d", api.nextID),
+			Name:   body.Name,
+			Scopes: body.Scopes,
+		}
+		api.tokens[apiToken.Token] = apiToken
+
+		// The secret is only returned on creation.
+		created := *apiToken
+		created.Secret = fmt.Sprintf("secret%04d", api.nextID)
+		writeJSON(w, http.StatusCreated, created)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *fakeAPI) serveAPIToken(w http.ResponseWriter, r *http.Request, token string) {
+	apiToken, ok := api.tokens[token]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, apiToken)
+	case http.MethodDelete:
+		delete(api.tokens, token)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *fakeAPI) serveNotifications(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var body provider.NotificationRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		api.nextID++
+		notification := &provider.Notification{ID: fmt.Sprintf("notification%04d", api.nextID)}
+		setNotification(notification, body)
+		api.notifications[notification.ID] = notification
+		writeJSON(w, http.StatusCreated, notification)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *fakeAPI) serveNotification(w http.ResponseWriter, r *http.Request, id string) {
+	notification, ok := api.notifications[id]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, notification)
+	case http.MethodPut:
+		var body provider.NotificationRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		setNotification(notification, body)
+		writeJSON(w, http.StatusOK, notification)
+	case http.MethodDelete:
+		delete(api.notifications, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// setNotification sets the notification from the request body. The API returns event types in sorted order.
+func setNotification(notification *provider.Notification, body provider.NotificationRequest) {
+	notification.AssetToken = body.AssetToken
+	notification.TeamToken = body.TeamToken
+	notification.Channel = body.Channel
+	notification.Recipient = body.Recipient
+	notification.EventTypes = body.EventTypes
+	sort.Strings(notification.EventTypes)
+}
+
+func (api *fakeAPI) serveExportSchedules(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		var body provider.ExportScheduleRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		api.nextID++
+		schedule := &provider.ExportSchedule{ID: fmt.Sprintf("export%04d", api.nextID)}
+		setExportSchedule(schedule, body)
+		api.exportSchedules[schedule.ID] = schedule
+		writeJSON(w, http.StatusCreated, schedule)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (api *fakeAPI) serveExportSchedule(w http.ResponseWriter, r *http.Request, id string) {
+	schedule, ok := api.exportSchedules[id]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, schedule)
+	case http.MethodPut:
+		var body provider.ExportScheduleRequest
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+
+		setExportSchedule(schedule, body)
+		writeJSON(w, http.StatusOK, schedule)
+	case http.MethodDelete:
+		delete(api.exportSchedules, id)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// exportNextRuns are the next runs of export schedules by frequency, as if the API scheduled them.
+var exportNextRuns = map[string]string{
+	"daily":   "2026-10-15T00:00:00Z",
+	"weekly":  "2026-10-19T00:00:00Z",
+	"monthly": "2026-11-01T00:00:00Z",
+}
+
+// setExportSchedule sets the export schedule from the request body, scheduling its next run by the frequency.
+func setExportSchedule(schedule *provider.ExportSchedule, body provider.ExportScheduleRequest) {
+	schedule.Format = body.Format
+	schedule.Destination = body.Destination
+	schedule.Frequency = body.Frequency
+	schedule.Filters = body.Filters
+	schedule.NextRunAt = exportNextRuns[body.Frequency]
+}
+
+func (api *fakeAPI) serveScanReport(w http.ResponseWriter, r *http.Request, id string) {
+	report, ok := api.reports[id]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"error": "Not Found"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, report)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(v)
+}
+
+// testAccResourceToken returns an import ID function returning the token of the resource.
+func testAccResourceToken(name string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", name)
+		}
+
+		return rs.Primary.Attributes["token"], nil
+	}
+}
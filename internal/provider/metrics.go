@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// requestDurationBuckets are the upper bounds of the request duration histogram, in seconds.
+var requestDurationBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// metrics collects request counts, latencies and retries of the API requests made by the provider.
+// A nil metrics discards everything.
+type metrics struct {
+	mu sync.Mutex
+	// requests counts the requests by method and status code.
+	requests map[requestKey]int64
+	// buckets counts the requests by the duration bucket they fall in, with the last one for longer requests.
+	buckets       []int64
+	durationSum   float64
+	durationCount int64
+	retries       int64
+}
+
+// requestKey is the labels of a request counted by metrics.
+type requestKey struct {
+	method string
+	code   string
+}
+
+func newMetrics() *metrics {
+	return &metrics{
+		requests: map[requestKey]int64{},
+		buckets:  make([]int64, len(requestDurationBuckets)+1),
+	}
+}
+
+// observeRequest records a request with the status code of the response, or "error" if it failed without one.
+func (m *metrics) observeRequest(method string, resp *http.Response, d time.Duration) {
+	if m == nil {
+		return
+	}
+
+	code := "error"
+	if resp != nil {
+		code = strconv.Itoa(resp.StatusCode)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestKey{method: method, code: code}]++
+
+	seconds := d.Seconds()
+	bucket := sort.SearchFloat64s(requestDurationBuckets, seconds)
+	m.buckets[bucket]++
+	m.durationSum += seconds
+	m.durationCount++
+}
+
+// observeRetry records that a request is retried.
+func (m *metrics) observeRetry() {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.retries++
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format.
+func (m *metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	keys := make([]requestKey, 0, len(m.requests))
+	for key := range m.requests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].method != keys[j].method {
+			return keys[i].method < keys[j].method
+		}
+		return keys[i].code < keys[j].code
+	})
+
+	fmt.Fprintln(w, "# HELP detectify_requests_total Requests sent to the Detectify API, by method and status code.")
+	fmt.Fprintln(w, "# TYPE detectify_requests_total counter")
+	for _, key := range keys {
+		fmt.Fprintf(w, "detectify_requests_total{method=%q,code=%q} %d\n", key.method, key.code, m.requests[key])
+	}
+
+	fmt.Fprintln(w, "# HELP detectify_request_duration_seconds Time until the Detectify API responded to a request.")
+	fmt.Fprintln(w, "# TYPE detectify_request_duration_seconds histogram")
+	var cumulative int64
+	for i, le := range requestDurationBuckets {
+		cumulative += m.buckets[i]
+		fmt.Fprintf(w, "detectify_request_duration_seconds_bucket{le=%q} %d\n", strconv.FormatFloat(le, 'g', -1, 64), cumulative)
+	}
+	fmt.Fprintf(w, "detectify_request_duration_seconds_bucket{le=\"+Inf\"} %d\n", m.durationCount)
+	fmt.Fprintf(w, "detectify_request_duration_seconds_sum %g\n", m.durationSum)
+	fmt.Fprintf(w, "detectify_request_duration_seconds_count %d\n", m.durationCount)
+
+	fmt.Fprintln(w, "# HELP detectify_retries_total Requests to the Detectify API retried after a transient error.")
+	fmt.Fprintln(w, "# TYPE detectify_retries_total counter")
+	fmt.Fprintf(w, "detectify_retries_total %d\n", m.retries)
+}
+
+// metricsServers are the running metrics servers, shut down by ShutdownMetricsServers.
+var (
+	metricsServersMu sync.Mutex
+	metricsServers   = map[*http.Server]bool{}
+)
+
+// startMetricsServer serves the metrics on /metrics at addr, returning the server and the address it listens on.
+func startMetricsServer(addr string, m *metrics) (*http.Server, string, error) {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, "", err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m)
+
+	server := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	metricsServersMu.Lock()
+	metricsServers[server] = true
+	metricsServersMu.Unlock()
+
+	go server.Serve(listener)
+
+	return server, listener.Addr().String(), nil
+}
+
+// shutdownMetricsServer stops the metrics server, waiting for active scrapes to finish.
+func shutdownMetricsServer(ctx context.Context, server *http.Server) error {
+	metricsServersMu.Lock()
+	delete(metricsServers, server)
+	metricsServersMu.Unlock()
+
+	return server.Shutdown(ctx)
+}
+
+// ShutdownMetricsServers stops the metrics servers started by providers in this process.
+// It is called when the provider server stops.
+func ShutdownMetricsServers(ctx context.Context) error {
+	metricsServersMu.Lock()
+	servers := make([]*http.Server, 0, len(metricsServers))
+	for server := range metricsServers {
+		servers = append(servers, server)
+	}
+	metricsServersMu.Unlock()
+
+	var errs []error
+	for _, server := range servers {
+		if err := shutdownMetricsServer(ctx, server); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
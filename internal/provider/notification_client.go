@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+)
+
+// notificationChannels are the ways a notification can be delivered.
+var notificationChannels = []string{"email", "slack"}
+
+// notificationEventTypes are the events a notification can be sent for.
+var notificationEventTypes = []string{
+	"finding_new",
+	"finding_resolved",
+	"scan_started",
+	"scan_completed",
+	"asset_added",
+	"asset_removed",
+}
+
+// Notification is the configuration of who is notified of events on an asset or a team,
+// as represented by the Detectify API. Exactly one of AssetToken and TeamToken is set.
+type Notification struct {
+	ID         string `json:"id"`
+	AssetToken string `json:"asset_token,omitempty"`
+	TeamToken  string `json:"team_token,omitempty"`
+	// Channel is how the notification is delivered, one of email and slack.
+	Channel string `json:"channel"`
+	// Recipient is the email address or Slack channel notified.
+	Recipient  string   `json:"recipient"`
+	EventTypes []string `json:"event_types"`
+}
+
+// NotificationRequest is the request body used when creating or updating a notification.
+type NotificationRequest struct {
+	AssetToken string   `json:"asset_token,omitempty"`
+	TeamToken  string   `json:"team_token,omitempty"`
+	Channel    string   `json:"channel"`
+	Recipient  string   `json:"recipient"`
+	EventTypes []string `json:"event_types"`
+}
+
+// GetNotification returns the notification identified by id.
+func (c *Client) GetNotification(ctx context.Context, id string) (*Notification, error) {
+	var notification Notification
+	if err := c.do(ctx, http.MethodGet, apiPath("notifications", id), nil, &notification); err != nil {
+		return nil, err
+	}
+
+	return &notification, nil
+}
+
+// CreateNotification adds a new notification.
+func (c *Client) CreateNotification(ctx context.Context, body NotificationRequest) (*Notification, error) {
+	var notification Notification
+	if err := c.do(ctx, http.MethodPost, "/v2/notifications/", body, &notification); err != nil {
+		return nil, err
+	}
+
+	return &notification, nil
+}
+
+// UpdateNotification replaces the notification identified by id.
+func (c *Client) UpdateNotification(ctx context.Context, id string, body NotificationRequest) (*Notification, error) {
+	var notification Notification
+	if err := c.do(ctx, http.MethodPut, apiPath("notifications", id), body, &notification); err != nil {
+		return nil, err
+	}
+
+	return &notification, nil
+}
+
+// DeleteNotification removes the notification identified by id.
+func (c *Client) DeleteNotification(ctx context.Context, id string) error {
+	return c.do(ctx, http.MethodDelete, apiPath("notifications", id), nil, nil)
+}
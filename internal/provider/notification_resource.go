@@ -0,0 +1,279 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                     = &NotificationResource{}
+	_ resource.ResourceWithConfigValidators = &NotificationResource{}
+	_ resource.ResourceWithImportState      = &NotificationResource{}
+)
+
+func NewNotificationResource() resource.Resource {
+	return &NotificationResource{}
+}
+
+// NotificationResource defines the resource implementation.
+type NotificationResource struct {
+	client *Client
+}
+
+// NotificationResourceModel describes the resource data model.
+type NotificationResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	AssetToken types.String `tfsdk:"asset_token"`
+	TeamToken  types.String `tfsdk:"team_token"`
+	Channel    types.String `tfsdk:"channel"`
+	Recipient  types.String `tfsdk:"recipient"`
+	EventTypes types.Set    `tfsdk:"event_types"`
+}
+
+func (r *NotificationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification"
+}
+
+func (r *NotificationResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages who is notified of events on an asset or a team, by email or in a Slack channel.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "The notification identifier.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"asset_token": schema.StringAttribute{
+				MarkdownDescription: "The token of the asset to notify of events on. Exactly one of `asset_token` and `team_token` must be set.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"team_token": schema.StringAttribute{
+				MarkdownDescription: "The token of the team to notify of events on. Exactly one of `asset_token` and `team_token` must be set.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"channel": schema.StringAttribute{
+				MarkdownDescription: "How the notification is delivered, one of `" + strings.Join(notificationChannels, "` and `") + "`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(notificationChannels...),
+				},
+			},
+			"recipient": schema.StringAttribute{
+				MarkdownDescription: "The email address or Slack channel, such as `#security`, to notify.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"event_types": schema.SetAttribute{
+				MarkdownDescription: "The events to notify of, any of `" + strings.Join(notificationEventTypes, "`, `") + "`.",
+				ElementType:         types.StringType,
+				Required:            true,
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.OneOf(notificationEventTypes...)),
+				},
+			},
+		},
+	}
+}
+
+func (r *NotificationResource) ConfigValidators(ctx context.Context) []resource.ConfigValidator {
+	return []resource.ConfigValidator{
+		resourcevalidator.ExactlyOneOf(
+			path.MatchRoot("asset_token"),
+			path.MatchRoot("team_token"),
+		),
+	}
+}
+
+func (r *NotificationResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *NotificationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NotificationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := data.request(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	notification, err := r.client.CreateNotification(ctx, body)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create notification, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.update(ctx, notification)...)
+
+	tflog.Trace(ctx, "created a notification", map[string]any{"id": notification.ID})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NotificationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NotificationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	notification, err := r.client.GetNotification(ctx, data.ID.ValueString())
+	if IsNotFound(err) {
+		tflog.Warn(ctx, "notification not found, removing from state", map[string]any{"id": data.ID.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read notification, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.update(ctx, notification)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NotificationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data NotificationResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	body, diags := data.request(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	notification, err := r.client.UpdateNotification(ctx, data.ID.ValueString(), body)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update notification, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.update(ctx, notification)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NotificationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data NotificationResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteNotification(ctx, data.ID.ValueString())
+	if err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete notification, got error: %s", err))
+		return
+	}
+}
+
+func (r *NotificationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// request builds the request body from the model.
+func (m *NotificationResourceModel) request(ctx context.Context) (NotificationRequest, diag.Diagnostics) {
+	body := NotificationRequest{
+		AssetToken: m.AssetToken.ValueString(),
+		TeamToken:  m.TeamToken.ValueString(),
+		Channel:    m.Channel.ValueString(),
+		Recipient:  m.Recipient.ValueString(),
+		EventTypes: []string{},
+	}
+
+	diags := m.EventTypes.ElementsAs(ctx, &body.EventTypes, false)
+
+	return body, diags
+}
+
+// update sets the model values from the API representation of the notification.
+func (m *NotificationResourceModel) update(ctx context.Context, notification *Notification) diag.Diagnostics {
+	m.ID = types.StringValue(notification.ID)
+
+	m.AssetToken = types.StringNull()
+	if notification.AssetToken != "" {
+		m.AssetToken = types.StringValue(notification.AssetToken)
+	}
+
+	m.TeamToken = types.StringNull()
+	if notification.TeamToken != "" {
+		m.TeamToken = types.StringValue(notification.TeamToken)
+	}
+
+	m.Channel = types.StringValue(notification.Channel)
+	m.Recipient = types.StringValue(notification.Recipient)
+
+	var diags diag.Diagnostics
+	m.EventTypes, diags = types.SetValueFrom(ctx, types.StringType, notification.EventTypes)
+
+	return diags
+}
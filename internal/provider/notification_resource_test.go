@@ -0,0 +1,142 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccNotificationResource(t *testing.T) {
+	api := newFakeAPI(t)
+	asset := api.addAsset("example.com")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkNotificationsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_notification" "test" {
+  asset_token = "` + asset + `"
+  channel     = "email"
+  recipient   = "security@example.com"
+  event_types = ["finding_new", "finding_fixed"]
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be one of`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_notification" "test" {
+  channel     = "email"
+  recipient   = "security@example.com"
+  event_types = ["finding_new"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Missing Attribute Configuration`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_notification" "test" {
+  asset_token = "` + asset + `"
+  channel     = "email"
+  recipient   = "security@example.com"
+  event_types = []
+}
+`,
+				ExpectError: regexp.MustCompile(`set must contain at least 1 elements`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_notification" "test" {
+  asset_token = "` + asset + `"
+  channel     = "email"
+  recipient   = "security@example.com"
+  event_types = ["scan_completed", "finding_new"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("detectify_notification.test", "id"),
+					resource.TestCheckResourceAttr("detectify_notification.test", "asset_token", asset),
+					resource.TestCheckNoResourceAttr("detectify_notification.test", "team_token"),
+					resource.TestCheckResourceAttr("detectify_notification.test", "event_types.#", "2"),
+					resource.TestCheckTypeSetElemAttr("detectify_notification.test", "event_types.*", "finding_new"),
+					resource.TestCheckTypeSetElemAttr("detectify_notification.test", "event_types.*", "scan_completed"),
+				),
+			},
+			{
+				// Changing the event filter updates the notification in place.
+				Config: api.providerConfig() + `
+resource "detectify_notification" "test" {
+  asset_token = "` + asset + `"
+  channel     = "slack"
+  recipient   = "#security"
+  event_types = ["finding_new", "finding_resolved", "asset_removed"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_notification.test", "channel", "slack"),
+					resource.TestCheckResourceAttr("detectify_notification.test", "recipient", "#security"),
+					resource.TestCheckResourceAttr("detectify_notification.test", "event_types.#", "3"),
+					resource.TestCheckTypeSetElemAttr("detectify_notification.test", "event_types.*", "finding_resolved"),
+					resource.TestCheckTypeSetElemAttr("detectify_notification.test", "event_types.*", "asset_removed"),
+					func(*terraform.State) error {
+						api.mu.Lock()
+						defer api.mu.Unlock()
+
+						require.Len(t, api.notifications, 1)
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "detectify_notification.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccNotificationResourceTeam(t *testing.T) {
+	api := newFakeAPI(t)
+	team := api.addTeam("Platform Security")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkNotificationsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_notification" "test" {
+  asset_token = "token0001"
+  team_token  = "` + team + `"
+  channel     = "email"
+  recipient   = "security@example.com"
+  event_types = ["finding_new"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_notification" "test" {
+  team_token  = "` + team + `"
+  channel     = "email"
+  recipient   = "security@example.com"
+  event_types = ["asset_added"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_notification.test", "team_token", team),
+					resource.TestCheckNoResourceAttr("detectify_notification.test", "asset_token"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// pageSizeAttribute is the schema of the page_size attribute of data sources listing paginated results.
+func pageSizeAttribute() schema.Int64Attribute {
+	return schema.Int64Attribute{
+		MarkdownDescription: "Number of items requested per page, between 1 and " + strconv.Itoa(MaxPageSize) + ". " +
+			"Defaults to the `default_page_size` of the provider.",
+		Optional: true,
+		Validators: []validator.Int64{
+			int64validator.Between(1, MaxPageSize),
+		},
+	}
+}
+
+// withPageSize returns a context requesting pages of the configured size, or of the provider default if it is not set.
+func withPageSize(ctx context.Context, configured types.Int64, defaultSize int64) context.Context {
+	if !configured.IsNull() && !configured.IsUnknown() {
+		return WithPageSize(ctx, configured.ValueInt64())
+	}
+
+	return WithPageSize(ctx, defaultSize)
+}
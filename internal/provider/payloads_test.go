@@ -0,0 +1,82 @@
+package provider_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPayloads decodes sample API payloads, failing if the payload has a field
+// the struct does not, or if a struct field is not populated by the payload.
+func TestPayloads(t *testing.T) {
+	tests := map[string]any{
+		"asset.json":                 &provider.Asset{},
+		"asset_settings.json":        &provider.AssetSettings{},
+		"scan_profile.json":          &provider.ScanProfile{},
+		"scan_profile_scope.json":    &provider.ScanProfileScope{},
+		"finding.json":               &provider.Finding{},
+		"api_token.json":             &provider.APIToken{},
+		"scan_report.json":           &provider.ScanReport{},
+		"asset_relationship.json":    &provider.AssetRelationship{},
+		"ip_address.json":            &provider.IPAddress{},
+		"subdomain_monitoring.json":  &provider.SubdomainMonitoring{},
+		"test_category.json":         &provider.TestCategory{},
+		"metadata_value.json":        &provider.MetadataValue{},
+		"domain_verification.json":   &provider.DomainVerification{},
+		"finding_comment.json":       &provider.FindingComment{},
+		"account.json":               &provider.Account{},
+		"scan.json":                  &provider.Scan{},
+		"team.json":                  &provider.Team{},
+		"notification.json":          &provider.Notification{},
+		"scan_credentials.json":      &provider.ScanCredentials{},
+		"api_warning.json":           &provider.APIWarning{},
+		"scan_profile_template.json": &provider.ScanProfileTemplate{},
+		"export_schedule.json":       &provider.ExportSchedule{},
+	}
+
+	for file, v := range tests {
+		t.Run(file, func(t *testing.T) {
+			payload, err := os.ReadFile(filepath.Join("testdata", "payloads", file))
+			require.NoError(t, err)
+
+			dec := json.NewDecoder(bytes.NewReader(payload))
+			dec.DisallowUnknownFields()
+			require.NoError(t, dec.Decode(v))
+
+			requireFieldsSet(t, reflect.ValueOf(v).Elem(), reflect.TypeOf(v).Elem().Name())
+		})
+	}
+}
+
+// requireFieldsSet fails if any field of the struct value v, or of structs nested in it, is a zero value.
+func requireFieldsSet(t *testing.T, v reflect.Value, name string) {
+	t.Helper()
+
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Field(i)
+		fieldName := name + "." + v.Type().Field(i).Name
+
+		require.False(t, field.IsZero(), "%s is not set by the payload", fieldName)
+
+		switch field.Kind() {
+		case reflect.Pointer:
+			if field.Elem().Kind() == reflect.Struct {
+				requireFieldsSet(t, field.Elem(), fieldName)
+			}
+		case reflect.Struct:
+			requireFieldsSet(t, field, fieldName)
+		case reflect.Slice:
+			for j := 0; j < field.Len(); j++ {
+				if field.Index(j).Kind() == reflect.Struct {
+					requireFieldsSet(t, field.Index(j), fieldName)
+				}
+			}
+		}
+	}
+}
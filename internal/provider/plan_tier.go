@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// The plan tiers of a Detectify account.
+const (
+	PlanTierStarter      = "starter"
+	PlanTierProfessional = "professional"
+	PlanTierEnterprise   = "enterprise"
+)
+
+// planTiers are the plan tiers, from the lowest to the highest.
+var planTiers = []string{PlanTierStarter, PlanTierProfessional, PlanTierEnterprise}
+
+// planTierRank returns the position of tier in planTiers, or -1 if it is not a known tier.
+func planTierRank(tier string) int {
+	for i, t := range planTiers {
+		if t == tier {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// PlanTier returns the plan tier of the account, reading it from the API the first time it is needed.
+func (d *DetectifyProviderData) PlanTier(ctx context.Context) (string, error) {
+	d.planTierMu.Lock()
+	defer d.planTierMu.Unlock()
+
+	if d.planTier != "" {
+		return d.planTier, nil
+	}
+
+	account, err := d.Client.GetAccount(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	d.planTier = account.PlanTier
+
+	return d.planTier, nil
+}
+
+// requirePlanTier adds an error diagnostic if the account is on a lower plan tier than required by the resource type,
+// so that it fails when planned rather than with an API error when applied.
+// The check is skipped if the plan tier cannot be read or is not known, leaving it to the API.
+func requirePlanTier(ctx context.Context, providerData *DetectifyProviderData, required, typeName string, diags *diag.Diagnostics) {
+	if providerData == nil {
+		return
+	}
+
+	tier, err := providerData.PlanTier(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to read the plan tier of the account, skipping the plan check", map[string]any{"error": err.Error()})
+		return
+	}
+
+	rank := planTierRank(tier)
+	if rank < 0 || rank >= planTierRank(required) {
+		return
+	}
+
+	diags.AddError(
+		"Plan Upgrade Required",
+		fmt.Sprintf("%s requires the %s plan or higher, but the account is on the %s plan.", typeName, planTierTitle(required), planTierTitle(tier)),
+	)
+}
+
+// planTierTitle returns the name of the plan tier as shown by Detectify, such as "Professional".
+func planTierTitle(tier string) string {
+	if tier == "" {
+		return tier
+	}
+
+	return strings.ToUpper(tier[:1]) + tier[1:]
+}
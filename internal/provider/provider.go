@@ -4,24 +4,71 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/tls"
 	"encoding/base64"
+	"errors"
 	"fmt"
+	"hash"
+	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
+	"sort"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
+// DefaultRequestTimeout is the default maximum duration of a single API request.
+const DefaultRequestTimeout = time.Minute
+
+// DefaultTLSMinVersion is the minimum TLS version used unless configured otherwise.
+const DefaultTLSMinVersion = "1.2"
+
+// tlsVersions maps the supported values of tls_min_version to their TLS versions.
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// regionBaseURLs maps the supported values of region to the base URLs of their Detectify API.
+var regionBaseURLs = map[string]string{
+	"eu": DefaultBaseURL,
+	"us": "https://api.us.detectify.com/rest",
+}
+
+// regions returns the supported values of region, sorted.
+func regions() []string {
+	names := make([]string, 0, len(regionBaseURLs))
+	for name := range regionBaseURLs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
 // Ensure DetectifyProvider satisfies various provider interfaces.
-var _ provider.Provider = &DetectifyProvider{}
+var (
+	_ provider.Provider              = &DetectifyProvider{}
+	_ provider.ProviderWithFunctions = &DetectifyProvider{}
+)
 
 // DetectifyProvider defines the provider implementation.
 type DetectifyProvider struct {
@@ -29,18 +76,69 @@ type DetectifyProvider struct {
 	// provider is built and ran locally, and "test" when running acceptance
 	// testing.
 	version string
+
+	// metricsServer serves the metrics of the last configuration, if enabled.
+	metricsServer *http.Server
+
+	// httpTransport sends the signed requests, instead of a network transport, if set.
+	httpTransport http.RoundTripper
 }
 
 // DetectifyProviderModel describes the provider data model.
 type DetectifyProviderModel struct {
-	APIKey types.String `tfsdk:"api_key"`
-	Secret types.String `tfsdk:"secret"`
+	APIKey        types.String `tfsdk:"api_key"`
+	APIKeyFile    types.String `tfsdk:"api_key_file"`
+	Secret        types.String `tfsdk:"secret"`
+	BaseURL       types.String `tfsdk:"base_url"`
+	Region        types.String `tfsdk:"region"`
+	TeamToken     types.String `tfsdk:"team_token"`
+	CorrelationID types.String `tfsdk:"correlation_id"`
+
+	MaxResponseBytes      types.Int64 `tfsdk:"max_response_bytes"`
+	MaxConcurrentRequests types.Int64 `tfsdk:"max_concurrent_requests"`
+	DefaultPageSize       types.Int64 `tfsdk:"default_page_size"`
+
+	AuthMode           types.String `tfsdk:"auth_mode"`
+	DisableSignature   types.Bool   `tfsdk:"disable_signature"`
+	SignatureAlgorithm types.String `tfsdk:"signature_algorithm"`
+	SignQueryString    types.Bool   `tfsdk:"sign_query_string"`
+
+	RequestTimeout      types.String `tfsdk:"request_timeout"`
+	ValidateCredentials types.Bool   `tfsdk:"validate_credentials"`
+	CompressRequests    types.Bool   `tfsdk:"compress_requests"`
+	TLSMinVersion       types.String `tfsdk:"tls_min_version"`
+	ForceHTTP1          types.Bool   `tfsdk:"force_http1"`
+	AllowInsecureURL    types.Bool   `tfsdk:"allow_insecure_url"`
+	ExtraHeaders        types.Map    `tfsdk:"extra_headers"`
+	MetricsListenAddr   types.String `tfsdk:"metrics_listen_addr"`
+	DNSServer           types.String `tfsdk:"dns_server"`
+
+	CircuitBreakerThreshold types.Int64  `tfsdk:"circuit_breaker_threshold"`
+	CircuitBreakerWindow    types.String `tfsdk:"circuit_breaker_window"`
+	CircuitBreakerCooldown  types.String `tfsdk:"circuit_breaker_cooldown"`
 }
 
 // DetectifyProviderData is used by resources and datasources to complete requests.
 type DetectifyProviderData struct {
-	Client *http.Client
-	Secret string
+	Client    *Client
+	TeamToken string
+	// CorrelationID is sent with every request made by the provider.
+	CorrelationID string
+	// ExtraHeaders are sent with every request made by the provider, except where reserved.
+	ExtraHeaders map[string]string
+	// MetricsAddr is the address the metrics endpoint listens on, empty if it is disabled.
+	MetricsAddr string
+	// DefaultPageSize is the page size of listings made by data sources that do not set one, or 0 if the API decides.
+	DefaultPageSize int64
+
+	// requests limits the number of requests in flight, shared by all requests made by the provider.
+	requests semaphore
+	// breaker stops requests while the API is failing, shared by all requests made by the provider.
+	breaker *circuitBreaker
+
+	// planTier is the plan tier of the account, once read by PlanTier.
+	planTierMu sync.Mutex
+	planTier   string
 }
 
 func (p *DetectifyProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -50,18 +148,186 @@ func (p *DetectifyProvider) Metadata(ctx context.Context, req provider.MetadataR
 
 func (p *DetectifyProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		MarkdownDescription: "Interact with the Detectify API. All attributes may also be provided via environment variables, " +
+			"in which case values set in the provider configuration take precedence. " +
+			"Each API request and response is logged at debug level when `" + debugEnvVar + "` is set to `true`, " +
+			"or when `TF_LOG` or `TF_LOG_PROVIDER` is `DEBUG` or `TRACE` unless `" + debugEnvVar + "` is `false`. " +
+			"The values of the authentication, cookie and extra headers are masked in the log.",
 		Attributes: map[string]schema.Attribute{
 			"api_key": schema.StringAttribute{
 				MarkdownDescription: "Detectify API key. May also be provided via `DETECTIFY_API_KEY` environment variable.",
-				Required:            true,
+				Optional:            true,
 				Sensitive:           true,
 			},
+			"api_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file containing the Detectify API key, such as a mounted secret. " +
+					"Surrounding whitespace is ignored. Conflicts with `api_key` and the `DETECTIFY_API_KEY` environment variable.",
+				Optional: true,
+			},
 			"secret": schema.StringAttribute{
 				MarkdownDescription: "Secret used for HMAC signature. May also be provided via `DETECTIFY_SECRET` environment variable. " +
 					"See [API documentation](https://developer.detectify.com/#section/Detectify-API/Authentication) for more information.",
 				Optional:  true,
 				Sensitive: true,
 			},
+			"base_url": schema.StringAttribute{
+				MarkdownDescription: "Base URL of the Detectify API. May also be provided via `DETECTIFY_BASE_URL` environment variable. " +
+					"Defaults to `" + DefaultBaseURL + "`, or the base URL of `region` if set.",
+				Optional: true,
+			},
+			"region": schema.StringAttribute{
+				MarkdownDescription: "Region of the Detectify API, one of `eu` and `us`, to use the base URL of instead of setting `base_url`. " +
+					"Ignored if `base_url` or the `DETECTIFY_BASE_URL` environment variable is set. Defaults to `eu`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(regions()...),
+				},
+			},
+			"team_token": schema.StringAttribute{
+				MarkdownDescription: "Token of the team that new assets are added to. May also be provided via `DETECTIFY_TEAM_TOKEN` environment variable.",
+				Optional:            true,
+			},
+			"correlation_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier sent in the `" + correlationIDHeader + "` header of every request, " +
+					"to correlate the requests of a Terraform run. A random identifier is generated if not set.",
+				Optional: true,
+			},
+			"max_response_bytes": schema.Int64Attribute{
+				MarkdownDescription: "Maximum size of an API response body, in bytes. Larger responses fail with an error. " +
+					fmt.Sprintf("Defaults to `%d` (%d MiB).", DefaultMaxResponseBytes, DefaultMaxResponseBytes>>20),
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"max_concurrent_requests": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of API requests in flight at once, regardless of the parallelism of Terraform. " +
+					"Requests over the limit wait for others to finish. Unlimited by default.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"default_page_size": schema.Int64Attribute{
+				MarkdownDescription: "Number of items requested per page by data sources listing paginated results, " +
+					"unless the data source sets `page_size`. Between 1 and " + strconv.Itoa(MaxPageSize) + ". Uses the API default if not set.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.Between(1, MaxPageSize),
+				},
+			},
+			"auth_mode": schema.StringAttribute{
+				MarkdownDescription: "Authentication scheme of requests, one of `api_key` to authenticate by the API key only, " +
+					"and `hmac` to also sign requests with an HMAC signature using `secret`, which is then required. " +
+					"With `api_key`, a secret set in the configuration or the `DETECTIFY_SECRET` environment variable is not used. " +
+					"If not set, requests are signed if a secret is set and `disable_signature` is not.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(authModes()...),
+				},
+			},
+			"disable_signature": schema.BoolAttribute{
+				MarkdownDescription: "Whether to send requests authenticated by the API key only, without an HMAC signature, " +
+					"even if a secret is set in the configuration or the `DETECTIFY_SECRET` environment variable. Defaults to `false`.",
+				Optional: true,
+			},
+			"signature_algorithm": schema.StringAttribute{
+				MarkdownDescription: "Hash algorithm of the HMAC signature of requests, one of `sha256` and `sha512`. " +
+					"Defaults to `" + string(DefaultSignatureAlgorithm) + "`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(signatureAlgorithms()...),
+				},
+			},
+			"sign_query_string": schema.BoolAttribute{
+				MarkdownDescription: "Whether to include the query string, with parameters sorted by name, in the signed value of requests. " +
+					"Only the path is signed by default. Defaults to `false`.",
+				Optional: true,
+			},
+			"request_timeout": schema.StringAttribute{
+				MarkdownDescription: "Maximum duration of a single API request, such as `30s` or `2m`. " +
+					"Defaults to `" + DefaultRequestTimeout.String() + "`.",
+				Optional: true,
+			},
+			"validate_credentials": schema.BoolAttribute{
+				MarkdownDescription: "Whether to check that the credentials are accepted by the API when configuring the provider, " +
+					"failing early if they are not. The check is a single request reading the account of the API key, bounded by `request_timeout`. " +
+					"Set to `false` when the API is not reachable when planning, such as when using a mock server. Defaults to `true`.",
+				Optional: true,
+			},
+			"compress_requests": schema.BoolAttribute{
+				MarkdownDescription: "Whether to send request bodies of at least " + strconv.Itoa(CompressionMinBytes) + " bytes gzip compressed, " +
+					"with `Content-Encoding: gzip`. Requests are signed with the compressed body. " +
+					"Only enable this if the API accepts compressed requests. Defaults to `false`.",
+				Optional: true,
+			},
+			"tls_min_version": schema.StringAttribute{
+				MarkdownDescription: "Minimum TLS version of connections to the API, one of `1.2` and `1.3`. " +
+					"Defaults to `" + DefaultTLSMinVersion + "`.",
+				Optional: true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("1.2", "1.3"),
+				},
+			},
+			"force_http1": schema.BoolAttribute{
+				MarkdownDescription: "Whether to use HTTP/1.1 instead of HTTP/2, for networks where a proxy or other intermediary breaks HTTP/2. " +
+					"Defaults to `false`, negotiating HTTP/2 when the API supports it.",
+				Optional: true,
+			},
+			"allow_insecure_url": schema.BoolAttribute{
+				MarkdownDescription: "Whether to allow a base URL using plain `http://`, such as for testing against a local server. " +
+					"The API key and request signatures are then sent in cleartext. Defaults to `false`.",
+				Optional: true,
+			},
+			"extra_headers": schema.MapAttribute{
+				MarkdownDescription: "Headers sent with every request, such as for an API gateway or tracing. " +
+					"The authentication headers and `" + correlationIDHeader + "` are reserved and cannot be set. " +
+					"The values are sensitive, as they may carry credentials.",
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+			},
+			"metrics_listen_addr": schema.StringAttribute{
+				MarkdownDescription: "Address, such as `127.0.0.1:9464`, to serve Prometheus metrics of the API requests on at `/metrics` " +
+					"while the provider runs. The metrics include request counts, latencies and retries. Disabled if not set.",
+				Optional: true,
+			},
+			"dns_server": schema.StringAttribute{
+				MarkdownDescription: "Address of the DNS server, such as `10.0.0.53` or `10.0.0.53:5353`, to resolve the host of the base URL with " +
+					"instead of the system resolver, such as for split-horizon DNS to reach an internal Detectify deployment. " +
+					"The port defaults to `53`.",
+				Optional: true,
+				Validators: []validator.String{
+					dnsServerValidator{},
+				},
+			},
+			"circuit_breaker_threshold": schema.Int64Attribute{
+				MarkdownDescription: "Number of consecutive failed API requests within `circuit_breaker_window` after which requests " +
+					"fail immediately, without being sent, for `circuit_breaker_cooldown`. This stops a large apply from spending minutes " +
+					"retrying an API that is down. A request fails if it cannot be sent or the API responds with a server error. " +
+					"After the cooldown a single request is sent to probe the API, while the others keep failing until it finishes, " +
+					"and the breaker trips again right away if it fails. Disabled if not set.",
+				Optional: true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"circuit_breaker_window": schema.StringAttribute{
+				MarkdownDescription: "Duration, such as `30s` or `2m`, within which the failed requests counted by `circuit_breaker_threshold` must happen. " +
+					"Defaults to `" + DefaultCircuitBreakerWindow.String() + "`.",
+				Optional: true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
+			"circuit_breaker_cooldown": schema.StringAttribute{
+				MarkdownDescription: "Duration, such as `30s` or `2m`, for which requests fail immediately once `circuit_breaker_threshold` is reached. " +
+					"Defaults to `" + DefaultCircuitBreakerCooldown.String() + "`.",
+				Optional: true,
+				Validators: []validator.String{
+					durationValidator{},
+				},
+			},
 		},
 	}
 }
@@ -86,6 +352,15 @@ func (p *DetectifyProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	if config.APIKeyFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("api_key_file"),
+			"Unknown Detectify API key file",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the Detectify API key file. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
 	if config.Secret.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("secret"),
@@ -94,6 +369,238 @@ func (p *DetectifyProvider) Configure(ctx context.Context, req provider.Configur
 		)
 	}
 
+	if config.BaseURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("base_url"),
+			"Unknown Detectify base URL",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the Detectify base URL. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the DETECTIFY_BASE_URL environment variable.",
+		)
+	}
+
+	if config.Region.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("region"),
+			"Unknown Detectify region",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the Detectify region. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.TeamToken.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("team_token"),
+			"Unknown Detectify team token",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the Detectify team token. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the DETECTIFY_TEAM_TOKEN environment variable.",
+		)
+	}
+
+	if config.CorrelationID.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("correlation_id"),
+			"Unknown correlation ID",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the correlation ID. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.MaxResponseBytes.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_response_bytes"),
+			"Unknown maximum response size",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the maximum response size. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.MaxConcurrentRequests.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("max_concurrent_requests"),
+			"Unknown maximum concurrent requests",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the maximum concurrent requests. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.DefaultPageSize.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("default_page_size"),
+			"Unknown default page size",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the default page size. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.AuthMode.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_mode"),
+			"Unknown authentication mode",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the authentication mode. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.DisableSignature.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("disable_signature"),
+			"Unknown signature setting",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for disabling signatures. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.SignatureAlgorithm.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("signature_algorithm"),
+			"Unknown signature algorithm",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the signature algorithm. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.SignQueryString.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("sign_query_string"),
+			"Unknown query string signing setting",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for signing query strings. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.TLSMinVersion.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("tls_min_version"),
+			"Unknown minimum TLS version",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the minimum TLS version. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ForceHTTP1.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("force_http1"),
+			"Unknown HTTP/1.1 setting",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for forcing HTTP/1.1. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.CompressRequests.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("compress_requests"),
+			"Unknown request compression setting",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for compressing requests. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.AllowInsecureURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("allow_insecure_url"),
+			"Unknown Detectify insecure URL setting",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for allowing an insecure base URL. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.RequestTimeout.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("request_timeout"),
+			"Unknown request timeout",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the request timeout. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ValidateCredentials.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("validate_credentials"),
+			"Unknown credential validation setting",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for validating credentials. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.ExtraHeaders.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("extra_headers"),
+			"Unknown extra headers",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the extra headers. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.MetricsListenAddr.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("metrics_listen_addr"),
+			"Unknown metrics listen address",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the metrics listen address. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.DNSServer.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("dns_server"),
+			"Unknown DNS server",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the DNS server. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.CircuitBreakerThreshold.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("circuit_breaker_threshold"),
+			"Unknown circuit breaker threshold",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the circuit breaker threshold. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.CircuitBreakerWindow.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("circuit_breaker_window"),
+			"Unknown circuit breaker window",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the circuit breaker window. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	if config.CircuitBreakerCooldown.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("circuit_breaker_cooldown"),
+			"Unknown circuit breaker cooldown",
+			"The provider cannot create the Detectify API client as there is an unknown configuration value for the circuit breaker cooldown. "+
+				"Either target apply the source of the value first or set the value statically in the configuration.",
+		)
+	}
+
+	extraHeaders := map[string]string{}
+	for name, value := range config.ExtraHeaders.Elements() {
+		v, ok := value.(types.String)
+		if !ok || v.IsUnknown() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("extra_headers").AtMapKey(name),
+				"Unknown extra header",
+				fmt.Sprintf("The provider cannot create the Detectify API client as there is an unknown configuration value for the header %q. ", name)+
+					"Either target apply the source of the value first or set the value statically in the configuration.",
+			)
+			continue
+		}
+
+		if reservedHeaders[http.CanonicalHeaderKey(name)] {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("extra_headers").AtMapKey(name),
+				"Reserved header",
+				fmt.Sprintf("The header %q is set by the provider and cannot be overridden.", name),
+			)
+			continue
+		}
+
+		extraHeaders[http.CanonicalHeaderKey(name)] = v.ValueString()
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -102,26 +609,175 @@ func (p *DetectifyProvider) Configure(ctx context.Context, req provider.Configur
 	// overriding with Terraform configuration values if set.
 	apiKey := os.Getenv("DETECTIFY_API_KEY")
 	secret := os.Getenv("DETECTIFY_SECRET")
+	baseURL := os.Getenv("DETECTIFY_BASE_URL")
+	teamToken := os.Getenv("DETECTIFY_TEAM_TOKEN")
 
 	if !config.APIKey.IsNull() {
 		apiKey = config.APIKey.ValueString()
 	}
 
+	// The key file is an alternative to the other sources of the key, so it
+	// cannot be combined with them.
+	if !config.APIKeyFile.IsNull() {
+		if !config.APIKey.IsNull() || len(os.Getenv("DETECTIFY_API_KEY")) > 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_key_file"),
+				"Conflicting Detectify API key",
+				"The Detectify API key file cannot be used together with the api_key attribute or the DETECTIFY_API_KEY environment variable. "+
+					"Remove all but one of them.",
+			)
+
+			return
+		}
+
+		b, err := os.ReadFile(config.APIKeyFile.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("api_key_file"),
+				"Unable to read Detectify API key file",
+				"The provider cannot read the Detectify API key from the file: "+err.Error(),
+			)
+
+			return
+		}
+
+		apiKey = strings.TrimSpace(string(b))
+	}
+
 	if !config.Secret.IsNull() {
 		secret = config.Secret.ValueString()
 	}
 
+	if !config.BaseURL.IsNull() {
+		baseURL = config.BaseURL.ValueString()
+	}
+
+	if !config.TeamToken.IsNull() {
+		teamToken = config.TeamToken.ValueString()
+	}
+
+	authMode := AuthMode(config.AuthMode.ValueString())
+	if authMode == AuthModeHMAC && config.DisableSignature.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("disable_signature"),
+			"Conflicting Detectify authentication mode",
+			"Requests cannot be sent without a signature when auth_mode is \"hmac\". Remove disable_signature or set auth_mode to \"api_key\".",
+		)
+
+		return
+	}
+
+	// Without a secret, the transport does not sign requests.
+	if authMode == AuthModeAPIKey || config.DisableSignature.ValueBool() {
+		secret = ""
+	}
+
+	if len(baseURL) == 0 && !config.Region.IsNull() {
+		regionURL, ok := regionBaseURLs[config.Region.ValueString()]
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("region"),
+				"Invalid Detectify region",
+				fmt.Sprintf("The Detectify region must be one of %s, got: %q. Set base_url instead for other deployments.",
+					strings.Join(regions(), ", "), config.Region.ValueString()),
+			)
+
+			return
+		}
+
+		baseURL = regionURL
+	}
+
+	if len(baseURL) == 0 {
+		baseURL = DefaultBaseURL
+	}
+
+	// The API key and signatures must not be sent in cleartext, unless explicitly allowed.
+	if u, err := url.Parse(baseURL); err == nil && strings.EqualFold(u.Scheme, "http") && !config.AllowInsecureURL.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("base_url"),
+			"Insecure Detectify base URL",
+			fmt.Sprintf("The base URL %q uses plain HTTP, so the API key and request signatures would be sent in cleartext. "+
+				"Use an https:// URL, or set allow_insecure_url to true if this is intended, such as for testing against a local server.", baseURL),
+		)
+
+		return
+	}
+
+	correlationID := config.CorrelationID.ValueString()
+	if len(correlationID) == 0 {
+		id, err := uuid.GenerateUUID()
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to generate correlation ID", err.Error())
+			return
+		}
+
+		correlationID = id
+	}
+
 	// If any expected configuration is missing, add errors with instructions.
 	if len(apiKey) == 0 {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
 			"Missing Detectify API key",
 			"The provider cannot create the Detectify API client as there is a missing or empty value for the Detectify API key. "+
-				"Set the API key value in the configuration or use the DETECTIFY_API_KEY environment variable. "+
+				"Set the API key value or key file in the configuration or use the DETECTIFY_API_KEY environment variable. "+
+				"If either is already set, ensure the value is not empty.",
+		)
+	}
+
+	if authMode == AuthModeHMAC && len(secret) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secret"),
+			"Missing Detectify secret",
+			"The provider cannot create the Detectify API client as there is a missing or empty value for the Detectify secret, "+
+				"which is required to sign requests when auth_mode is \"hmac\". "+
+				"Set the secret value in the configuration or use the DETECTIFY_SECRET environment variable, or set auth_mode to \"api_key\". "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
+	if _, err := base64.StdEncoding.DecodeString(secret); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secret"),
+			"Invalid Detectify secret",
+			"The Detectify secret must be base64 encoded, as provided by Detectify: "+err.Error(),
+		)
+	}
+
+	algorithm := DefaultSignatureAlgorithm
+	if !config.SignatureAlgorithm.IsNull() {
+		algorithm = SignatureAlgorithm(config.SignatureAlgorithm.ValueString())
+	}
+
+	requestTimeout := DefaultRequestTimeout
+	if !config.RequestTimeout.IsNull() {
+		d, err := time.ParseDuration(config.RequestTimeout.ValueString())
+		if err != nil || d <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("request_timeout"),
+				"Invalid request timeout",
+				fmt.Sprintf("The request timeout must be a positive duration such as \"30s\", got: %q.", config.RequestTimeout.ValueString()),
+			)
+		}
+
+		requestTimeout = d
+	}
+
+	tlsMinVersion := tlsVersions[DefaultTLSMinVersion]
+	if !config.TLSMinVersion.IsNull() {
+		version, ok := tlsVersions[config.TLSMinVersion.ValueString()]
+		if !ok {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tls_min_version"),
+				"Unsupported TLS version",
+				fmt.Sprintf("The minimum TLS version must be one of \"1.2\" and \"1.3\", got: %q.", config.TLSMinVersion.ValueString()),
+			)
+		}
+
+		tlsMinVersion = version
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -131,21 +787,156 @@ func (p *DetectifyProvider) Configure(ctx context.Context, req provider.Configur
 	ctx = tflog.SetField(ctx, "secret", secret)
 	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "secret")
 
+	requests := newSemaphore(config.MaxConcurrentRequests.ValueInt64())
+
+	// The durations are validated by the schema.
+	breakerWindow, breakerCooldown := DefaultCircuitBreakerWindow, DefaultCircuitBreakerCooldown
+	if d, err := time.ParseDuration(config.CircuitBreakerWindow.ValueString()); err == nil && d > 0 {
+		breakerWindow = d
+	}
+	if d, err := time.ParseDuration(config.CircuitBreakerCooldown.ValueString()); err == nil && d > 0 {
+		breakerCooldown = d
+	}
+	breaker := newCircuitBreaker(config.CircuitBreakerThreshold.ValueInt64(), breakerWindow, breakerCooldown)
+
+	// A provider configured again replaces its metrics server.
+	if p.metricsServer != nil {
+		if err := shutdownMetricsServer(ctx, p.metricsServer); err != nil {
+			tflog.Warn(ctx, "Unable to stop metrics server", map[string]any{"error": err.Error()})
+		}
+		p.metricsServer = nil
+	}
+
+	var requestMetrics *metrics
+	var metricsAddr string
+	if addr := config.MetricsListenAddr.ValueString(); addr != "" {
+		requestMetrics = newMetrics()
+
+		server, listenAddr, err := startMetricsServer(addr, requestMetrics)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("metrics_listen_addr"),
+				"Unable to start metrics server",
+				fmt.Sprintf("The provider cannot serve metrics on %q, got error: %s", addr, err),
+			)
+			return
+		}
+
+		p.metricsServer = server
+		metricsAddr = listenAddr
+		tflog.Info(ctx, "Serving metrics", map[string]any{"addr": metricsAddr})
+	}
+
+	var resolver *net.Resolver
+	if !config.DNSServer.IsNull() {
+		addr, _ := dnsServerAddress(config.DNSServer.ValueString())
+		resolver = newResolver(addr)
+	}
+
+	var baseTransport http.RoundTripper = newHTTPTransport(tlsMinVersion, config.ForceHTTP1.ValueBool(), resolver)
+	if p.httpTransport != nil {
+		baseTransport = p.httpTransport
+	}
+
+	debug, debugSource := debugFromEnv()
+	if debugSource != "" {
+		tflog.Info(ctx, "Logging API requests", map[string]any{"source": debugSource})
+	}
+	if v := os.Getenv(debugEnvVar); v != "" {
+		if _, err := strconv.ParseBool(v); err != nil {
+			resp.Diagnostics.AddWarning(
+				"Invalid "+debugEnvVar+" value",
+				fmt.Sprintf("The %s environment variable must be true or false, got: %q.", debugEnvVar, v),
+			)
+		}
+	}
+
 	// wrap transport for client
-	client := http.DefaultClient
-	client.Transport = &transport{
-		Transport: http.DefaultTransport,
-		signature: config.Secret.ValueString(),
+	client := &http.Client{
+		Transport: &transport{
+			Transport:     baseTransport,
+			apiKey:        apiKey,
+			secret:        secret,
+			algorithm:     algorithm,
+			signQuery:     config.SignQueryString.ValueBool(),
+			correlationID: correlationID,
+			extraHeaders:  extraHeaders,
+			requests:      requests,
+			breaker:       breaker,
+			metrics:       requestMetrics,
+			debug:         debug,
+		},
+		CheckRedirect: checkRedirect(strings.TrimSuffix(baseURL, "/")),
+		Timeout:       requestTimeout,
+	}
+
+	apiClient := NewClient(client, strings.TrimSuffix(baseURL, "/"))
+	apiClient.metrics = requestMetrics
+	apiClient.SetSignedRequests(secret != "")
+	if !config.MaxResponseBytes.IsNull() {
+		apiClient.SetMaxResponseBytes(config.MaxResponseBytes.ValueInt64())
+	}
+
+	if config.CompressRequests.ValueBool() {
+		apiClient.SetRequestCompression(CompressionMinBytes)
+	}
+
+	var planTier string
+	if config.ValidateCredentials.IsNull() || config.ValidateCredentials.ValueBool() {
+		// Bound the whole check, including retries, so that an unresponsive API cannot block Terraform.
+		validateCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+		defer cancel()
+
+		// The account of the API key is read, as it needs no permission beyond valid credentials.
+		account, err := apiClient.GetAccount(validateCtx)
+		if err != nil {
+			detail := fmt.Sprintf("The Detectify API did not accept the credentials, got error: %s", err)
+
+			var authErr *AuthError
+			switch {
+			case errors.Is(validateCtx.Err(), context.DeadlineExceeded):
+				detail = fmt.Sprintf("The Detectify API did not respond within the request timeout of %s.", requestTimeout)
+			case errors.As(err, &authErr):
+				detail = authErr.Hint() + fmt.Sprintf(" Got error: %s", authErr.APIError)
+			}
+
+			resp.Diagnostics.AddError("Unable to validate Detectify credentials", detail)
+			return
+		}
+
+		planTier = account.PlanTier
 	}
 
-	providerData := DetectifyProviderData{
-		Client: client,
-		Secret: config.Secret.ValueString(),
+	providerData := &DetectifyProviderData{
+		Client:          apiClient,
+		TeamToken:       teamToken,
+		CorrelationID:   correlationID,
+		ExtraHeaders:    extraHeaders,
+		MetricsAddr:     metricsAddr,
+		DefaultPageSize: config.DefaultPageSize.ValueInt64(),
+		requests:        requests,
+		breaker:         breaker,
+		planTier:        planTier,
 	}
 
 	resp.DataSourceData = providerData
 	resp.ResourceData = providerData
 
+	// A missing secret is not an error, as API keys may not require signed requests, so say which mode is used
+	// to help debug requests that are rejected.
+	switch {
+	case authMode == AuthModeAPIKey:
+		tflog.Info(ctx, "Authenticating requests with the API key only, as auth_mode is api_key", map[string]any{"auth_mode": "api_key"})
+	case config.DisableSignature.ValueBool():
+		tflog.Info(ctx, "Authenticating requests with the API key only, as disable_signature is set", map[string]any{"auth_mode": "api_key"})
+	case secret == "":
+		tflog.Info(ctx, "Authenticating requests with the API key only, as no secret is set. "+
+			"Set secret or DETECTIFY_SECRET if the API key requires signed requests", map[string]any{"auth_mode": "api_key"})
+	default:
+		tflog.Info(ctx, "Authenticating requests with the API key and an HMAC signature", map[string]any{"auth_mode": "signature", "signature_algorithm": string(algorithm)})
+	}
+
+	tflog.Info(ctx, "Sending requests with correlation ID", map[string]any{"correlation_id": correlationID})
 	tflog.Debug(ctx, "Configured Detectify provider", map[string]any{"success": true})
 }
 
@@ -153,6 +944,14 @@ func (p *DetectifyProvider) Configure(ctx context.Context, req provider.Configur
 func (p *DetectifyProvider) Resources(ctx context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewAssetResource,
+		NewScanProfileResource,
+		NewAPITokenResource,
+		NewScanProfileAttachmentResource,
+		NewDomainVerificationResource,
+		NewFindingCommentResource,
+		NewNotificationResource,
+		NewAssetTaggingResource,
+		NewExportScheduleResource,
 	}
 }
 
@@ -160,6 +959,27 @@ func (p *DetectifyProvider) Resources(ctx context.Context) []func() resource.Res
 func (p *DetectifyProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewAssetDataSource,
+		NewAssetsDataSource,
+		NewAssetsByTokensDataSource,
+		NewFindingsDataSource,
+		NewScanReportDataSource,
+		NewAssetRelationshipsDataSource,
+		NewAssetIPAddressesDataSource,
+		NewTestCategoriesDataSource,
+		NewScanProfileTemplatesDataSource,
+		NewCoverageDataSource,
+		NewScanHistoryDataSource,
+		NewTeamDataSource,
+	}
+}
+
+// Functions defines the provider-defined functions implemented in the provider.
+func (p *DetectifyProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewIsValidDomainFunction,
+		NewNormalizeDomainFunction,
+		NewIsValidTokenFunction,
+		NewVerifyWebhookFunction,
 	}
 }
 
@@ -171,42 +991,360 @@ func New(version string) func() provider.Provider {
 	}
 }
 
+// NewWithHTTPTransport returns a provider sending its requests, once authenticated and signed, using rt
+// instead of the network. It lets tests serve canned responses without starting a server.
+// The tls_min_version attribute has no effect on rt.
+func NewWithHTTPTransport(version string, rt http.RoundTripper) func() provider.Provider {
+	return func() provider.Provider {
+		return &DetectifyProvider{
+			version:       version,
+			httpTransport: rt,
+		}
+	}
+}
+
+// correlationIDHeader is the header used to send the correlation ID.
+const correlationIDHeader = "X-Correlation-ID"
+
+// reservedHeaders are the headers set by the transport, which cannot be set as extra headers.
+// The names are in canonical form.
+var reservedHeaders = map[string]bool{
+	"X-Detectify-Key":       true,
+	"X-Detectify-Timestamp": true,
+	"X-Detectify-Signature": true,
+
+	http.CanonicalHeaderKey(correlationIDHeader): true,
+}
+
 // custom transport with API credentials in headers
 type transport struct {
-	Transport http.RoundTripper
-	Headers   http.Header
-	apiKey    string
-	secret    string
-	signature string
+	Transport     http.RoundTripper
+	apiKey        string
+	secret        string
+	algorithm     SignatureAlgorithm
+	signQuery     bool
+	correlationID string
+	extraHeaders  map[string]string
+	requests      semaphore
+	breaker       *circuitBreaker
+	metrics       *metrics
+	// debug logs each request and response.
+	debug bool
+
+	// deprecationOnce limits the deprecation warning to one per run.
+	deprecationOnce sync.Once
 }
 
 func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
-	t.Headers.Set("X-Detectify-Key", t.apiKey)
+	// RoundTrippers must not modify the original request.
+	req = req.Clone(req.Context())
+
+	// The path is signed with a trailing slash, so it must be sent with one for the signature to match.
+	normalizePath(req.URL)
 
-	if len(t.signature) > 0 {
+	// Extra headers do not override headers of the request, and are set before the reserved headers.
+	for name, value := range t.extraHeaders {
+		if _, ok := req.Header[name]; !ok {
+			req.Header.Set(name, value)
+		}
+	}
+
+	req.Header.Set("X-Detectify-Key", t.apiKey)
+	req.Header.Set(correlationIDHeader, t.correlationID)
+
+	if err := t.breaker.allow(); err != nil {
+		return nil, err
+	}
+
+	if err := t.requests.acquire(req.Context()); err != nil {
+		t.breaker.abandon()
+		return nil, err
+	}
+
+	// The request is signed once it may be sent, so that waiting for a slot does not age its timestamp.
+	if len(t.secret) > 0 {
 		ts := time.Now()
-		signature := CalculateSignature(req, t.apiKey, t.secret, ts)
+		signature := CalculateSignature(req, t.apiKey, t.secret, ts, t.algorithm, t.signQuery)
 
-		t.Headers.Set("X-Detectify-Timestamp", strconv.FormatInt(ts.Unix(), 10))
-		t.Headers.Set("X-Detectify-Signature", signature)
+		req.Header.Set("X-Detectify-Timestamp", strconv.FormatInt(ts.Unix(), 10))
+		req.Header.Set("X-Detectify-Signature", signature)
 	}
 
-	return t.Transport.RoundTrip(req)
+	start := time.Now()
+	resp, err := t.Transport.RoundTrip(req)
+	// Requests given up on by the provider say nothing about the API.
+	if req.Context().Err() == nil {
+		t.breaker.record(err != nil || resp.StatusCode >= 500)
+	} else {
+		t.breaker.abandon()
+	}
+	t.metrics.observeRequest(req.Method, resp, time.Since(start))
+	if t.debug {
+		t.logRequest(req, resp, err, time.Since(start))
+	}
+	if err != nil {
+		t.requests.release()
+		return nil, err
+	}
+
+	resp.Body = &releasingBody{ReadCloser: resp.Body, release: t.requests.release}
+	t.warnDeprecation(req, resp)
+
+	return resp, nil
+}
+
+// maxRedirects is the number of redirects followed for a request before giving up.
+const maxRedirects = 10
+
+// checkRedirect returns the redirect policy of the HTTP client. The transport signs each request it sends,
+// so a redirected request is signed anew for its path instead of replaying the signature of the original.
+// Redirects outside of the base URL are refused, so that the API key and signatures are not sent elsewhere.
+func checkRedirect(baseURL string) func(req *http.Request, via []*http.Request) error {
+	return func(req *http.Request, via []*http.Request) error {
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %d redirects", maxRedirects)
+		}
+
+		base, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+
+		target := *req.URL
+		target.RawQuery, target.Fragment = "", ""
+		if !strings.EqualFold(target.Scheme, base.Scheme) || !strings.EqualFold(target.Host, base.Host) ||
+			!strings.HasPrefix(target.Path, strings.TrimSuffix(base.Path, "/")+"/") {
+			return fmt.Errorf("%w: %s %s redirected to %s, outside of the API base URL %s",
+				ErrRedirectRefused, via[0].Method, via[0].URL, req.URL, baseURL)
+		}
+
+		return nil
+	}
+}
+
+// debugEnvVar is the environment variable enabling logging of API requests.
+const debugEnvVar = "DETECTIFY_PROVIDER_DEBUG"
+
+// debugFromEnv reports whether API requests are logged, and the environment variable enabling it.
+// Terraform starts the provider without command line flags, so this is configured by the environment.
+func debugFromEnv() (bool, string) {
+	if enabled, err := strconv.ParseBool(os.Getenv(debugEnvVar)); err == nil {
+		if enabled {
+			return true, debugEnvVar
+		}
+		return false, ""
+	}
+
+	for _, name := range []string{"TF_LOG_PROVIDER", "TF_LOG"} {
+		switch strings.ToUpper(os.Getenv(name)) {
+		case "DEBUG", "TRACE":
+			return true, name
+		}
+	}
+
+	return false, ""
 }
 
-// Calculate the HMAC signature for the request.
-func CalculateSignature(req *http.Request, apiKey, secretKey string, timestamp time.Time) string {
+// sensitiveHeaders are the headers whose values are not logged, besides the extra headers,
+// which may carry credentials such as gateway tokens.
+var sensitiveHeaders = []string{
+	"X-Detectify-Key",
+	"X-Detectify-Signature",
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// maskHeaders returns a copy of the headers, with the values of sensitive headers and extra headers masked.
+func (t *transport) maskHeaders(header http.Header) http.Header {
+	masked := header.Clone()
+	mask := func(name string) {
+		if len(masked.Values(name)) > 0 {
+			masked.Set(name, "***")
+		}
+	}
+
+	for _, name := range sensitiveHeaders {
+		mask(name)
+	}
+	for name := range t.extraHeaders {
+		mask(name)
+	}
+
+	return masked
+}
+
+// logRequest logs a request sent to the API and its response, with the values of sensitive headers masked.
+func (t *transport) logRequest(req *http.Request, resp *http.Response, err error, duration time.Duration) {
+	fields := map[string]any{
+		"method":          req.Method,
+		"url":             req.URL.String(),
+		"request_headers": t.maskHeaders(req.Header),
+		"duration_ms":     duration.Milliseconds(),
+	}
+	if err != nil {
+		fields["error"] = err.Error()
+	} else {
+		fields["status"] = resp.StatusCode
+		fields["protocol"] = resp.Proto
+		fields["response_headers"] = t.maskHeaders(resp.Header)
+	}
+
+	tflog.Debug(req.Context(), "Sent Detectify API request", fields)
+}
+
+// newHTTPTransport returns a copy of the default transport, negotiating at least the TLS version.
+// Like the default transport, it uses HTTP/2 when the server supports it, unless forceHTTP1 is set.
+// Hosts are resolved with the resolver if set, or the system resolver if nil.
+func newHTTPTransport(minVersion uint16, forceHTTP1 bool, resolver *net.Resolver) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	if resolver != nil {
+		// The dialer of the default transport, but resolving with the resolver.
+		dialer := &net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+			Resolver:  resolver,
+		}
+		t.DialContext = dialer.DialContext
+	}
+
+	if t.TLSClientConfig == nil {
+		t.TLSClientConfig = &tls.Config{}
+	}
+	t.TLSClientConfig.MinVersion = minVersion
+
+	// A non-nil empty TLSNextProto disables HTTP/2, and only HTTP/1.1 is offered when negotiating the protocol.
+	if forceHTTP1 {
+		t.ForceAttemptHTTP2 = false
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		t.TLSClientConfig.NextProtos = []string{"http/1.1"}
+	}
+
+	return t
+}
+
+// newResolver returns a resolver sending all DNS queries to the server at addr.
+func newResolver(addr string) *net.Resolver {
+	var dialer net.Dialer
+	return &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, addr)
+		},
+	}
+}
+
+// normalizePath adds a trailing slash to the path of u, unless it already has one.
+func normalizePath(u *url.URL) {
+	if strings.HasSuffix(u.Path, "/") {
+		return
+	}
+
+	u.Path += "/"
+	if u.RawPath != "" {
+		u.RawPath += "/"
+	}
+}
+
+// warnDeprecation logs a warning the first time a response signals that the API is deprecated.
+func (t *transport) warnDeprecation(req *http.Request, resp *http.Response) {
+	deprecation := resp.Header.Get("Deprecation")
+	sunset := resp.Header.Get("Sunset")
+	if deprecation == "" && sunset == "" {
+		return
+	}
+
+	t.deprecationOnce.Do(func() {
+		tflog.Warn(req.Context(), "The Detectify API is deprecated for requests made by this provider version, upgrade the provider", map[string]any{
+			"method":      req.Method,
+			"path":        req.URL.Path,
+			"deprecation": deprecation,
+			"sunset":      sunset,
+			"link":        resp.Header.Get("Link"),
+		})
+	})
+}
+
+// AuthMode is the authentication scheme of requests.
+type AuthMode string
+
+const (
+	// AuthModeAPIKey authenticates requests by the API key only.
+	AuthModeAPIKey AuthMode = "api_key"
+	// AuthModeHMAC authenticates requests by the API key and an HMAC signature.
+	AuthModeHMAC AuthMode = "hmac"
+)
+
+// authModes returns the names of the supported authentication modes.
+func authModes() []string {
+	return []string{string(AuthModeAPIKey), string(AuthModeHMAC)}
+}
+
+// SignatureAlgorithm is the hash algorithm of the HMAC signature of a request.
+type SignatureAlgorithm string
+
+const (
+	SignatureAlgorithmSHA256 SignatureAlgorithm = "sha256"
+	SignatureAlgorithmSHA512 SignatureAlgorithm = "sha512"
+)
+
+// DefaultSignatureAlgorithm is the signature algorithm used unless configured otherwise.
+const DefaultSignatureAlgorithm = SignatureAlgorithmSHA256
+
+// signatureHashes maps the supported signature algorithms to their hash functions.
+var signatureHashes = map[SignatureAlgorithm]func() hash.Hash{
+	SignatureAlgorithmSHA256: sha256.New,
+	SignatureAlgorithmSHA512: sha512.New,
+}
+
+// signatureAlgorithms returns the names of the supported signature algorithms.
+func signatureAlgorithms() []string {
+	return []string{string(SignatureAlgorithmSHA256), string(SignatureAlgorithmSHA512)}
+}
+
+// Calculate the HMAC signature for the request using the algorithm, including the
+// query string if signQuery is set. It panics if the algorithm is not supported.
+func CalculateSignature(req *http.Request, apiKey, secretKey string, timestamp time.Time, algorithm SignatureAlgorithm, signQuery bool) string {
+	newHash, ok := signatureHashes[algorithm]
+	if !ok {
+		panic(fmt.Sprintf("unsupported signature algorithm %q", algorithm))
+	}
+
 	key, err := base64.StdEncoding.DecodeString(secretKey)
 	if err != nil {
 		panic(err)
 	}
 
-	// TODO: Issue with reading body like this?
-
-	value := fmt.Sprintf("%s;%s;%s;%d;%s", req.Method, req.URL.Path, apiKey, timestamp.Unix(), req.Body)
-	fmt.Println(value)
-	mac := hmac.New(sha256.New, key)
+	value := CanonicalRequestString(req, apiKey, timestamp, signQuery)
+	mac := hmac.New(newHash, key)
 	mac.Write([]byte(value))
 
 	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
 }
+
+// CanonicalRequestString returns the string signed for the request, in the form
+// method;path;apiKey;timestamp;body. If signQuery is set and the request has a
+// query string, the path is followed by the query string with parameters sorted by name.
+func CanonicalRequestString(req *http.Request, apiKey string, timestamp time.Time, signQuery bool) string {
+	// Read the body from a copy, leaving the request body intact for sending.
+	var body []byte
+	if req.GetBody != nil {
+		if r, err := req.GetBody(); err == nil {
+			body, _ = io.ReadAll(r)
+			r.Close()
+		}
+	}
+
+	// The path is signed as it is sent, escaped, and with a trailing slash as the API paths have one.
+	urlPath := req.URL.EscapedPath()
+	if !strings.HasSuffix(urlPath, "/") {
+		urlPath += "/"
+	}
+
+	if query := req.URL.Query(); signQuery && len(query) > 0 {
+		urlPath += "?" + query.Encode()
+	}
+
+	return fmt.Sprintf("%s;%s;%s;%d;%s", req.Method, urlPath, apiKey, timestamp.Unix(), body)
+}
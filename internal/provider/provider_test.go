@@ -1,16 +1,37 @@
 package provider_test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"maps"
+	"net"
 	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
 	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
 	loader "github.com/peteole/testdata-loader"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/net/dns/dnsmessage"
 )
 
 // testAccProtoV6ProviderFactories are used to instantiate a provider during
@@ -38,17 +59,1712 @@ func testProviderPreCheck(t *testing.T) {
 	// TODO: Validate provider setup
 }
 
+// testAccLivePreCheck skips tests against the real Detectify API unless credentials are provided.
+// The secret is optional, as the API key can be used without signing requests.
+func testAccLivePreCheck(t *testing.T) {
+	if os.Getenv("DETECTIFY_API_KEY") == "" {
+		t.Skip("DETECTIFY_API_KEY must be set for acceptance tests against the Detectify API")
+	}
+
+	if os.Getenv("DETECTIFY_TEST_DOMAIN") == "" {
+		t.Skip("DETECTIFY_TEST_DOMAIN must be set to a domain that may be added and removed as an asset")
+	}
+}
+
 func TestCalculateHMACSignature(t *testing.T) {
 	// path := "/v2/domains/"
 	var ts int64 = 1519829567
 	apiKey := "10840b0f938942feafb7186de74b9682"
 	secretKey := "0vyTnawJRFn0Q9tWLTM188Olizc72JczHSXoIlsPQIc="
 
-	req, err := http.NewRequest(http.MethodGet, "http://localhost/v2/domains", nil)
+	tests := []struct {
+		algorithm provider.SignatureAlgorithm
+		expected  string
+	}{
+		{provider.SignatureAlgorithmSHA256, "6jpu6S4cQwEY4uLk+xELSe1RhajVJP0QEDpGWZ5T+U0="},
+		{provider.SignatureAlgorithmSHA512, "bAlNsalXzzrVWULIwiAaHzeOlWk6YHsbdmCF6s5h9K2PcEP5UdiRPszc57mFM8fdy9KrVijiBvM/KMr+cuG+8g=="},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.algorithm), func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, "http://localhost/v2/domains", nil)
+			require.NoError(t, err)
+
+			actual := provider.CalculateSignature(req, apiKey, secretKey, time.Unix(ts, 0), tt.algorithm, false)
+
+			require.Equal(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestCanonicalRequestString(t *testing.T) {
+	apiKey := "10840b0f938942feafb7186de74b9682"
+	ts := time.Unix(1519829567, 0)
+
+	tests := map[string]struct {
+		method    string
+		url       string
+		body      string
+		signQuery bool
+		expected  string
+	}{
+		"empty body": {
+			method:   http.MethodGet,
+			url:      "http://localhost/v2/domains",
+			expected: "GET;/v2/domains/;10840b0f938942feafb7186de74b9682;1519829567;",
+		},
+		"body": {
+			method:   http.MethodPost,
+			url:      "http://localhost/v2/domains/",
+			body:     `{"name":"example.com"}`,
+			expected: `POST;/v2/domains/;10840b0f938942feafb7186de74b9682;1519829567;{"name":"example.com"}`,
+		},
+		"query string": {
+			method:   http.MethodGet,
+			url:      "http://localhost/v2/domains/aaaa1111/findings/?order_by=severity&order=desc",
+			expected: "GET;/v2/domains/aaaa1111/findings/;10840b0f938942feafb7186de74b9682;1519829567;",
+		},
+		"signed query string": {
+			method:    http.MethodGet,
+			url:       "http://localhost/v2/domains/aaaa1111/findings?order_by=severity&order=desc&a=b%20c",
+			signQuery: true,
+			expected:  "GET;/v2/domains/aaaa1111/findings/?a=b+c&order=desc&order_by=severity;10840b0f938942feafb7186de74b9682;1519829567;",
+		},
+		"signed without query string": {
+			method:    http.MethodGet,
+			url:       "http://localhost/v2/domains/",
+			signQuery: true,
+			expected:  "GET;/v2/domains/;10840b0f938942feafb7186de74b9682;1519829567;",
+		},
+		"special characters in path": {
+			method:   http.MethodDelete,
+			url:      "http://localhost/v2/domains/a%20b%2Fc",
+			expected: "DELETE;/v2/domains/a%20b%2Fc/;10840b0f938942feafb7186de74b9682;1519829567;",
+		},
+		"unescaped characters in path": {
+			method:   http.MethodGet,
+			url:      "http://localhost/v2/domains/a b",
+			expected: "GET;/v2/domains/a%20b/;10840b0f938942feafb7186de74b9682;1519829567;",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var body io.Reader
+			if test.body != "" {
+				body = strings.NewReader(test.body)
+			}
+
+			req, err := http.NewRequest(test.method, test.url, body)
+			require.NoError(t, err)
+
+			require.Equal(t, test.expected, provider.CanonicalRequestString(req, apiKey, ts, test.signQuery))
+
+			// The body is read from a copy, and is still there to be sent.
+			if body != nil {
+				b, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				require.Equal(t, test.body, string(b))
+			}
+		})
+	}
+}
+
+// configureProvider configures the provider with the given attributes, with unset attributes as null.
+func configureProvider(t *testing.T, values map[string]tftypes.Value) (*provider.DetectifyProviderData, diag.Diagnostics) {
+	t.Helper()
+
+	return configureProviderWith(context.Background(), t, provider.New("test")(), values)
+}
+
+// testProviderDefaults are the values of the attributes that configureProvider and configureProviderWith
+// set unless given. The test servers use plain HTTP, and most of them do not serve the credentials check.
+var testProviderDefaults = map[string]tftypes.Value{
+	"allow_insecure_url":   tftypes.NewValue(tftypes.Bool, true),
+	"validate_credentials": tftypes.NewValue(tftypes.Bool, false),
+}
+
+// configureProviderWith configures p with the values using ctx, like configureProvider.
+func configureProviderWith(ctx context.Context, t *testing.T, p fwprovider.Provider, values map[string]tftypes.Value) (*provider.DetectifyProviderData, diag.Diagnostics) {
+	t.Helper()
+
+	values = maps.Clone(values)
+	if values == nil {
+		values = map[string]tftypes.Value{}
+	}
+	for name, value := range testProviderDefaults {
+		if _, ok := values[name]; !ok {
+			values[name] = value
+		}
+	}
+
+	var schemaResp fwprovider.SchemaResponse
+	p.Schema(ctx, fwprovider.SchemaRequest{}, &schemaResp)
+
+	resp := &fwprovider.ConfigureResponse{}
+	p.Configure(ctx, fwprovider.ConfigureRequest{
+		Config: tfsdk.Config{
+			Schema: schemaResp.Schema,
+			Raw:    schemaValue(t, schemaResp.Schema, values),
+		},
+	}, resp)
+
+	if resp.Diagnostics.HasError() {
+		return nil, resp.Diagnostics
+	}
+
+	providerData, ok := resp.ResourceData.(*provider.DetectifyProviderData)
+	require.True(t, ok)
+
+	return providerData, resp.Diagnostics
+}
+
+// recordingServer returns the URL of a server, and a function returning the requests it has received.
+func recordingServer(t *testing.T) (string, func() []*http.Request) {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requests []*http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r)
+		mu.Unlock()
+		if r.URL.Path == "/v2/account/" {
+			w.Write([]byte(`{}`))
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL, func() []*http.Request {
+		mu.Lock()
+		defer mu.Unlock()
+		return requests
+	}
+}
+
+func TestProviderEnvironment(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	t.Run("base_url from environment", func(t *testing.T) {
+		url, requests := recordingServer(t)
+		t.Setenv("DETECTIFY_BASE_URL", url)
+
+		providerData, diags := configureProvider(t, nil)
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+		_, err := providerData.Client.ListAssets(context.Background())
+		require.NoError(t, err)
+		require.Len(t, requests(), 1)
+	})
+
+	t.Run("base_url from configuration overrides environment", func(t *testing.T) {
+		envURL, envRequests := recordingServer(t)
+		configURL, configRequests := recordingServer(t)
+		t.Setenv("DETECTIFY_BASE_URL", envURL)
+
+		providerData, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url": tftypes.NewValue(tftypes.String, configURL),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+		_, err := providerData.Client.ListAssets(context.Background())
+		require.NoError(t, err)
+		require.Len(t, configRequests(), 1)
+		require.Empty(t, envRequests())
+	})
+
+	t.Run("team_token from environment", func(t *testing.T) {
+		t.Setenv("DETECTIFY_TEAM_TOKEN", "env-team")
+
+		providerData, diags := configureProvider(t, nil)
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		require.Equal(t, "env-team", providerData.TeamToken)
+	})
+
+	t.Run("team_token from configuration overrides environment", func(t *testing.T) {
+		t.Setenv("DETECTIFY_TEAM_TOKEN", "env-team")
+
+		providerData, diags := configureProvider(t, map[string]tftypes.Value{
+			"team_token": tftypes.NewValue(tftypes.String, "config-team"),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		require.Equal(t, "config-team", providerData.TeamToken)
+	})
+}
+
+func TestProviderAPIKeyFile(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "")
+
+	keyFile := filepath.Join(t.TempDir(), "api-key")
+	require.NoError(t, os.WriteFile(keyFile, []byte("  10840b0f938942feafb7186de74b9682\n"), 0o600))
+
+	t.Run("read from file", func(t *testing.T) {
+		url, requests := recordingServer(t)
+
+		providerData, diags := configureProvider(t, map[string]tftypes.Value{
+			"api_key_file": tftypes.NewValue(tftypes.String, keyFile),
+			"base_url":     tftypes.NewValue(tftypes.String, url),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+		_, err := providerData.Client.ListAssets(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, requests(), 1)
+		require.Equal(t, "10840b0f938942feafb7186de74b9682", requests()[0].Header.Get("X-Detectify-Key"))
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"api_key_file": tftypes.NewValue(tftypes.String, filepath.Join(t.TempDir(), "missing")),
+		})
+		require.True(t, diags.HasError())
+		require.Equal(t, "Unable to read Detectify API key file", diags.Errors()[0].Summary())
+	})
+
+	t.Run("conflicts with api_key", func(t *testing.T) {
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"api_key":      tftypes.NewValue(tftypes.String, "10840b0f938942feafb7186de74b9682"),
+			"api_key_file": tftypes.NewValue(tftypes.String, keyFile),
+		})
+		require.True(t, diags.HasError())
+		require.Equal(t, "Conflicting Detectify API key", diags.Errors()[0].Summary())
+	})
+
+	t.Run("conflicts with environment", func(t *testing.T) {
+		t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"api_key_file": tftypes.NewValue(tftypes.String, keyFile),
+		})
+		require.True(t, diags.HasError())
+		require.Equal(t, "Conflicting Detectify API key", diags.Errors()[0].Summary())
+	})
+}
+
+func TestProviderDisableSignature(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+	t.Setenv("DETECTIFY_SECRET", "c2VjcmV0")
+
+	tests := map[string]struct {
+		disableSignature tftypes.Value
+		expectSigned     bool
+	}{
+		"default": {
+			disableSignature: tftypes.NewValue(tftypes.Bool, nil),
+			expectSigned:     true,
+		},
+		"enabled": {
+			disableSignature: tftypes.NewValue(tftypes.Bool, false),
+			expectSigned:     true,
+		},
+		"disabled": {
+			disableSignature: tftypes.NewValue(tftypes.Bool, true),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			url, requests := recordingServer(t)
+
+			providerData, diags := configureProvider(t, map[string]tftypes.Value{
+				"base_url":          tftypes.NewValue(tftypes.String, url),
+				"disable_signature": test.disableSignature,
+			})
+			require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+			_, err := providerData.Client.ListAssets(context.Background())
+			require.NoError(t, err)
+
+			require.Len(t, requests(), 1)
+			r := requests()[0]
+			require.Equal(t, "10840b0f938942feafb7186de74b9682", r.Header.Get("X-Detectify-Key"))
+
+			if test.expectSigned {
+				require.NotEmpty(t, r.Header.Get("X-Detectify-Signature"))
+				require.NotEmpty(t, r.Header.Get("X-Detectify-Timestamp"))
+			} else {
+				require.Empty(t, r.Header.Values("X-Detectify-Signature"))
+				require.Empty(t, r.Header.Values("X-Detectify-Timestamp"))
+			}
+		})
+	}
+}
+
+func TestProviderAuthMode(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+	t.Setenv("DETECTIFY_SECRET", "c2VjcmV0")
+
+	tests := map[string]struct {
+		authMode     string
+		expectSigned bool
+	}{
+		"api_key": {
+			authMode: "api_key",
+		},
+		"hmac": {
+			authMode:     "hmac",
+			expectSigned: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			url, requests := recordingServer(t)
+
+			providerData, diags := configureProvider(t, map[string]tftypes.Value{
+				"base_url":  tftypes.NewValue(tftypes.String, url),
+				"auth_mode": tftypes.NewValue(tftypes.String, test.authMode),
+			})
+			require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+			_, err := providerData.Client.ListAssets(context.Background())
+			require.NoError(t, err)
+
+			require.Len(t, requests(), 1)
+			r := requests()[0]
+			require.Equal(t, "10840b0f938942feafb7186de74b9682", r.Header.Get("X-Detectify-Key"))
+
+			if test.expectSigned {
+				require.NotEmpty(t, r.Header.Get("X-Detectify-Signature"))
+				require.NotEmpty(t, r.Header.Get("X-Detectify-Timestamp"))
+			} else {
+				require.Empty(t, r.Header.Values("X-Detectify-Signature"))
+				require.Empty(t, r.Header.Values("X-Detectify-Timestamp"))
+			}
+		})
+	}
+
+	t.Run("hmac without secret", func(t *testing.T) {
+		t.Setenv("DETECTIFY_SECRET", "")
+
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"auth_mode": tftypes.NewValue(tftypes.String, "hmac"),
+		})
+		require.True(t, diags.HasError())
+		require.Equal(t, "Missing Detectify secret", diags.Errors()[0].Summary())
+	})
+
+	t.Run("hmac with signature disabled", func(t *testing.T) {
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"auth_mode":         tftypes.NewValue(tftypes.String, "hmac"),
+			"disable_signature": tftypes.NewValue(tftypes.Bool, true),
+		})
+		require.True(t, diags.HasError())
+		require.Equal(t, "Conflicting Detectify authentication mode", diags.Errors()[0].Summary())
+	})
+
+	t.Run("api_key without secret", func(t *testing.T) {
+		t.Setenv("DETECTIFY_SECRET", "")
+
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"auth_mode": tftypes.NewValue(tftypes.String, "api_key"),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+	})
+
+	t.Run("api_key without API key", func(t *testing.T) {
+		t.Setenv("DETECTIFY_API_KEY", "")
+
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"auth_mode": tftypes.NewValue(tftypes.String, "api_key"),
+		})
+		require.True(t, diags.HasError())
+		require.Equal(t, "Missing Detectify API key", diags.Errors()[0].Summary())
+	})
+}
+
+func TestProviderSignatureAlgorithm(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+	t.Setenv("DETECTIFY_SECRET", "c2VjcmV0")
+
+	tests := map[string]struct {
+		signatureAlgorithm tftypes.Value
+		expected           provider.SignatureAlgorithm
+	}{
+		"default": {
+			signatureAlgorithm: tftypes.NewValue(tftypes.String, nil),
+			expected:           provider.SignatureAlgorithmSHA256,
+		},
+		"sha256": {
+			signatureAlgorithm: tftypes.NewValue(tftypes.String, "sha256"),
+			expected:           provider.SignatureAlgorithmSHA256,
+		},
+		"sha512": {
+			signatureAlgorithm: tftypes.NewValue(tftypes.String, "sha512"),
+			expected:           provider.SignatureAlgorithmSHA512,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			url, requests := recordingServer(t)
+
+			providerData, diags := configureProvider(t, map[string]tftypes.Value{
+				"base_url":            tftypes.NewValue(tftypes.String, url),
+				"signature_algorithm": test.signatureAlgorithm,
+			})
+			require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+			_, err := providerData.Client.ListAssets(context.Background())
+			require.NoError(t, err)
+
+			require.Len(t, requests(), 1)
+			r := requests()[0]
+
+			ts, err := strconv.ParseInt(r.Header.Get("X-Detectify-Timestamp"), 10, 64)
+			require.NoError(t, err)
+
+			expected := provider.CalculateSignature(r, "10840b0f938942feafb7186de74b9682", "c2VjcmV0", time.Unix(ts, 0), test.expected, false)
+			require.Equal(t, expected, r.Header.Get("X-Detectify-Signature"))
+		})
+	}
+}
+
+func TestProviderSignQueryString(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+	t.Setenv("DETECTIFY_SECRET", "c2VjcmV0")
+
+	for _, signQuery := range []bool{false, true} {
+		t.Run(strconv.FormatBool(signQuery), func(t *testing.T) {
+			url, requests := recordingServer(t)
+
+			providerData, diags := configureProvider(t, map[string]tftypes.Value{
+				"base_url":          tftypes.NewValue(tftypes.String, url),
+				"sign_query_string": tftypes.NewValue(tftypes.Bool, signQuery),
+			})
+			require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+			_, err := providerData.Client.ListFindings(context.Background(), "aaaa1111", provider.FindingsQuery{OrderBy: "severity", Order: "desc"})
+			require.NoError(t, err)
+
+			require.Len(t, requests(), 1)
+			r := requests()[0]
+			require.NotEmpty(t, r.URL.RawQuery)
+
+			ts, err := strconv.ParseInt(r.Header.Get("X-Detectify-Timestamp"), 10, 64)
+			require.NoError(t, err)
+
+			expected := provider.CalculateSignature(r, "10840b0f938942feafb7186de74b9682", "c2VjcmV0", time.Unix(ts, 0), provider.SignatureAlgorithmSHA256, signQuery)
+			require.Equal(t, expected, r.Header.Get("X-Detectify-Signature"))
+
+			other := provider.CalculateSignature(r, "10840b0f938942feafb7186de74b9682", "c2VjcmV0", time.Unix(ts, 0), provider.SignatureAlgorithmSHA256, !signQuery)
+			require.NotEqual(t, other, r.Header.Get("X-Detectify-Signature"))
+		})
+	}
+}
+
+func TestProviderSignedRetries(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+	t.Setenv("DETECTIFY_SECRET", "c2VjcmV0")
+
+	var mu sync.Mutex
+	var requests []*http.Request
+	var bodies [][]byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r)
+		bodies = append(bodies, body)
+
+		if len(requests) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Write([]byte(`{"token": "aaaa1111", "name": "example.com"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	providerData, diags := configureProvider(t, map[string]tftypes.Value{
+		"base_url": tftypes.NewValue(tftypes.String, server.URL),
+	})
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+	providerData.Client.SetRetryPolicy(2, time.Millisecond)
+
+	ctx := provider.WithIdempotencyKey(context.Background(), "create-example.com")
+	_, err := providerData.Client.CreateAsset(ctx, provider.AssetRequest{Name: "example.com"})
+	require.NoError(t, err)
+
+	require.Len(t, requests, 3)
+
+	timestamps := map[string]bool{}
+	signatures := map[string]bool{}
+	for i, r := range requests {
+		require.Equal(t, "create-example.com", r.Header.Get("Idempotency-Key"))
+
+		ts, err := strconv.ParseInt(r.Header.Get("X-Detectify-Timestamp"), 10, 64)
+		require.NoError(t, err)
+
+		signed, err := http.NewRequest(r.Method, r.URL.String(), bytes.NewReader(bodies[i]))
+		require.NoError(t, err)
+
+		expected := provider.CalculateSignature(signed, "10840b0f938942feafb7186de74b9682", "c2VjcmV0", time.Unix(ts, 0), provider.SignatureAlgorithmSHA256, false)
+		require.Equal(t, expected, r.Header.Get("X-Detectify-Signature"))
+
+		timestamps[r.Header.Get("X-Detectify-Timestamp")] = true
+		signatures[r.Header.Get("X-Detectify-Signature")] = true
+	}
+
+	// Each attempt is signed anew, never replaying the signature of the attempt before it.
+	require.Len(t, timestamps, 3)
+	require.Len(t, signatures, 3)
+}
+
+func TestProviderRedirect(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+	t.Setenv("DETECTIFY_SECRET", "c2VjcmV0")
+
+	var mu sync.Mutex
+	var requests []*http.Request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r)
+		mu.Unlock()
+
+		switch r.URL.Path {
+		case "/v2/domains/old/":
+			http.Redirect(w, r, "/v2/domains/aaaa1111/", http.StatusMovedPermanently)
+		case "/v2/domains/away/":
+			http.Redirect(w, r, "https://elsewhere.example.com/v2/domains/aaaa1111/", http.StatusFound)
+		case "/v2/domains/aaaa1111/":
+			w.Write([]byte(`{"token": "aaaa1111", "name": "example.com"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	providerData, diags := configureProvider(t, map[string]tftypes.Value{
+		"base_url": tftypes.NewValue(tftypes.String, server.URL),
+	})
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+	t.Run("followed", func(t *testing.T) {
+		requests = nil
+
+		asset, err := providerData.Client.GetAsset(context.Background(), "old")
+		require.NoError(t, err)
+		require.Equal(t, "aaaa1111", asset.Token)
+
+		// The followed request is signed for its own path, not with the signature of the original.
+		require.Len(t, requests, 2)
+		redirected := requests[1]
+		require.Equal(t, "/v2/domains/aaaa1111/", redirected.URL.Path)
+
+		ts, err := strconv.ParseInt(redirected.Header.Get("X-Detectify-Timestamp"), 10, 64)
+		require.NoError(t, err)
+
+		signed, err := http.NewRequest(redirected.Method, server.URL+redirected.URL.String(), nil)
+		require.NoError(t, err)
+
+		expected := provider.CalculateSignature(signed, "10840b0f938942feafb7186de74b9682", "c2VjcmV0", time.Unix(ts, 0), provider.SignatureAlgorithmSHA256, false)
+		require.Equal(t, expected, redirected.Header.Get("X-Detectify-Signature"))
+		require.NotEqual(t, requests[0].Header.Get("X-Detectify-Signature"), redirected.Header.Get("X-Detectify-Signature"))
+	})
+
+	t.Run("outside base URL", func(t *testing.T) {
+		requests = nil
+
+		// The redirect is refused without retrying, so the credentials are never sent to the other host.
+		_, err := providerData.Client.GetAsset(context.Background(), "away")
+		require.ErrorIs(t, err, provider.ErrRedirectRefused)
+		require.ErrorContains(t, err, "https://elsewhere.example.com/v2/domains/aaaa1111/")
+		require.Len(t, requests, 1)
+	})
+}
+
+func TestProviderDeprecationWarning(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "@1735689600")
+		w.Header().Set("Sunset", "Wed, 31 Dec 2025 23:59:59 GMT")
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	providerData, diags := configureProvider(t, map[string]tftypes.Value{
+		"base_url": tftypes.NewValue(tftypes.String, server.URL),
+	})
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	for i := 0; i < 3; i++ {
+		_, err := providerData.Client.ListAssets(ctx)
+		require.NoError(t, err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	require.NoError(t, err)
+
+	var warnings []map[string]any
+	for _, entry := range entries {
+		if entry["@level"] == "warn" {
+			warnings = append(warnings, entry)
+		}
+	}
+
+	require.Len(t, warnings, 1)
+	require.Equal(t, "@1735689600", warnings[0]["deprecation"])
+	require.Equal(t, "Wed, 31 Dec 2025 23:59:59 GMT", warnings[0]["sunset"])
+	require.Equal(t, "/v2/domains/", warnings[0]["path"])
+}
+
+// extraHeaders returns the value of the extra_headers attribute with the headers.
+func extraHeaders(headers map[string]string) tftypes.Value {
+	values := map[string]tftypes.Value{}
+	for name, value := range headers {
+		values[name] = tftypes.NewValue(tftypes.String, value)
+	}
+
+	return tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, values)
+}
+
+func TestProviderExtraHeaders(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+	t.Setenv("DETECTIFY_SECRET", "c2VjcmV0")
+
+	t.Run("sent", func(t *testing.T) {
+		url, requests := recordingServer(t)
+
+		providerData, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url": tftypes.NewValue(tftypes.String, url),
+			"extra_headers": extraHeaders(map[string]string{
+				"x-gateway-token": "gateway",
+				"X-Feature-Flags": "beta",
+			}),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		require.Equal(t, map[string]string{"X-Gateway-Token": "gateway", "X-Feature-Flags": "beta"}, providerData.ExtraHeaders)
+
+		_, err := providerData.Client.ListAssets(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, requests(), 1)
+		r := requests()[0]
+		require.Equal(t, "gateway", r.Header.Get("X-Gateway-Token"))
+		require.Equal(t, "beta", r.Header.Get("X-Feature-Flags"))
+		require.Equal(t, "10840b0f938942feafb7186de74b9682", r.Header.Get("X-Detectify-Key"))
+	})
+
+	t.Run("sensitive", func(t *testing.T) {
+		var resp fwprovider.SchemaResponse
+		provider.New("test")().Schema(context.Background(), fwprovider.SchemaRequest{}, &resp)
+		require.True(t, resp.Schema.Attributes["extra_headers"].IsSensitive())
+	})
+
+	for _, name := range []string{"X-Detectify-Key", "x-detectify-signature", "X-Detectify-Timestamp", "X-Correlation-ID"} {
+		t.Run("reserved "+name, func(t *testing.T) {
+			_, diags := configureProvider(t, map[string]tftypes.Value{
+				"extra_headers": extraHeaders(map[string]string{name: "override"}),
+			})
+			require.True(t, diags.HasError())
+			require.Equal(t, "Reserved header", diags.Errors()[0].Summary())
+		})
+	}
+}
+
+func TestProviderMaxConcurrentRequests(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	const limit = 2
+
+	var mu sync.Mutex
+	inFlight, maxInFlight, total := 0, 0, 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		total++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+
+		time.Sleep(20 * time.Millisecond)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	providerData, diags := configureProvider(t, map[string]tftypes.Value{
+		"base_url":                tftypes.NewValue(tftypes.String, server.URL),
+		"max_concurrent_requests": tftypes.NewValue(tftypes.Number, limit),
+	})
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := providerData.Client.ListAssets(context.Background())
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 10, total)
+	require.LessOrEqual(t, maxInFlight, limit)
+}
+
+func TestProviderCircuitBreaker(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	var failing atomic.Bool
+	var requests atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	providerData, diags := configureProvider(t, map[string]tftypes.Value{
+		"base_url":                  tftypes.NewValue(tftypes.String, server.URL),
+		"circuit_breaker_threshold": tftypes.NewValue(tftypes.Number, 2),
+		"circuit_breaker_cooldown":  tftypes.NewValue(tftypes.String, "200ms"),
+	})
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+	providerData.Client.SetRetryPolicy(3, time.Millisecond)
+
+	// The breaker trips on the second failed attempt, which stops the retries.
+	failing.Store(true)
+	_, err := providerData.Client.ListAssets(context.Background())
+	require.ErrorIs(t, err, provider.ErrCircuitOpen)
+	require.ErrorContains(t, err, "2 consecutive requests to the Detectify API failed")
+	require.EqualValues(t, 2, requests.Load())
+
+	// Requests fail immediately during the cooldown, even though the API is back.
+	failing.Store(false)
+	_, err = providerData.Client.ListAssets(context.Background())
+	require.ErrorIs(t, err, provider.ErrCircuitOpen)
+	require.EqualValues(t, 2, requests.Load())
+
+	// After the cooldown, a successful request resets the breaker.
+	time.Sleep(250 * time.Millisecond)
+	_, err = providerData.Client.ListAssets(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 3, requests.Load())
+
+	// The failures are counted anew, so the breaker trips on the second of them again.
+	failing.Store(true)
+	_, err = providerData.Client.ListAssets(context.Background())
+	require.ErrorIs(t, err, provider.ErrCircuitOpen)
+	require.EqualValues(t, 5, requests.Load())
+
+	// A failure after the cooldown trips the breaker again right away.
+	time.Sleep(250 * time.Millisecond)
+	_, err = providerData.Client.ListAssets(context.Background())
+	require.ErrorIs(t, err, provider.ErrCircuitOpen)
+	require.EqualValues(t, 6, requests.Load())
+}
+
+func TestProviderCircuitBreakerProbe(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	var failing atomic.Bool
+	var requests atomic.Int64
+	probing := make(chan struct{})
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The probe after the cooldown is held until released.
+		if requests.Add(1) == 3 {
+			close(probing)
+			<-release
+		}
+
+		if failing.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	providerData, diags := configureProvider(t, map[string]tftypes.Value{
+		"base_url":                  tftypes.NewValue(tftypes.String, server.URL),
+		"circuit_breaker_threshold": tftypes.NewValue(tftypes.Number, 2),
+		"circuit_breaker_cooldown":  tftypes.NewValue(tftypes.String, "200ms"),
+	})
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+	providerData.Client.SetRetryPolicy(2, time.Millisecond)
+
+	failing.Store(true)
+	_, err := providerData.Client.ListAssets(context.Background())
+	require.ErrorIs(t, err, provider.ErrCircuitOpen)
+	require.EqualValues(t, 2, requests.Load())
+
+	// After the cooldown, only one of the concurrent requests is sent to probe the API.
+	time.Sleep(250 * time.Millisecond)
+	probe := make(chan error, 1)
+	go func() {
+		_, err := providerData.Client.ListAssets(context.Background())
+		probe <- err
+	}()
+	<-probing
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = providerData.Client.ListAssets(context.Background())
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		require.ErrorIs(t, err, provider.ErrCircuitOpen)
+		require.ErrorContains(t, err, "waiting for a request probing whether it recovered")
+	}
+	require.EqualValues(t, 3, requests.Load())
+
+	// The probe fails, so the breaker trips again for the cooldown.
+	close(release)
+	require.ErrorIs(t, <-probe, provider.ErrCircuitOpen)
+	_, err = providerData.Client.ListAssets(context.Background())
+	require.ErrorIs(t, err, provider.ErrCircuitOpen)
+	require.ErrorContains(t, err, "not sending requests for another")
+	require.EqualValues(t, 3, requests.Load())
+
+	// The next probe succeeds, and requests are sent as usual.
+	failing.Store(false)
+	time.Sleep(250 * time.Millisecond)
+	_, err = providerData.Client.ListAssets(context.Background())
+	require.NoError(t, err)
+	_, err = providerData.Client.ListAssets(context.Background())
+	require.NoError(t, err)
+	require.EqualValues(t, 5, requests.Load())
+}
+
+func TestProviderValidateCredentials(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	t.Run("accepted", func(t *testing.T) {
+		url, requests := recordingServer(t)
+
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url":             tftypes.NewValue(tftypes.String, url),
+			"validate_credentials": tftypes.NewValue(tftypes.Bool, true),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		require.Len(t, requests(), 1)
+		require.Equal(t, "/v2/account/", requests()[0].URL.Path)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "Unauthorized"}`))
+		}))
+		t.Cleanup(server.Close)
+
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url":             tftypes.NewValue(tftypes.String, server.URL),
+			"validate_credentials": tftypes.NewValue(tftypes.Bool, true),
+		})
+		require.True(t, diags.HasError())
+		require.Equal(t, "Unable to validate Detectify credentials", diags.Errors()[0].Summary())
+		require.Contains(t, diags.Errors()[0].Detail(), "rejected the credentials with status 401 Unauthorized")
+		require.Contains(t, diags.Errors()[0].Detail(), "unexpected status code 401: Unauthorized")
+	})
+
+	t.Run("forbidden", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error": "Forbidden"}`))
+		}))
+		t.Cleanup(server.Close)
+
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url":             tftypes.NewValue(tftypes.String, server.URL),
+			"validate_credentials": tftypes.NewValue(tftypes.Bool, true),
+		})
+		require.True(t, diags.HasError())
+		require.Equal(t, "Unable to validate Detectify credentials", diags.Errors()[0].Summary())
+		require.Contains(t, diags.Errors()[0].Detail(), "denied access with status 403 Forbidden")
+	})
+
+	t.Run("forbidden signed", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error": "Forbidden"}`))
+		}))
+		t.Cleanup(server.Close)
+
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url":             tftypes.NewValue(tftypes.String, server.URL),
+			"secret":               tftypes.NewValue(tftypes.String, "c2VjcmV0"),
+			"validate_credentials": tftypes.NewValue(tftypes.Bool, true),
+		})
+		require.True(t, diags.HasError())
+		require.Contains(t, diags.Errors()[0].Detail(), "denied access with status 403 Forbidden")
+		require.Contains(t, diags.Errors()[0].Detail(), `auth_mode "hmac"`)
+		require.Contains(t, diags.Errors()[0].Detail(), "signature is wrong")
+		require.Contains(t, diags.Errors()[0].Detail(), "unexpected status code 403: Forbidden")
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		release := make(chan struct{})
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-r.Context().Done():
+			case <-release:
+			}
+		}))
+		t.Cleanup(server.Close)
+		t.Cleanup(func() { close(release) })
+
+		start := time.Now()
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url":             tftypes.NewValue(tftypes.String, server.URL),
+			"request_timeout":      tftypes.NewValue(tftypes.String, "100ms"),
+			"validate_credentials": tftypes.NewValue(tftypes.Bool, true),
+		})
+		require.Less(t, time.Since(start), 5*time.Second)
+
+		require.True(t, diags.HasError())
+		require.Equal(t, "Unable to validate Detectify credentials", diags.Errors()[0].Summary())
+		require.Contains(t, diags.Errors()[0].Detail(), "did not respond within the request timeout of 100ms")
+	})
+
+	t.Run("validated by default", func(t *testing.T) {
+		url, requests := recordingServer(t)
+
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url":             tftypes.NewValue(tftypes.String, url),
+			"validate_credentials": tftypes.NewValue(tftypes.Bool, nil),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		require.Len(t, requests(), 1)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		url, requests := recordingServer(t)
+
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url":             tftypes.NewValue(tftypes.String, url),
+			"validate_credentials": tftypes.NewValue(tftypes.Bool, false),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		require.Empty(t, requests())
+	})
+
+	t.Run("invalid timeout", func(t *testing.T) {
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"request_timeout": tftypes.NewValue(tftypes.String, "soon"),
+		})
+		require.True(t, diags.HasError())
+		require.Equal(t, "Invalid request timeout", diags.Errors()[0].Summary())
+	})
+}
+
+func TestProviderCorrelationID(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	t.Run("generated", func(t *testing.T) {
+		url, requests := recordingServer(t)
+
+		providerData, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url": tftypes.NewValue(tftypes.String, url),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		require.NotEmpty(t, providerData.CorrelationID)
+
+		for i := 0; i < 2; i++ {
+			_, err := providerData.Client.ListAssets(context.Background())
+			require.NoError(t, err)
+		}
+
+		require.Len(t, requests(), 2)
+		for _, r := range requests() {
+			require.Equal(t, providerData.CorrelationID, r.Header.Get("X-Correlation-ID"))
+		}
+
+		other, diags := configureProvider(t, nil)
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		require.NotEqual(t, providerData.CorrelationID, other.CorrelationID)
+	})
+
+	t.Run("configured", func(t *testing.T) {
+		url, requests := recordingServer(t)
+
+		providerData, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url":       tftypes.NewValue(tftypes.String, url),
+			"correlation_id": tftypes.NewValue(tftypes.String, "deploy-1234"),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+		_, err := providerData.Client.ListAssets(context.Background())
+		require.NoError(t, err)
+
+		require.Len(t, requests(), 1)
+		require.Equal(t, "deploy-1234", requests()[0].Header.Get("X-Correlation-ID"))
+	})
+}
+
+// Aliased provider configurations are configured in turn, and must not share
+// clients. The acceptance test framework serves all aliases from one provider
+// server, unlike Terraform, so this is tested by configuring the provider directly.
+func TestProviderAliases(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "")
+
+	firstURL, firstRequests := recordingServer(t)
+	secondURL, secondRequests := recordingServer(t)
+
+	first, diags := configureProvider(t, map[string]tftypes.Value{
+		"api_key":    tftypes.NewValue(tftypes.String, "11111111111111111111111111111111"),
+		"base_url":   tftypes.NewValue(tftypes.String, firstURL),
+		"team_token": tftypes.NewValue(tftypes.String, "first-team"),
+	})
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+	second, diags := configureProvider(t, map[string]tftypes.Value{
+		"api_key":    tftypes.NewValue(tftypes.String, "22222222222222222222222222222222"),
+		"secret":     tftypes.NewValue(tftypes.String, "c2VjcmV0"),
+		"base_url":   tftypes.NewValue(tftypes.String, secondURL),
+		"team_token": tftypes.NewValue(tftypes.String, "second-team"),
+	})
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+	// Use the first client after configuring the second, to catch shared state.
+	for _, providerData := range []*provider.DetectifyProviderData{first, second, first} {
+		_, err := providerData.Client.ListAssets(context.Background())
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, "first-team", first.TeamToken)
+	require.Equal(t, "second-team", second.TeamToken)
+	require.NotSame(t, first.Client, second.Client)
+
+	require.Len(t, firstRequests(), 2)
+	for _, r := range firstRequests() {
+		require.Equal(t, "11111111111111111111111111111111", r.Header.Get("X-Detectify-Key"))
+		require.Empty(t, r.Header.Get("X-Detectify-Signature"))
+		require.Equal(t, first.CorrelationID, r.Header.Get("X-Correlation-ID"))
+	}
+
+	require.Len(t, secondRequests(), 1)
+	require.Equal(t, "22222222222222222222222222222222", secondRequests()[0].Header.Get("X-Detectify-Key"))
+	require.NotEmpty(t, secondRequests()[0].Header.Get("X-Detectify-Signature"))
+	require.Equal(t, second.CorrelationID, secondRequests()[0].Header.Get("X-Correlation-ID"))
+
+	// Configuring the provider must not change the default client used elsewhere in the process.
+	require.Nil(t, http.DefaultClient.Transport)
+}
+
+func TestProviderMetrics(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	var mu sync.Mutex
+	failures := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		// The first request fails with a transient error, and is retried.
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	t.Run("disabled by default", func(t *testing.T) {
+		providerData, diags := configureProvider(t, map[string]tftypes.Value{
+			"base_url": tftypes.NewValue(tftypes.String, server.URL),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		require.Empty(t, providerData.MetricsAddr)
+	})
+
+	providerData, diags := configureProvider(t, map[string]tftypes.Value{
+		"base_url":            tftypes.NewValue(tftypes.String, server.URL),
+		"metrics_listen_addr": tftypes.NewValue(tftypes.String, "127.0.0.1:0"),
+	})
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+	require.NotEmpty(t, providerData.MetricsAddr)
+	t.Cleanup(func() { provider.ShutdownMetricsServers(context.Background()) })
+
+	providerData.Client.SetRetryPolicy(3, time.Millisecond)
+	for i := 0; i < 3; i++ {
+		_, err := providerData.Client.ListAssets(context.Background())
+		require.NoError(t, err)
+	}
+
+	resp, err := http.Get("http://" + providerData.MetricsAddr + "/metrics")
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	require.Contains(t, string(body), `detectify_requests_total{method="GET",code="200"} 3`)
+	require.Contains(t, string(body), `detectify_requests_total{method="GET",code="503"} 1`)
+	require.Contains(t, string(body), `detectify_request_duration_seconds_bucket{le="+Inf"} 4`)
+	require.Contains(t, string(body), `detectify_request_duration_seconds_count 4`)
+	require.Contains(t, string(body), `detectify_retries_total 1`)
+
+	// The server is stopped on shutdown.
+	require.NoError(t, provider.ShutdownMetricsServers(context.Background()))
+	_, err = http.Get("http://" + providerData.MetricsAddr + "/metrics")
+	require.Error(t, err)
+}
+
+func TestProviderTLSMinVersion(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	for _, version := range []string{"1.2", "1.3"} {
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"tls_min_version": tftypes.NewValue(tftypes.String, version),
+		})
+		require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+	}
+
+	_, diags := configureProvider(t, map[string]tftypes.Value{
+		"tls_min_version": tftypes.NewValue(tftypes.String, "1.1"),
+	})
+	require.True(t, diags.HasError())
+	require.Equal(t, "Unsupported TLS version", diags.Errors()[0].Summary())
+}
+
+// fakeDNSServer starts a DNS server answering A queries for any name with 127.0.0.1,
+// and returns its address and a function returning the names queried.
+func fakeDNSServer(t *testing.T) (string, func() []string) {
+	t.Helper()
+
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	var mu sync.Mutex
+	var names []string
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+
+			var p dnsmessage.Parser
+			header, err := p.Start(buf[:n])
+			if err != nil {
+				continue
+			}
+			question, err := p.Question()
+			if err != nil {
+				continue
+			}
+
+			mu.Lock()
+			names = append(names, question.Name.String())
+			mu.Unlock()
+
+			b := dnsmessage.NewBuilder(nil, dnsmessage.Header{ID: header.ID, Response: true, Authoritative: true})
+			b.EnableCompression()
+			_ = b.StartQuestions()
+			_ = b.Question(question)
+			_ = b.StartAnswers()
+			if question.Type == dnsmessage.TypeA {
+				_ = b.AResource(
+					dnsmessage.ResourceHeader{Name: question.Name, Type: dnsmessage.TypeA, Class: dnsmessage.ClassINET, TTL: 60},
+					dnsmessage.AResource{A: [4]byte{127, 0, 0, 1}},
+				)
+			}
+
+			msg, err := b.Finish()
+			if err != nil {
+				continue
+			}
+			_, _ = conn.WriteTo(msg, addr)
+		}
+	}()
+
+	return conn.LocalAddr().String(), func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return slices.Clone(names)
+	}
+}
+
+func TestProviderDNSServer(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	dnsAddr, names := fakeDNSServer(t)
+
+	// The host only resolves to the server with the fake DNS server.
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	baseURL := "http://api.detectify.test:" + port
+
+	providerData, diags := configureProvider(t, map[string]tftypes.Value{
+		"base_url":   tftypes.NewValue(tftypes.String, baseURL),
+		"dns_server": tftypes.NewValue(tftypes.String, dnsAddr),
+	})
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+	_, err = providerData.Client.ListAssets(context.Background())
+	require.NoError(t, err)
+
+	require.Contains(t, names(), "api.detectify.test.")
+}
+
+func TestProviderHTTPTransport(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+	t.Setenv("DETECTIFY_SECRET", "")
+
+	var requests []*http.Request
+	rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests = append(requests, r)
+		return cannedResponse(http.StatusOK, `{"token": "aaaa1111", "name": "example.com"}`), nil
+	})
+
+	providerData, diags := configureProviderWith(context.Background(), t, provider.NewWithHTTPTransport("test", rt)(), nil)
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+	asset, err := providerData.Client.GetAsset(context.Background(), "aaaa1111")
+	require.NoError(t, err)
+	require.Equal(t, "example.com", asset.Name)
+
+	// The requests are authenticated before they reach the transport.
+	require.Len(t, requests, 1)
+	require.Equal(t, provider.DefaultBaseURL+"/v2/domains/aaaa1111/", requests[0].URL.String())
+	require.Equal(t, "10840b0f938942feafb7186de74b9682", requests[0].Header.Get("X-Detectify-Key"))
+}
+
+func TestProviderRegion(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+	t.Setenv("DETECTIFY_SECRET", "")
+	t.Setenv("DETECTIFY_BASE_URL", "")
+
+	tests := map[string]struct {
+		values  map[string]tftypes.Value
+		baseURL string
+		wantURL string
+	}{
+		"default": {
+			wantURL: "https://api.detectify.com/rest/v2/domains/",
+		},
+		"eu": {
+			values:  map[string]tftypes.Value{"region": tftypes.NewValue(tftypes.String, "eu")},
+			wantURL: "https://api.detectify.com/rest/v2/domains/",
+		},
+		"us": {
+			values:  map[string]tftypes.Value{"region": tftypes.NewValue(tftypes.String, "us")},
+			wantURL: "https://api.us.detectify.com/rest/v2/domains/",
+		},
+		"base_url overrides region": {
+			values: map[string]tftypes.Value{
+				"region":   tftypes.NewValue(tftypes.String, "us"),
+				"base_url": tftypes.NewValue(tftypes.String, "https://detectify.example.com/rest"),
+			},
+			wantURL: "https://detectify.example.com/rest/v2/domains/",
+		},
+		"environment base URL overrides region": {
+			values:  map[string]tftypes.Value{"region": tftypes.NewValue(tftypes.String, "us")},
+			baseURL: "https://detectify.example.com/rest",
+			wantURL: "https://detectify.example.com/rest/v2/domains/",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("DETECTIFY_BASE_URL", tt.baseURL)
+
+			var requests []*http.Request
+			rt := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+				requests = append(requests, r)
+				return cannedResponse(http.StatusOK, `[]`), nil
+			})
+
+			providerData, diags := configureProviderWith(context.Background(), t, provider.NewWithHTTPTransport("test", rt)(), tt.values)
+			require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+			_, err := providerData.Client.ListAssets(context.Background())
+			require.NoError(t, err)
+
+			require.Len(t, requests, 1)
+			require.Equal(t, tt.wantURL, requests[0].URL.String())
+		})
+	}
+
+	t.Run("unknown region", func(t *testing.T) {
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"region": tftypes.NewValue(tftypes.String, "ap"),
+		})
+		require.True(t, diags.HasError())
+		require.Equal(t, "Invalid Detectify region", diags.Errors()[0].Summary())
+		require.Contains(t, diags.Errors()[0].Detail(), `must be one of eu, us, got: "ap"`)
+	})
+}
+
+func TestProviderAuthModeLog(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+	t.Setenv("DETECTIFY_SECRET", "")
+
+	tests := map[string]struct {
+		values         map[string]tftypes.Value
+		expectAuthMode string
+		expectMessage  string
+	}{
+		"no secret": {
+			expectAuthMode: "api_key",
+			expectMessage:  "no secret is set",
+		},
+		"secret": {
+			values: map[string]tftypes.Value{
+				"secret": tftypes.NewValue(tftypes.String, "c2VjcmV0"),
+			},
+			expectAuthMode: "signature",
+		},
+		"signature disabled": {
+			values: map[string]tftypes.Value{
+				"secret":            tftypes.NewValue(tftypes.String, "c2VjcmV0"),
+				"disable_signature": tftypes.NewValue(tftypes.Bool, true),
+			},
+			expectAuthMode: "api_key",
+			expectMessage:  "disable_signature",
+		},
+		"api_key mode": {
+			values: map[string]tftypes.Value{
+				"secret":    tftypes.NewValue(tftypes.String, "c2VjcmV0"),
+				"auth_mode": tftypes.NewValue(tftypes.String, "api_key"),
+			},
+			expectAuthMode: "api_key",
+			expectMessage:  "auth_mode",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var output bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+
+			_, diags := configureProviderWith(ctx, t, provider.New("test")(), test.values)
+			require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+			entries, err := tflogtest.MultilineJSONDecode(&output)
+			require.NoError(t, err)
+
+			var authEntries []map[string]any
+			for _, entry := range entries {
+				if _, ok := entry["auth_mode"]; ok && entry["@level"] == "info" {
+					authEntries = append(authEntries, entry)
+				}
+			}
+
+			require.Len(t, authEntries, 1)
+			require.Equal(t, test.expectAuthMode, authEntries[0]["auth_mode"])
+			require.Contains(t, authEntries[0]["@message"], test.expectMessage)
+		})
+	}
+}
+
+func TestProviderInsecureURL(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+
+	tests := map[string]struct {
+		baseURL          tftypes.Value
+		allowInsecureURL tftypes.Value
+		expectError      bool
+	}{
+		"https": {
+			baseURL:          tftypes.NewValue(tftypes.String, "https://api.example.com"),
+			allowInsecureURL: tftypes.NewValue(tftypes.Bool, nil),
+		},
+		"http": {
+			baseURL:          tftypes.NewValue(tftypes.String, "http://api.example.com"),
+			allowInsecureURL: tftypes.NewValue(tftypes.Bool, nil),
+			expectError:      true,
+		},
+		"http disallowed": {
+			baseURL:          tftypes.NewValue(tftypes.String, "HTTP://api.example.com"),
+			allowInsecureURL: tftypes.NewValue(tftypes.Bool, false),
+			expectError:      true,
+		},
+		"http allowed": {
+			baseURL:          tftypes.NewValue(tftypes.String, "http://127.0.0.1:8080"),
+			allowInsecureURL: tftypes.NewValue(tftypes.Bool, true),
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, diags := configureProvider(t, map[string]tftypes.Value{
+				"base_url":           test.baseURL,
+				"allow_insecure_url": test.allowInsecureURL,
+			})
+
+			if test.expectError {
+				require.True(t, diags.HasError())
+				require.Equal(t, "Insecure Detectify base URL", diags.Errors()[0].Summary())
+				require.Contains(t, diags.Errors()[0].Detail(), "cleartext")
+				return
+			}
+
+			require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+		})
+	}
+
+	t.Run("http from environment", func(t *testing.T) {
+		t.Setenv("DETECTIFY_BASE_URL", "http://api.example.com")
+
+		_, diags := configureProvider(t, map[string]tftypes.Value{
+			"allow_insecure_url": tftypes.NewValue(tftypes.Bool, nil),
+		})
+		require.True(t, diags.HasError())
+		require.Equal(t, "Insecure Detectify base URL", diags.Errors()[0].Summary())
+	})
+}
+
+func TestProviderCompressRequests(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+	t.Setenv("DETECTIFY_SECRET", "c2VjcmV0")
+
+	largeNotes := strings.Repeat("notes ", provider.CompressionMinBytes)
+
+	tests := map[string]struct {
+		compressRequests tftypes.Value
+		notes            string
+		expectGzip       bool
+	}{
+		"disabled": {
+			compressRequests: tftypes.NewValue(tftypes.Bool, nil),
+			notes:            largeNotes,
+		},
+		"small body": {
+			compressRequests: tftypes.NewValue(tftypes.Bool, true),
+			notes:            "notes",
+		},
+		"large body": {
+			compressRequests: tftypes.NewValue(tftypes.Bool, true),
+			notes:            largeNotes,
+			expectGzip:       true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var received *http.Request
+			var receivedBody []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				receivedBody, _ = io.ReadAll(r.Body)
+				w.Write([]byte(`{"token": "aaaa1111", "name": "example.com"}`))
+			}))
+			t.Cleanup(server.Close)
+
+			providerData, diags := configureProvider(t, map[string]tftypes.Value{
+				"base_url":          tftypes.NewValue(tftypes.String, server.URL),
+				"compress_requests": test.compressRequests,
+			})
+			require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+			_, err := providerData.Client.CreateAsset(context.Background(), provider.AssetRequest{Name: "example.com", Notes: test.notes})
+			require.NoError(t, err)
+			require.NotNil(t, received)
+
+			body := receivedBody
+			if test.expectGzip {
+				require.Equal(t, "gzip", received.Header.Get("Content-Encoding"))
+
+				r, err := gzip.NewReader(bytes.NewReader(receivedBody))
+				require.NoError(t, err)
+				body, err = io.ReadAll(r)
+				require.NoError(t, err)
+				require.Less(t, len(receivedBody), len(body))
+			} else {
+				require.Empty(t, received.Header.Values("Content-Encoding"))
+			}
+
+			var request provider.AssetRequest
+			require.NoError(t, json.Unmarshal(body, &request))
+			require.Equal(t, test.notes, request.Notes)
+
+			// The signature is of the body as received.
+			signed, err := http.NewRequest(received.Method, received.URL.String(), bytes.NewReader(receivedBody))
+			require.NoError(t, err)
+
+			ts, err := strconv.ParseInt(received.Header.Get("X-Detectify-Timestamp"), 10, 64)
+			require.NoError(t, err)
+
+			expected := provider.CalculateSignature(signed, "10840b0f938942feafb7186de74b9682", "c2VjcmV0", time.Unix(ts, 0), provider.SignatureAlgorithmSHA256, false)
+			require.Equal(t, expected, received.Header.Get("X-Detectify-Signature"))
+		})
+	}
+}
+
+func TestProviderDebugLogging(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+	t.Setenv("DETECTIFY_SECRET", "c2VjcmV0")
+
+	tests := map[string]struct {
+		env          map[string]string
+		expectLogged bool
+		expectWarn   bool
+	}{
+		"disabled by default": {},
+		"debug variable": {
+			env:          map[string]string{"DETECTIFY_PROVIDER_DEBUG": "true"},
+			expectLogged: true,
+		},
+		"TF_LOG": {
+			env:          map[string]string{"TF_LOG": "debug"},
+			expectLogged: true,
+		},
+		"TF_LOG_PROVIDER": {
+			env:          map[string]string{"TF_LOG_PROVIDER": "TRACE"},
+			expectLogged: true,
+		},
+		"TF_LOG at info": {
+			env: map[string]string{"TF_LOG": "INFO"},
+		},
+		"turned off despite TF_LOG": {
+			env: map[string]string{"TF_LOG": "DEBUG", "DETECTIFY_PROVIDER_DEBUG": "false"},
+		},
+		"invalid debug variable": {
+			env:        map[string]string{"DETECTIFY_PROVIDER_DEBUG": "verbose"},
+			expectWarn: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for _, name := range []string{"DETECTIFY_PROVIDER_DEBUG", "TF_LOG", "TF_LOG_PROVIDER"} {
+				t.Setenv(name, test.env[name])
+			}
+
+			url, _ := recordingServer(t)
+			providerData, diags := configureProvider(t, map[string]tftypes.Value{
+				"base_url": tftypes.NewValue(tftypes.String, url),
+			})
+			require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+			require.Equal(t, test.expectWarn, diags.WarningsCount() == 1, "unexpected diagnostics: %v", diags)
+
+			var output bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &output)
+
+			_, err := providerData.Client.ListAssets(ctx)
+			require.NoError(t, err)
+
+			entries, err := tflogtest.MultilineJSONDecode(&output)
+			require.NoError(t, err)
+
+			var requestEntries []map[string]any
+			for _, entry := range entries {
+				if entry["@message"] == "Sent Detectify API request" {
+					requestEntries = append(requestEntries, entry)
+				}
+			}
+
+			if !test.expectLogged {
+				require.Empty(t, requestEntries)
+				return
+			}
+
+			require.Len(t, requestEntries, 1)
+			require.Equal(t, "debug", requestEntries[0]["@level"])
+			require.Equal(t, "GET", requestEntries[0]["method"])
+			require.Equal(t, url+"/v2/domains/", requestEntries[0]["url"])
+			require.EqualValues(t, http.StatusOK, requestEntries[0]["status"])
+
+			// The credentials are not logged.
+			require.NotContains(t, output.String(), "10840b0f938942feafb7186de74b9682")
+			headers := requestEntries[0]["request_headers"].(map[string]any)
+			require.Equal(t, []any{"***"}, headers["X-Detectify-Key"])
+			require.Equal(t, []any{"***"}, headers["X-Detectify-Signature"])
+			require.NotEmpty(t, headers["X-Detectify-Timestamp"])
+		})
+	}
+}
+
+func TestProviderDebugLoggingSensitiveHeaders(t *testing.T) {
+	t.Setenv("DETECTIFY_API_KEY", "10840b0f938942feafb7186de74b9682")
+	t.Setenv("DETECTIFY_PROVIDER_DEBUG", "true")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Set-Cookie", "session=response-cookie")
+		w.Header().Add("Set-Cookie", "tracking=response-tracking")
+		w.Header().Set("X-Gateway-Token", "response-gateway")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+
+	providerData, diags := configureProvider(t, map[string]tftypes.Value{
+		"base_url": tftypes.NewValue(tftypes.String, server.URL),
+		"extra_headers": extraHeaders(map[string]string{
+			"x-gateway-token":     "request-gateway",
+			"Authorization":       "Bearer request-authorization",
+			"Proxy-Authorization": "Basic request-proxy",
+			"Cookie":              "session=request-cookie",
+			"X-Feature-Flags":     "beta",
+		}),
+	})
+	require.False(t, diags.HasError(), "unexpected diagnostics: %v", diags)
+
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	_, err := providerData.Client.ListAssets(ctx)
+	require.NoError(t, err)
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
 	require.NoError(t, err)
 
-	expected := "6jpu6S4cQwEY4uLk+xELSe1RhajVJP0QEDpGWZ5T+U0="
-	actual := provider.CalculateSignature(req, apiKey, secretKey, time.Unix(ts, 0))
+	var requestEntries []map[string]any
+	for _, entry := range entries {
+		if entry["@message"] == "Sent Detectify API request" {
+			requestEntries = append(requestEntries, entry)
+		}
+	}
+	require.Len(t, requestEntries, 1)
+
+	for _, value := range []string{"request-gateway", "request-authorization", "request-proxy", "request-cookie", "response-cookie", "response-tracking", "response-gateway"} {
+		require.NotContains(t, output.String(), value)
+	}
+
+	requestHeaders := requestEntries[0]["request_headers"].(map[string]any)
+	for _, name := range []string{"X-Gateway-Token", "Authorization", "Proxy-Authorization", "Cookie", "X-Feature-Flags"} {
+		require.Equal(t, []any{"***"}, requestHeaders[name], "request header %s", name)
+	}
 
-	require.Equal(t, expected, actual)
+	responseHeaders := requestEntries[0]["response_headers"].(map[string]any)
+	require.Equal(t, []any{"***"}, responseHeaders["Set-Cookie"])
+	require.Equal(t, []any{"***"}, responseHeaders["X-Gateway-Token"])
+	require.Equal(t, []any{"application/json"}, responseHeaders["Content-Type"])
 }
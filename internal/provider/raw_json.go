@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// includeRawAttribute is the schema of the include_raw attribute of data sources.
+func includeRawAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "Whether to set `raw_json` to the unparsed API response. Defaults to `false`, to keep it out of the state.",
+		Optional:            true,
+	}
+}
+
+// rawJSONAttribute is the schema of the raw_json attribute of data sources. It is sensitive, as the response
+// may hold fields the provider keeps out of plans, such as secrets.
+func rawJSONAttribute() schema.StringAttribute {
+	return schema.StringAttribute{
+		MarkdownDescription: "The unparsed API response, for fields the provider does not expose, such as with `jsondecode`. " +
+			"Null unless `include_raw` is `true`. Sensitive, as the response may hold secret fields.",
+		Computed:  true,
+		Sensitive: true,
+	}
+}
+
+// exposeHeadersAttribute is the schema of the expose_headers attribute of data sources.
+func exposeHeadersAttribute() schema.ListAttribute {
+	return schema.ListAttribute{
+		MarkdownDescription: "Names of API response headers to set in `response_headers`, such as `X-RateLimit-Remaining` " +
+			"to throttle a pipeline or `X-Request-Id` to report an issue.",
+		ElementType: types.StringType,
+		Optional:    true,
+		Validators: []validator.List{
+			listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+		},
+	}
+}
+
+// responseHeadersAttribute is the schema of the response_headers attribute of data sources.
+func responseHeadersAttribute() schema.MapAttribute {
+	return schema.MapAttribute{
+		MarkdownDescription: "The response headers named in `expose_headers`, by the name as given, of the last API request " +
+			"made reading the data source. Headers with several values are joined by `, `, and headers missing from the " +
+			"response are left out. Null unless `expose_headers` is set.",
+		ElementType: types.StringType,
+		Computed:    true,
+	}
+}
+
+// withRawResponse returns a context recording the API response if include is true or headers are to be exposed,
+// along with the recorded response.
+func withRawResponse(ctx context.Context, include types.Bool, exposeHeaders types.List) (context.Context, *RawResponse) {
+	if !include.ValueBool() && exposeHeaders.IsNull() {
+		return ctx, nil
+	}
+
+	raw := &RawResponse{}
+	return WithRawResponse(ctx, raw), raw
+}
+
+// rawJSONValue returns the recorded response as a raw_json value, or null if it was not to be included.
+func rawJSONValue(raw *RawResponse, include types.Bool) types.String {
+	if raw == nil || !include.ValueBool() {
+		return types.StringNull()
+	}
+
+	return types.StringValue(string(raw.Body))
+}
+
+// responseHeadersValue returns the exposed headers of the recorded response as a response_headers value,
+// or null if no headers were to be exposed.
+func responseHeadersValue(ctx context.Context, raw *RawResponse, exposeHeaders types.List) (types.Map, diag.Diagnostics) {
+	if raw == nil || exposeHeaders.IsNull() {
+		return types.MapNull(types.StringType), nil
+	}
+
+	var names []string
+	diags := exposeHeaders.ElementsAs(ctx, &names, false)
+	if diags.HasError() {
+		return types.MapNull(types.StringType), diags
+	}
+
+	headers := make(map[string]string, len(names))
+	for _, name := range names {
+		if values := raw.Header.Values(name); len(values) > 0 {
+			headers[name] = strings.Join(values, ", ")
+		}
+	}
+
+	value, d := types.MapValueFrom(ctx, types.StringType, headers)
+	diags.Append(d...)
+	return value, diags
+}
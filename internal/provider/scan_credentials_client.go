@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+)
+
+// The types of scan credentials.
+const (
+	// ScanCredentialsTypeBasic credentials are a username and password.
+	ScanCredentialsTypeBasic = "basic"
+	// ScanCredentialsTypeToken credentials are a bearer token.
+	ScanCredentialsTypeToken = "token"
+)
+
+// ScanCredentials are the credentials an asset is scanned with, as represented by the Detectify API.
+// The API never returns the password or token.
+type ScanCredentials struct {
+	// Type is one of basic and token.
+	Type string `json:"type"`
+	// Username is set for basic credentials.
+	Username  string `json:"username,omitempty"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+// ScanCredentialsRequest is the request body used when setting the scan credentials of an asset.
+type ScanCredentialsRequest struct {
+	Type     string `json:"type"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Token    string `json:"token,omitempty"`
+}
+
+// scanCredentialsPath returns the path of the scan credentials of the asset identified by token.
+func scanCredentialsPath(token string) string {
+	return apiPath("domains", token, "credentials")
+}
+
+// GetScanCredentials returns the scan credentials of the asset identified by token.
+// It fails with a not found error if the asset has no scan credentials.
+func (c *Client) GetScanCredentials(ctx context.Context, token string) (*ScanCredentials, error) {
+	var credentials ScanCredentials
+	if err := c.do(ctx, http.MethodGet, scanCredentialsPath(token), nil, &credentials); err != nil {
+		return nil, err
+	}
+
+	return &credentials, nil
+}
+
+// SetScanCredentials replaces the scan credentials of the asset identified by token.
+func (c *Client) SetScanCredentials(ctx context.Context, token string, body ScanCredentialsRequest) (*ScanCredentials, error) {
+	var credentials ScanCredentials
+	if err := c.do(ctx, http.MethodPut, scanCredentialsPath(token), body, &credentials); err != nil {
+		return nil, err
+	}
+
+	return &credentials, nil
+}
+
+// DeleteScanCredentials removes the scan credentials of the asset identified by token.
+func (c *Client) DeleteScanCredentials(ctx context.Context, token string) error {
+	return c.do(ctx, http.MethodDelete, scanCredentialsPath(token), nil, nil)
+}
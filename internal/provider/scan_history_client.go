@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"context"
+)
+
+// Scan is a scan in the scan history of an asset or scan profile, as represented by the Detectify API.
+type Scan struct {
+	ID        string `json:"id"`
+	StartedAt string `json:"started_at"`
+	// FinishedAt is nil while the scan is in progress.
+	FinishedAt *string `json:"finished_at,omitempty"`
+	Status     string  `json:"status"`
+	// FindingsDelta is the change in the number of findings compared to the previous scan.
+	FindingsDelta int64 `json:"findings_delta"`
+}
+
+// ListAssetScans returns up to limit of the most recent scans of the asset identified by token,
+// or all of them if limit is zero.
+func (c *Client) ListAssetScans(ctx context.Context, token string, limit int) ([]Scan, error) {
+	return listPagesUpTo[Scan](ctx, c, apiPath("domains", token, "scans"), nil, "scans", limit)
+}
+
+// ListScanProfileScans returns up to limit of the most recent scans of the scan profile identified by token,
+// or all of them if limit is zero.
+func (c *Client) ListScanProfileScans(ctx context.Context, token string, limit int) ([]Scan, error) {
+	return listPagesUpTo[Scan](ctx, c, apiPath("profiles", token, "scans"), nil, "scans", limit)
+}
@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ datasource.DataSource                     = &ScanHistoryDataSource{}
+	_ datasource.DataSourceWithConfigValidators = &ScanHistoryDataSource{}
+)
+
+func NewScanHistoryDataSource() datasource.DataSource {
+	return &ScanHistoryDataSource{}
+}
+
+// defaultScanHistoryLimit is the number of scans returned if the limit is not set.
+const defaultScanHistoryLimit = 10
+
+// ScanHistoryDataSource defines the data source implementation.
+type ScanHistoryDataSource struct {
+	client          *Client
+	defaultPageSize int64
+}
+
+// ScanHistoryDataSourceModel describes the data source data model.
+type ScanHistoryDataSourceModel struct {
+	AssetToken   types.String `tfsdk:"asset_token"`
+	ProfileToken types.String `tfsdk:"profile_token"`
+	Limit        types.Int64  `tfsdk:"limit"`
+	Scans        types.List   `tfsdk:"scans"`
+	PageSize     types.Int64  `tfsdk:"page_size"`
+}
+
+// scanHistoryItemModel describes a scan in the scan history data source.
+type scanHistoryItemModel struct {
+	ID            types.String `tfsdk:"id"`
+	StartedAt     types.String `tfsdk:"started_at"`
+	FinishedAt    types.String `tfsdk:"finished_at"`
+	Status        types.String `tfsdk:"status"`
+	FindingsDelta types.Int64  `tfsdk:"findings_delta"`
+}
+
+// scanHistoryItemAttrTypes are the attribute types of scanHistoryItemModel.
+var scanHistoryItemAttrTypes = map[string]attr.Type{
+	"id":             types.StringType,
+	"started_at":     types.StringType,
+	"finished_at":    types.StringType,
+	"status":         types.StringType,
+	"findings_delta": types.Int64Type,
+}
+
+func (d *ScanHistoryDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scan_history"
+}
+
+func (d *ScanHistoryDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the most recent scans of an asset or a scan profile.",
+
+		Attributes: map[string]schema.Attribute{
+			"asset_token": schema.StringAttribute{
+				MarkdownDescription: "The token of the asset. Exactly one of `asset_token` and `profile_token` must be set.",
+				Optional:            true,
+			},
+			"profile_token": schema.StringAttribute{
+				MarkdownDescription: "The token of the scan profile. Exactly one of `asset_token` and `profile_token` must be set.",
+				Optional:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "The maximum number of scans to return. Defaults to `" + strconv.Itoa(defaultScanHistoryLimit) + "`.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"scans": schema.ListNestedAttribute{
+				MarkdownDescription: "The scans, most recent first.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The scan ID.",
+							Computed:            true,
+						},
+						"started_at": schema.StringAttribute{
+							MarkdownDescription: "When the scan started, in RFC 3339 format.",
+							Computed:            true,
+						},
+						"finished_at": schema.StringAttribute{
+							MarkdownDescription: "When the scan finished, in RFC 3339 format. Null while the scan is in progress.",
+							Computed:            true,
+						},
+						"status": schema.StringAttribute{
+							MarkdownDescription: "The status of the scan, such as `running` or `completed`.",
+							Computed:            true,
+						},
+						"findings_delta": schema.Int64Attribute{
+							MarkdownDescription: "The change in the number of findings compared to the previous scan.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"page_size": pageSizeAttribute(),
+		},
+	}
+}
+
+func (d *ScanHistoryDataSource) ConfigValidators(ctx context.Context) []datasource.ConfigValidator {
+	return []datasource.ConfigValidator{
+		datasourcevalidator.ExactlyOneOf(
+			path.MatchRoot("asset_token"),
+			path.MatchRoot("profile_token"),
+		),
+	}
+}
+
+func (d *ScanHistoryDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.defaultPageSize = providerData.DefaultPageSize
+}
+
+func (d *ScanHistoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ScanHistoryDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	limit := defaultScanHistoryLimit
+	if !data.Limit.IsNull() {
+		limit = int(data.Limit.ValueInt64())
+	}
+
+	clientCtx := withPageSize(ctx, data.PageSize, d.defaultPageSize)
+
+	var scans []Scan
+	var err error
+	if !data.AssetToken.IsNull() {
+		scans, err = d.client.ListAssetScans(clientCtx, data.AssetToken.ValueString(), limit)
+	} else {
+		scans, err = d.client.ListScanProfileScans(clientCtx, data.ProfileToken.ValueString(), limit)
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list scans, got error: %s", err))
+		return
+	}
+
+	items := make([]scanHistoryItemModel, len(scans))
+	for i, scan := range scans {
+		items[i] = scanHistoryItemModel{
+			ID:            types.StringValue(scan.ID),
+			StartedAt:     types.StringValue(scan.StartedAt),
+			FinishedAt:    types.StringPointerValue(scan.FinishedAt),
+			Status:        types.StringValue(scan.Status),
+			FindingsDelta: types.Int64Value(scan.FindingsDelta),
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.Scans, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: scanHistoryItemAttrTypes}, items)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Trace(ctx, "read scan history", map[string]any{"count": len(scans)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
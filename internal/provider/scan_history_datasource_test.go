@@ -0,0 +1,125 @@
+package provider_test
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+)
+
+func TestAccScanHistoryDataSource(t *testing.T) {
+	api := newFakeAPI(t)
+
+	asset := api.addAsset("example.com")
+	for i := 1; i <= 5; i++ {
+		finishedAt := fmt.Sprintf("2024-05-0%dT12:30:00Z", i)
+		api.addScan(asset, provider.Scan{
+			ID:            fmt.Sprintf("scan%d", i),
+			StartedAt:     fmt.Sprintf("2024-05-0%dT12:00:00Z", i),
+			FinishedAt:    &finishedAt,
+			Status:        "completed",
+			FindingsDelta: int64(i - 3),
+		})
+	}
+	api.addScan(asset, provider.Scan{ID: "scan6", StartedAt: "2024-05-06T12:00:00Z", Status: "running"})
+
+	// The scan profile created by the configuration gets the next token.
+	api.addScan("profile0002", provider.Scan{ID: "scan7", StartedAt: "2024-05-07T12:00:00Z", Status: "running"})
+
+	// checkScanRequests checks the number of requests for pages of scans since the previous check.
+	scanRequests := 0
+	checkScanRequests := func(want int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			api.mu.Lock()
+			defer api.mu.Unlock()
+
+			if got := api.scanRequests - scanRequests; got != want {
+				return fmt.Errorf("expected %d scan page requests, got %d", want, got)
+			}
+			scanRequests = api.scanRequests
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+data "detectify_scan_history" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Missing Attribute Configuration`),
+			},
+			{
+				Config: api.providerConfig() + `
+data "detectify_scan_history" "test" {
+  asset_token = "` + asset + `"
+  limit       = 0
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Value`),
+			},
+			{
+				// Only the pages needed for the limit are requested.
+				Config: api.providerConfig() + `
+data "detectify_scan_history" "test" {
+  asset_token = "` + asset + `"
+  limit       = 3
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.detectify_scan_history.test", "scans.#", "3"),
+					resource.TestCheckResourceAttr("data.detectify_scan_history.test", "scans.0.id", "scan6"),
+					resource.TestCheckResourceAttr("data.detectify_scan_history.test", "scans.0.status", "running"),
+					resource.TestCheckNoResourceAttr("data.detectify_scan_history.test", "scans.0.finished_at"),
+					resource.TestCheckResourceAttr("data.detectify_scan_history.test", "scans.1.id", "scan5"),
+					resource.TestCheckResourceAttr("data.detectify_scan_history.test", "scans.1.started_at", "2024-05-05T12:00:00Z"),
+					resource.TestCheckResourceAttr("data.detectify_scan_history.test", "scans.1.finished_at", "2024-05-05T12:30:00Z"),
+					resource.TestCheckResourceAttr("data.detectify_scan_history.test", "scans.1.findings_delta", "2"),
+					resource.TestCheckResourceAttr("data.detectify_scan_history.test", "scans.2.id", "scan4"),
+					checkScanRequests(2),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+data "detectify_scan_history" "test" {
+  asset_token = "` + asset + `"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.detectify_scan_history.test", "scans.#", "6"),
+					resource.TestCheckResourceAttr("data.detectify_scan_history.test", "scans.5.id", "scan1"),
+					resource.TestCheckResourceAttr("data.detectify_scan_history.test", "scans.5.findings_delta", "-2"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_scan_profile" "test" {
+  name     = "Example"
+  endpoint = "https://example.com"
+}
+
+data "detectify_scan_history" "test" {
+  profile_token = detectify_scan_profile.test.token
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.detectify_scan_history.test", "scans.#", "1"),
+					resource.TestCheckResourceAttr("data.detectify_scan_history.test", "scans.0.id", "scan7"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+data "detectify_scan_history" "test" {
+  asset_token = "token9999"
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to list scans`),
+			},
+		},
+	})
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ScanProfileAttachmentResource{}
+
+func NewScanProfileAttachmentResource() resource.Resource {
+	return &ScanProfileAttachmentResource{}
+}
+
+// ScanProfileAttachmentResource defines the resource implementation.
+type ScanProfileAttachmentResource struct {
+	client *Client
+}
+
+// ScanProfileAttachmentResourceModel describes the resource data model.
+type ScanProfileAttachmentResourceModel struct {
+	ScanProfileToken types.String `tfsdk:"scan_profile_token"`
+	AssetToken       types.String `tfsdk:"asset_token"`
+}
+
+func (r *ScanProfileAttachmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scan_profile_attachment"
+}
+
+func (r *ScanProfileAttachmentResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Attaches an asset to a scan profile. Changing either token replaces the attachment.",
+
+		Attributes: map[string]schema.Attribute{
+			"scan_profile_token": schema.StringAttribute{
+				MarkdownDescription: "The token of the scan profile.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"asset_token": schema.StringAttribute{
+				MarkdownDescription: "The token of the asset.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+func (r *ScanProfileAttachmentResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+}
+
+func (r *ScanProfileAttachmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ScanProfileAttachmentResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An asset that is already attached is the desired state, so the conflict is not an error.
+	err := r.client.AttachScanProfile(ctx, data.ScanProfileToken.ValueString(), data.AssetToken.ValueString())
+	if err != nil && !IsConflict(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to attach asset to scan profile, got error: %s", err))
+		return
+	}
+
+	tflog.Trace(ctx, "attached an asset to a scan profile", map[string]any{
+		"scan_profile_token": data.ScanProfileToken.ValueString(),
+		"asset_token":        data.AssetToken.ValueString(),
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScanProfileAttachmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ScanProfileAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.GetScanProfileAttachment(ctx, data.ScanProfileToken.ValueString(), data.AssetToken.ValueString())
+	if IsNotFound(err) {
+		tflog.Warn(ctx, "Scan profile attachment not found, removing from state", map[string]any{
+			"scan_profile_token": data.ScanProfileToken.ValueString(),
+			"asset_token":        data.AssetToken.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read scan profile attachment, got error: %s", err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScanProfileAttachmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// All configurable attributes require replacement, so there is nothing to update.
+	resp.Diagnostics.AddError(
+		"Unexpected Update",
+		"Scan profile attachments cannot be updated in place. Please report this issue to the provider developers.",
+	)
+}
+
+func (r *ScanProfileAttachmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ScanProfileAttachmentResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An asset that is already detached is the desired state, so the missing attachment is not an error.
+	err := r.client.DetachScanProfile(ctx, data.ScanProfileToken.ValueString(), data.AssetToken.ValueString())
+	if err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to detach asset from scan profile, got error: %s", err))
+		return
+	}
+}
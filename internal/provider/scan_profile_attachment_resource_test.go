@@ -0,0 +1,113 @@
+package provider_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestAccScanProfileAttachmentResource(t *testing.T) {
+	api := newFakeAPI(t)
+
+	first := api.addAsset("example.com")
+	second := api.addAsset("example.org")
+
+	config := func(assetToken string) string {
+		return api.providerConfig() + fmt.Sprintf(`
+resource "detectify_scan_profile" "test" {
+  name     = "Example"
+  endpoint = "https://example.com"
+}
+
+resource "detectify_scan_profile_attachment" "test" {
+  scan_profile_token = detectify_scan_profile.test.token
+  asset_token        = %q
+}
+`, assetToken)
+	}
+
+	// profileToken returns the token of the scan profile created by the test.
+	profileToken := func() string {
+		api.mu.Lock()
+		defer api.mu.Unlock()
+
+		for token := range api.profiles {
+			return token
+		}
+
+		t.Fatal("no scan profile was created")
+		return ""
+	}
+
+	// checkAttached verifies which assets are attached to the scan profile.
+	checkAttached := func(assetTokens ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			token := profileToken()
+
+			api.mu.Lock()
+			defer api.mu.Unlock()
+
+			if len(api.attachments[token]) != len(assetTokens) {
+				return fmt.Errorf("expected %d attached assets, got %v", len(assetTokens), api.attachments[token])
+			}
+
+			for _, assetToken := range assetTokens {
+				if !api.attachments[token][assetToken] {
+					return fmt.Errorf("asset %q is not attached", assetToken)
+				}
+			}
+
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(first),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("detectify_scan_profile_attachment.test", "scan_profile_token", "detectify_scan_profile.test", "token"),
+					resource.TestCheckResourceAttr("detectify_scan_profile_attachment.test", "asset_token", first),
+					checkAttached(first),
+				),
+			},
+			{
+				// An asset detached outside of Terraform is attached again.
+				PreConfig: func() { api.setAttached(profileToken(), first, false) },
+				Config:    config(first),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_scan_profile_attachment.test", plancheck.ResourceActionCreate),
+					},
+				},
+				Check: checkAttached(first),
+			},
+			{
+				// Attaching an asset that is already attached succeeds, and the replaced attachment is detached.
+				PreConfig: func() { api.setAttached(profileToken(), second, true) },
+				Config:    config(second),
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("detectify_scan_profile_attachment.test", plancheck.ResourceActionDestroyBeforeCreate),
+					},
+				},
+				Check: checkAttached(second),
+			},
+			{
+				// Removing the attachment detaches the asset, leaving the scan profile.
+				Config: api.providerConfig() + `
+resource "detectify_scan_profile" "test" {
+  name     = "Example"
+  endpoint = "https://example.com"
+}
+`,
+				Check: checkAttached(),
+			},
+		},
+	})
+}
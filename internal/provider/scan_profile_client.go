@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+)
+
+// ScanProfile is a scan profile as represented by the Detectify API.
+type ScanProfile struct {
+	Token    string `json:"token"`
+	Name     string `json:"name"`
+	Endpoint string `json:"endpoint"`
+}
+
+// ScanProfileRequest is the request body used when creating or updating a scan profile.
+type ScanProfileRequest struct {
+	Name     string `json:"name"`
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// ScanProfileScope is the scope configuration of a scan profile.
+type ScanProfileScope struct {
+	IncludedHosts []string `json:"included_hosts"`
+	ExcludedHosts []string `json:"excluded_hosts"`
+}
+
+// GetScanProfile returns the scan profile identified by token.
+func (c *Client) GetScanProfile(ctx context.Context, token string) (*ScanProfile, error) {
+	var profile ScanProfile
+	if err := c.do(ctx, http.MethodGet, apiPath("profiles", token), nil, &profile); err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+// CreateScanProfile adds a new scan profile.
+func (c *Client) CreateScanProfile(ctx context.Context, body ScanProfileRequest) (*ScanProfile, error) {
+	var profile ScanProfile
+	if err := c.do(ctx, http.MethodPost, "/v2/profiles/", body, &profile); err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+// UpdateScanProfile replaces the mutable properties of the scan profile identified by token.
+func (c *Client) UpdateScanProfile(ctx context.Context, token string, body ScanProfileRequest) (*ScanProfile, error) {
+	var profile ScanProfile
+	if err := c.do(ctx, http.MethodPut, apiPath("profiles", token), body, &profile); err != nil {
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+// DeleteScanProfile removes the scan profile identified by token.
+func (c *Client) DeleteScanProfile(ctx context.Context, token string) error {
+	return c.do(ctx, http.MethodDelete, apiPath("profiles", token), nil, nil)
+}
+
+// GetScanProfileScope returns the scope configuration of the scan profile identified by token.
+func (c *Client) GetScanProfileScope(ctx context.Context, token string) (*ScanProfileScope, error) {
+	var scope ScanProfileScope
+	if err := c.do(ctx, http.MethodGet, apiPath("profiles", token, "scope"), nil, &scope); err != nil {
+		return nil, err
+	}
+
+	return &scope, nil
+}
+
+// UpdateScanProfileScope replaces the scope configuration of the scan profile identified by token.
+func (c *Client) UpdateScanProfileScope(ctx context.Context, token string, body ScanProfileScope) (*ScanProfileScope, error) {
+	var scope ScanProfileScope
+	if err := c.do(ctx, http.MethodPut, apiPath("profiles", token, "scope"), body, &scope); err != nil {
+		return nil, err
+	}
+
+	return &scope, nil
+}
+
+// scanProfileAssetPath returns the path of the attachment of the asset to the scan profile.
+func scanProfileAssetPath(profileToken, assetToken string) string {
+	return apiPath("profiles", profileToken, "assets", assetToken)
+}
+
+// GetScanProfileAttachment checks whether the asset is attached to the scan profile,
+// returning an APIError for a missing object if it is not.
+func (c *Client) GetScanProfileAttachment(ctx context.Context, profileToken, assetToken string) error {
+	return c.do(ctx, http.MethodGet, scanProfileAssetPath(profileToken, assetToken), nil, nil)
+}
+
+// AttachScanProfile attaches the asset to the scan profile.
+func (c *Client) AttachScanProfile(ctx context.Context, profileToken, assetToken string) error {
+	return c.do(ctx, http.MethodPut, scanProfileAssetPath(profileToken, assetToken), nil, nil)
+}
+
+// DetachScanProfile detaches the asset from the scan profile.
+func (c *Client) DetachScanProfile(ctx context.Context, profileToken, assetToken string) error {
+	return c.do(ctx, http.MethodDelete, scanProfileAssetPath(profileToken, assetToken), nil, nil)
+}
@@ -0,0 +1,312 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var (
+	_ resource.Resource                = &ScanProfileResource{}
+	_ resource.ResourceWithImportState = &ScanProfileResource{}
+	_ resource.ResourceWithModifyPlan  = &ScanProfileResource{}
+)
+
+func NewScanProfileResource() resource.Resource {
+	return &ScanProfileResource{}
+}
+
+// ScanProfileResource defines the resource implementation.
+type ScanProfileResource struct {
+	client       *Client
+	providerData *DetectifyProviderData
+}
+
+// scanProfilePlanTier is the lowest plan tier that includes scan profiles.
+const scanProfilePlanTier = PlanTierProfessional
+
+// ScanProfileResourceModel describes the resource data model.
+type ScanProfileResourceModel struct {
+	Token         types.String `tfsdk:"token"`
+	Name          types.String `tfsdk:"name"`
+	Endpoint      types.String `tfsdk:"endpoint"`
+	IncludedHosts types.Set    `tfsdk:"included_hosts"`
+	ExcludedHosts types.Set    `tfsdk:"excluded_hosts"`
+}
+
+func (r *ScanProfileResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scan_profile"
+}
+
+func (r *ScanProfileResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Manages a scan profile, used to scan a web application. Requires the Professional plan or higher.",
+
+		Attributes: map[string]schema.Attribute{
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The scan profile token.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "The name of the scan profile.",
+				Required:            true,
+			},
+			"endpoint": schema.StringAttribute{
+				MarkdownDescription: "The hostname or URL to scan.",
+				Required:            true,
+				Validators: []validator.String{
+					hostOrURLValidator{},
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"included_hosts": schema.SetAttribute{
+				MarkdownDescription: "Additional hostnames or URLs that are in scope for scans.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(hostOrURLValidator{}),
+				},
+			},
+			"excluded_hosts": schema.SetAttribute{
+				MarkdownDescription: "Hostnames or URLs that are excluded from scans.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.Set{
+					setvalidator.ValueStringsAre(hostOrURLValidator{}),
+				},
+			},
+		},
+	}
+}
+
+func (r *ScanProfileResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = providerData.Client
+	r.providerData = providerData
+}
+
+func (r *ScanProfileResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Only new scan profiles are checked, as existing ones show that the plan includes them.
+	if !req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	requirePlanTier(ctx, r.providerData, scanProfilePlanTier, "detectify_scan_profile", &resp.Diagnostics)
+}
+
+func (r *ScanProfileResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ScanProfileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope, diags := data.scope(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, err := r.client.CreateScanProfile(ctx, ScanProfileRequest{
+		Name:     data.Name.ValueString(),
+		Endpoint: data.Endpoint.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create scan profile, got error: %s", err))
+		return
+	}
+
+	data.update(profile)
+
+	// Save the profile before configuring the scope, so that it is not lost if that fails.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+
+	updated, err := r.client.UpdateScanProfileScope(ctx, profile.Token, scope)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update scan profile scope, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateScope(ctx, updated)...)
+
+	tflog.Trace(ctx, "created a scan profile", map[string]any{"token": profile.Token})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScanProfileResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ScanProfileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, err := r.client.GetScanProfile(ctx, data.Token.ValueString())
+	if IsNotFound(err) {
+		tflog.Warn(ctx, "scan profile not found, removing from state", map[string]any{"token": data.Token.ValueString()})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read scan profile, got error: %s", err))
+		return
+	}
+
+	data.update(profile)
+
+	scope, err := r.client.GetScanProfileScope(ctx, profile.Token)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read scan profile scope, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateScope(ctx, scope)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScanProfileResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ScanProfileResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	scope, diags := data.scope(ctx)
+	resp.Diagnostics.Append(diags...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	profile, err := r.client.UpdateScanProfile(ctx, data.Token.ValueString(), ScanProfileRequest{
+		Name: data.Name.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update scan profile, got error: %s", err))
+		return
+	}
+
+	data.update(profile)
+
+	updated, err := r.client.UpdateScanProfileScope(ctx, profile.Token, scope)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update scan profile scope, got error: %s", err))
+		return
+	}
+
+	resp.Diagnostics.Append(data.updateScope(ctx, updated)...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ScanProfileResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ScanProfileResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	err := r.client.DeleteScanProfile(ctx, data.Token.ValueString())
+	if err != nil && !IsNotFound(err) {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete scan profile, got error: %s", err))
+		return
+	}
+}
+
+func (r *ScanProfileResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("token"), req, resp)
+}
+
+// scope builds the scope configuration from the model.
+func (m *ScanProfileResourceModel) scope(ctx context.Context) (ScanProfileScope, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	scope := ScanProfileScope{
+		IncludedHosts: []string{},
+		ExcludedHosts: []string{},
+	}
+
+	diags.Append(m.IncludedHosts.ElementsAs(ctx, &scope.IncludedHosts, false)...)
+	diags.Append(m.ExcludedHosts.ElementsAs(ctx, &scope.ExcludedHosts, false)...)
+
+	return scope, diags
+}
+
+// update sets the model values from the API representation of the scan profile.
+func (m *ScanProfileResourceModel) update(profile *ScanProfile) {
+	m.Token = types.StringValue(profile.Token)
+	m.Name = types.StringValue(profile.Name)
+	m.Endpoint = types.StringValue(profile.Endpoint)
+}
+
+// updateScope sets the model values from the API representation of the scope configuration.
+func (m *ScanProfileResourceModel) updateScope(ctx context.Context, scope *ScanProfileScope) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	m.IncludedHosts = stringSetValue(ctx, m.IncludedHosts, scope.IncludedHosts, &diags)
+	m.ExcludedHosts = stringSetValue(ctx, m.ExcludedHosts, scope.ExcludedHosts, &diags)
+
+	return diags
+}
+
+// stringSetValue returns a set of the values, keeping a null set null when there are no values
+// to avoid a difference between an unset and an empty attribute.
+func stringSetValue(ctx context.Context, current types.Set, values []string, diags *diag.Diagnostics) types.Set {
+	if len(values) == 0 && current.IsNull() {
+		return current
+	}
+
+	set, d := types.SetValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+
+	return set
+}
@@ -0,0 +1,147 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccScanProfileResourceScope(t *testing.T) {
+	api := newFakeAPI(t)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+resource "detectify_scan_profile" "test" {
+  name           = "Example"
+  endpoint       = "https://example.com"
+  included_hosts = ["not a hostname"]
+}
+`,
+				ExpectError: regexp.MustCompile(`value must be a hostname or\s+an HTTP\(S\) URL`),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_scan_profile" "test" {
+  name     = "Example"
+  endpoint = "https://example.com"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("detectify_scan_profile.test", "token"),
+					resource.TestCheckNoResourceAttr("detectify_scan_profile.test", "included_hosts"),
+					resource.TestCheckNoResourceAttr("detectify_scan_profile.test", "excluded_hosts"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_scan_profile" "test" {
+  name           = "Example"
+  endpoint       = "https://example.com"
+  included_hosts = ["www.example.com", "api.example.com"]
+  excluded_hosts = ["https://example.com/admin"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_scan_profile.test", "included_hosts.#", "2"),
+					resource.TestCheckTypeSetElemAttr("detectify_scan_profile.test", "included_hosts.*", "www.example.com"),
+					resource.TestCheckTypeSetElemAttr("detectify_scan_profile.test", "included_hosts.*", "api.example.com"),
+					resource.TestCheckResourceAttr("detectify_scan_profile.test", "excluded_hosts.#", "1"),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+resource "detectify_scan_profile" "test" {
+  name           = "Example"
+  endpoint       = "https://example.com"
+  included_hosts = ["api.example.com"]
+  excluded_hosts = []
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("detectify_scan_profile.test", "included_hosts.#", "1"),
+					resource.TestCheckTypeSetElemAttr("detectify_scan_profile.test", "included_hosts.*", "api.example.com"),
+					resource.TestCheckResourceAttr("detectify_scan_profile.test", "excluded_hosts.#", "0"),
+				),
+			},
+			{
+				ResourceName:                         "detectify_scan_profile.test",
+				ImportState:                          true,
+				ImportStateVerify:                    true,
+				ImportStateIdFunc:                    testAccResourceToken("detectify_scan_profile.test"),
+				ImportStateVerifyIdentifierAttribute: "token",
+			},
+		},
+	})
+}
+
+func TestAccScanProfileResourcePlanTier(t *testing.T) {
+	api := newFakeAPI(t)
+	api.planTier = provider.PlanTierStarter
+
+	config := api.providerConfig() + `
+resource "detectify_scan_profile" "test" {
+  name     = "Example"
+  endpoint = "https://example.com"
+}
+`
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Plan Upgrade Required`),
+			},
+			{
+				PreConfig: func() {
+					api.mu.Lock()
+					defer api.mu.Unlock()
+
+					// The failed plan did not create the profile.
+					require.Empty(t, api.profiles)
+					api.planTier = provider.PlanTierProfessional
+				},
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("detectify_scan_profile.test", "token"),
+				),
+			},
+			{
+				// Existing profiles are kept after a downgrade.
+				PreConfig: func() {
+					api.mu.Lock()
+					defer api.mu.Unlock()
+
+					api.planTier = provider.PlanTierStarter
+				},
+				Config: config,
+			},
+		},
+	})
+}
+
+func TestProviderDataPlanTierCached(t *testing.T) {
+	requests := 0
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"name": "Example Inc", "plan_tier": "enterprise"}`))
+	}))
+
+	for i := 0; i < 3; i++ {
+		tier, err := providerData.PlanTier(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, provider.PlanTierEnterprise, tier)
+	}
+
+	require.Equal(t, 1, requests)
+}
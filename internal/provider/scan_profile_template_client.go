@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"context"
+)
+
+// ScanProfileTemplate is a preset of scan profile settings offered by Detectify, as represented by the Detectify API.
+type ScanProfileTemplate struct {
+	ID          string                      `json:"id"`
+	Name        string                      `json:"name"`
+	Description string                      `json:"description"`
+	Settings    ScanProfileTemplateSettings `json:"settings"`
+}
+
+// ScanProfileTemplateSettings are the default settings of scan profiles based on a template.
+type ScanProfileTemplateSettings struct {
+	ScanFrequency  string   `json:"scan_frequency"`
+	TestCategories []string `json:"test_categories"`
+	IncludedHosts  []string `json:"included_hosts"`
+	ExcludedHosts  []string `json:"excluded_hosts"`
+}
+
+// ListScanProfileTemplates returns the scan profile templates offered by Detectify. The templates
+// are only requested once per client, which is a single Terraform run.
+func (c *Client) ListScanProfileTemplates(ctx context.Context) ([]ScanProfileTemplate, error) {
+	c.scanProfileTemplatesMu.Lock()
+	defer c.scanProfileTemplatesMu.Unlock()
+
+	if c.scanProfileTemplates != nil {
+		return c.scanProfileTemplates, nil
+	}
+
+	templates, err := listPages[ScanProfileTemplate](ctx, c, "/v2/profile-templates/", nil, "templates")
+	if err != nil {
+		return nil, err
+	}
+
+	c.scanProfileTemplates = templates
+
+	return templates, nil
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ScanProfileTemplatesDataSource{}
+
+func NewScanProfileTemplatesDataSource() datasource.DataSource {
+	return &ScanProfileTemplatesDataSource{}
+}
+
+// ScanProfileTemplatesDataSource defines the data source implementation.
+type ScanProfileTemplatesDataSource struct {
+	client          *Client
+	defaultPageSize int64
+}
+
+// ScanProfileTemplatesDataSourceModel describes the data source data model.
+type ScanProfileTemplatesDataSourceModel struct {
+	Templates types.List  `tfsdk:"templates"`
+	PageSize  types.Int64 `tfsdk:"page_size"`
+}
+
+// scanProfileTemplateModel describes a template in the scan profile templates data source.
+type scanProfileTemplateModel struct {
+	ID          types.String `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	Settings    types.Object `tfsdk:"settings"`
+}
+
+// scanProfileTemplateSettingsModel describes the default settings of a scan profile template.
+type scanProfileTemplateSettingsModel struct {
+	ScanFrequency  types.String `tfsdk:"scan_frequency"`
+	TestCategories types.List   `tfsdk:"test_categories"`
+	IncludedHosts  types.List   `tfsdk:"included_hosts"`
+	ExcludedHosts  types.List   `tfsdk:"excluded_hosts"`
+}
+
+// scanProfileTemplateSettingsAttrTypes are the attribute types of scanProfileTemplateSettingsModel.
+var scanProfileTemplateSettingsAttrTypes = map[string]attr.Type{
+	"scan_frequency":  types.StringType,
+	"test_categories": types.ListType{ElemType: types.StringType},
+	"included_hosts":  types.ListType{ElemType: types.StringType},
+	"excluded_hosts":  types.ListType{ElemType: types.StringType},
+}
+
+// scanProfileTemplateAttrTypes are the attribute types of scanProfileTemplateModel.
+var scanProfileTemplateAttrTypes = map[string]attr.Type{
+	"id":          types.StringType,
+	"name":        types.StringType,
+	"description": types.StringType,
+	"settings":    types.ObjectType{AttrTypes: scanProfileTemplateSettingsAttrTypes},
+}
+
+func (d *ScanProfileTemplatesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scan_profile_templates"
+}
+
+func (d *ScanProfileTemplatesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the scan profile templates offered by Detectify, presets of scan profile settings " +
+			"to base new scan profiles on. The templates are only requested once per Terraform run.",
+
+		Attributes: map[string]schema.Attribute{
+			"templates": schema.ListNestedAttribute{
+				MarkdownDescription: "The scan profile templates, in the order returned by the API.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "The identifier of the template.",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the template.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "A description of what the template is meant for.",
+							Computed:            true,
+						},
+						"settings": schema.SingleNestedAttribute{
+							MarkdownDescription: "The default settings of scan profiles based on the template.",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"scan_frequency": schema.StringAttribute{
+									MarkdownDescription: "How often scans are run, such as `weekly`.",
+									Computed:            true,
+								},
+								"test_categories": schema.ListAttribute{
+									MarkdownDescription: "The test categories scans are run with.",
+									ElementType:         types.StringType,
+									Computed:            true,
+								},
+								"included_hosts": schema.ListAttribute{
+									MarkdownDescription: "Additional hostnames or URLs that are in scope for scans.",
+									ElementType:         types.StringType,
+									Computed:            true,
+								},
+								"excluded_hosts": schema.ListAttribute{
+									MarkdownDescription: "Hostnames or URLs that are excluded from scans.",
+									ElementType:         types.StringType,
+									Computed:            true,
+								},
+							},
+						},
+					},
+				},
+			},
+			"page_size": pageSizeAttribute(),
+		},
+	}
+}
+
+func (d *ScanProfileTemplatesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.defaultPageSize = providerData.DefaultPageSize
+}
+
+func (d *ScanProfileTemplatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ScanProfileTemplatesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	templates, err := d.client.ListScanProfileTemplates(withPageSize(ctx, data.PageSize, d.defaultPageSize))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list scan profile templates, got error: %s", err))
+		return
+	}
+
+	items := make([]scanProfileTemplateModel, len(templates))
+	for i, template := range templates {
+		settings, diags := scanProfileTemplateSettingsValue(ctx, template.Settings)
+		resp.Diagnostics.Append(diags...)
+
+		items[i] = scanProfileTemplateModel{
+			ID:          types.StringValue(template.ID),
+			Name:        types.StringValue(template.Name),
+			Description: types.StringValue(template.Description),
+			Settings:    settings,
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.Templates, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: scanProfileTemplateAttrTypes}, items)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Trace(ctx, "read scan profile templates", map[string]any{"count": len(templates)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// scanProfileTemplateSettingsValue returns the object value of the default settings of a template.
+func scanProfileTemplateSettingsValue(ctx context.Context, settings ScanProfileTemplateSettings) (types.Object, diag.Diagnostics) {
+	var diags, d diag.Diagnostics
+	model := scanProfileTemplateSettingsModel{
+		ScanFrequency: types.StringValue(settings.ScanFrequency),
+	}
+
+	// Settings the template leaves out are empty lists rather than null.
+	for _, list := range []struct {
+		target *types.List
+		values []string
+	}{
+		{&model.TestCategories, settings.TestCategories},
+		{&model.IncludedHosts, settings.IncludedHosts},
+		{&model.ExcludedHosts, settings.ExcludedHosts},
+	} {
+		values := list.values
+		if values == nil {
+			values = []string{}
+		}
+
+		*list.target, d = types.ListValueFrom(ctx, types.StringType, values)
+		diags.Append(d...)
+	}
+
+	value, d := types.ObjectValueFrom(ctx, scanProfileTemplateSettingsAttrTypes, model)
+	diags.Append(d...)
+
+	return value, diags
+}
@@ -0,0 +1,83 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccScanProfileTemplatesDataSource(t *testing.T) {
+	api := newFakeAPI(t)
+
+	api.addScanProfileTemplate(provider.ScanProfileTemplate{
+		ID:          "owasp-top-10",
+		Name:        "OWASP Top 10",
+		Description: "Tests for the OWASP Top 10 web application security risks.",
+		Settings: provider.ScanProfileTemplateSettings{
+			ScanFrequency:  "weekly",
+			TestCategories: []string{"sql-injection", "xss"},
+			IncludedHosts:  []string{"api.example.com"},
+			ExcludedHosts:  []string{"admin.example.com"},
+		},
+	})
+	api.addScanProfileTemplate(provider.ScanProfileTemplate{
+		ID:          "quick",
+		Name:        "Quick scan",
+		Description: "A daily scan for the most common vulnerabilities.",
+		Settings:    provider.ScanProfileTemplateSettings{ScanFrequency: "daily", TestCategories: []string{"xss"}},
+	})
+	api.addScanProfileTemplate(provider.ScanProfileTemplate{
+		ID:          "api",
+		Name:        "API",
+		Description: "Tests for APIs without a user interface.",
+		Settings:    provider.ScanProfileTemplateSettings{ScanFrequency: "monthly", TestCategories: []string{"ssrf", "sql-injection"}},
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+data "detectify_scan_profile_templates" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// The three templates span two pages.
+					resource.TestCheckResourceAttr("data.detectify_scan_profile_templates.test", "templates.#", "3"),
+					resource.TestCheckResourceAttr("data.detectify_scan_profile_templates.test", "templates.0.id", "owasp-top-10"),
+					resource.TestCheckResourceAttr("data.detectify_scan_profile_templates.test", "templates.0.name", "OWASP Top 10"),
+					resource.TestCheckResourceAttr("data.detectify_scan_profile_templates.test", "templates.0.settings.scan_frequency", "weekly"),
+					resource.TestCheckResourceAttr("data.detectify_scan_profile_templates.test", "templates.0.settings.test_categories.#", "2"),
+					resource.TestCheckResourceAttr("data.detectify_scan_profile_templates.test", "templates.0.settings.included_hosts.0", "api.example.com"),
+					resource.TestCheckResourceAttr("data.detectify_scan_profile_templates.test", "templates.0.settings.excluded_hosts.0", "admin.example.com"),
+					resource.TestCheckResourceAttr("data.detectify_scan_profile_templates.test", "templates.1.description", "A daily scan for the most common vulnerabilities."),
+					resource.TestCheckResourceAttr("data.detectify_scan_profile_templates.test", "templates.1.settings.included_hosts.#", "0"),
+					resource.TestCheckResourceAttr("data.detectify_scan_profile_templates.test", "templates.2.id", "api"),
+					resource.TestCheckResourceAttr("data.detectify_scan_profile_templates.test", "templates.2.settings.test_categories.0", "ssrf"),
+				),
+			},
+		},
+	})
+}
+
+func TestListScanProfileTemplatesCached(t *testing.T) {
+	requests := 0
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"templates": [{"id": "quick", "name": "Quick scan", "description": "", "settings": {"scan_frequency": "daily"}}]}`))
+	}))
+
+	for i := 0; i < 3; i++ {
+		templates, err := providerData.Client.ListScanProfileTemplates(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []provider.ScanProfileTemplate{
+			{ID: "quick", Name: "Quick scan", Settings: provider.ScanProfileTemplateSettings{ScanFrequency: "daily"}},
+		}, templates)
+	}
+
+	require.Equal(t, 1, requests)
+}
@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+)
+
+// ScanReport is the summary of a scan, as represented by the Detectify API.
+type ScanReport struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+	Target string `json:"target"`
+	// Duration is the duration of the scan in seconds, nil while the scan is in progress.
+	Duration *int64 `json:"duration,omitempty"`
+	// Findings is the number of findings by severity.
+	Findings map[string]int64 `json:"findings"`
+}
+
+// GetScanReport returns the report of the scan identified by id.
+func (c *Client) GetScanReport(ctx context.Context, id string) (*ScanReport, error) {
+	var report ScanReport
+	if err := c.do(ctx, http.MethodGet, apiPath("scans", id, "report"), nil, &report); err != nil {
+		return nil, err
+	}
+
+	return &report, nil
+}
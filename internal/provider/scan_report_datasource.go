@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ScanReportDataSource{}
+
+func NewScanReportDataSource() datasource.DataSource {
+	return &ScanReportDataSource{}
+}
+
+// ScanReportDataSource defines the data source implementation.
+type ScanReportDataSource struct {
+	client *Client
+}
+
+// ScanReportDataSourceModel describes the data source data model.
+type ScanReportDataSourceModel struct {
+	ScanID          types.String `tfsdk:"scan_id"`
+	Status          types.String `tfsdk:"status"`
+	Target          types.String `tfsdk:"target"`
+	DurationSeconds types.Int64  `tfsdk:"duration_seconds"`
+	FindingsCount   types.Map    `tfsdk:"findings_count"`
+
+	IncludeRaw      types.Bool   `tfsdk:"include_raw"`
+	RawJSON         types.String `tfsdk:"raw_json"`
+	ExposeHeaders   types.List   `tfsdk:"expose_headers"`
+	ResponseHeaders types.Map    `tfsdk:"response_headers"`
+}
+
+func (d *ScanReportDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scan_report"
+}
+
+func (d *ScanReportDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Reads the summary results of a scan. Scans that are still in progress can be read, " +
+			"with the results found so far.",
+
+		Attributes: map[string]schema.Attribute{
+			"scan_id": schema.StringAttribute{
+				MarkdownDescription: "The identifier of the scan.",
+				Required:            true,
+			},
+			"status": schema.StringAttribute{
+				MarkdownDescription: "The status of the scan, such as `running` or `completed`.",
+				Computed:            true,
+			},
+			"target": schema.StringAttribute{
+				MarkdownDescription: "The hostname or URL that was scanned.",
+				Computed:            true,
+			},
+			"duration_seconds": schema.Int64Attribute{
+				MarkdownDescription: "How long the scan took, in seconds. Null while the scan is in progress.",
+				Computed:            true,
+			},
+			"findings_count": schema.MapAttribute{
+				MarkdownDescription: "The number of findings by severity, such as `high` or `low`.",
+				ElementType:         types.Int64Type,
+				Computed:            true,
+			},
+			"include_raw":      includeRawAttribute(),
+			"raw_json":         rawJSONAttribute(),
+			"expose_headers":   exposeHeadersAttribute(),
+			"response_headers": responseHeadersAttribute(),
+		},
+	}
+}
+
+func (d *ScanReportDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+}
+
+func (d *ScanReportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ScanReportDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clientCtx, raw := withRawResponse(ctx, data.IncludeRaw, data.ExposeHeaders)
+
+	report, err := d.client.GetScanReport(clientCtx, data.ScanID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read scan report, got error: %s", err))
+		return
+	}
+
+	data.Status = types.StringValue(report.Status)
+	data.Target = types.StringValue(report.Target)
+	data.DurationSeconds = types.Int64PointerValue(report.Duration)
+
+	findings := report.Findings
+	if findings == nil {
+		findings = map[string]int64{}
+	}
+
+	findingsCount, diags := types.MapValueFrom(ctx, types.Int64Type, findings)
+	resp.Diagnostics.Append(diags...)
+	data.FindingsCount = findingsCount
+
+	data.RawJSON = rawJSONValue(raw, data.IncludeRaw)
+
+	responseHeaders, diags := responseHeadersValue(ctx, raw, data.ExposeHeaders)
+	resp.Diagnostics.Append(diags...)
+	data.ResponseHeaders = responseHeaders
+
+	tflog.Trace(ctx, "read a scan report", map[string]any{"scan_id": report.ID, "status": report.Status})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
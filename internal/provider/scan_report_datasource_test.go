@@ -0,0 +1,82 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+)
+
+func TestAccScanReportDataSource(t *testing.T) {
+	api := newFakeAPI(t)
+
+	duration := int64(1834)
+	api.addScanReport(provider.ScanReport{
+		ID:       "scan0001",
+		Status:   "completed",
+		Target:   "example.com",
+		Duration: &duration,
+		Findings: map[string]int64{"high": 2, "medium": 5, "low": 11},
+	})
+	api.addScanReport(provider.ScanReport{
+		ID:     "scan0002",
+		Status: "running",
+		Target: "https://example.com/app",
+	})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+data "detectify_scan_report" "completed" {
+  scan_id = "scan0001"
+}
+
+data "detectify_scan_report" "running" {
+  scan_id     = "scan0002"
+  include_raw = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.detectify_scan_report.completed", "status", "completed"),
+					resource.TestCheckResourceAttr("data.detectify_scan_report.completed", "target", "example.com"),
+					resource.TestCheckResourceAttr("data.detectify_scan_report.completed", "duration_seconds", "1834"),
+					resource.TestCheckResourceAttr("data.detectify_scan_report.completed", "findings_count.%", "3"),
+					resource.TestCheckResourceAttr("data.detectify_scan_report.completed", "findings_count.high", "2"),
+					resource.TestCheckResourceAttr("data.detectify_scan_report.completed", "findings_count.low", "11"),
+
+					resource.TestCheckResourceAttr("data.detectify_scan_report.running", "status", "running"),
+					resource.TestCheckNoResourceAttr("data.detectify_scan_report.running", "duration_seconds"),
+					resource.TestCheckResourceAttr("data.detectify_scan_report.running", "findings_count.%", "0"),
+
+					resource.TestCheckNoResourceAttr("data.detectify_scan_report.completed", "raw_json"),
+					resource.TestCheckResourceAttrWith("data.detectify_scan_report.running", "raw_json", func(value string) error {
+						var report map[string]any
+						if err := json.Unmarshal([]byte(value), &report); err != nil {
+							return err
+						}
+
+						if report["id"] != "scan0002" || report["target"] != "https://example.com/app" {
+							return fmt.Errorf("unexpected raw report: %s", value)
+						}
+
+						return nil
+					}),
+				),
+			},
+			{
+				Config: api.providerConfig() + `
+data "detectify_scan_report" "missing" {
+  scan_id = "scan9999"
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to read scan report`),
+			},
+		},
+	})
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"sync"
+)
+
+// semaphore limits the number of concurrent holders to its capacity. A nil semaphore has no limit.
+type semaphore chan struct{}
+
+// newSemaphore returns a semaphore with n slots, or a nil semaphore if n is not positive.
+func newSemaphore(n int64) semaphore {
+	if n <= 0 {
+		return nil
+	}
+
+	return make(semaphore, n)
+}
+
+// acquire waits for a free slot, or until the context is done.
+func (s semaphore) acquire(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	select {
+	case s <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release frees a slot taken by acquire.
+func (s semaphore) release() {
+	if s == nil {
+		return
+	}
+
+	<-s
+}
+
+// releasingBody releases a semaphore slot when the response body is closed,
+// so that the slot is held until the response has been read.
+type releasingBody struct {
+	io.ReadCloser
+	once    sync.Once
+	release func()
+}
+
+func (b *releasingBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.once.Do(b.release)
+
+	return err
+}
@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+)
+
+// Team is a team of the account, as represented by the Detectify API.
+type Team struct {
+	Token string `json:"token"`
+	Name  string `json:"name"`
+}
+
+// ListTeams returns all teams available to the API key.
+func (c *Client) ListTeams(ctx context.Context) ([]Team, error) {
+	teams := []Team{}
+	if err := c.do(ctx, http.MethodGet, "/v2/teams/", nil, &teams); err != nil {
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+// GetTeam returns the team identified by token.
+func (c *Client) GetTeam(ctx context.Context, token string) (*Team, error) {
+	var team Team
+	if err := c.do(ctx, http.MethodGet, apiPath("teams", token), nil, &team); err != nil {
+		return nil, err
+	}
+
+	return &team, nil
+}
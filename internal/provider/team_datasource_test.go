@@ -0,0 +1,88 @@
+package provider_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccTeamDataSource(t *testing.T) {
+	api := newFakeAPI(t)
+
+	platform := api.addTeam("Platform Security")
+	web := api.addTeam("Web")
+	api.addTeam("Duplicate")
+	api.addTeam("Duplicate")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             api.checkAssetsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+data "detectify_team" "test" {
+  name  = "Web"
+  token = "` + web + `"
+}
+`,
+				ExpectError: regexp.MustCompile(`Invalid Attribute Combination`),
+			},
+			{
+				Config: api.providerConfig() + `
+data "detectify_team" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Missing Attribute Configuration`),
+			},
+			{
+				Config: api.providerConfig() + `
+data "detectify_team" "test" {
+  name = "Nobody"
+}
+`,
+				ExpectError: regexp.MustCompile(`Team Not Found`),
+			},
+			{
+				Config: api.providerConfig() + `
+data "detectify_team" "test" {
+  name = "Duplicate"
+}
+`,
+				ExpectError: regexp.MustCompile(`Multiple Teams Found`),
+			},
+			{
+				Config: api.providerConfig() + `
+data "detectify_team" "test" {
+  token = "team9999"
+}
+`,
+				ExpectError: regexp.MustCompile(`Unable to read team`),
+			},
+			{
+				// The token found by name can be used for the team of an asset.
+				Config: api.providerConfig() + `
+data "detectify_team" "by_name" {
+  name = "Platform Security"
+}
+
+data "detectify_team" "by_token" {
+  token = "` + web + `"
+}
+
+resource "detectify_asset" "test" {
+  domain     = "example.com"
+  team_token = data.detectify_team.by_name.token
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.detectify_team.by_name", "token", platform),
+					resource.TestCheckResourceAttr("data.detectify_team.by_name", "name", "Platform Security"),
+					resource.TestCheckResourceAttr("data.detectify_team.by_token", "token", web),
+					resource.TestCheckResourceAttr("data.detectify_team.by_token", "name", "Web"),
+					resource.TestCheckResourceAttr("detectify_asset.test", "team_token", platform),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TestCategoriesDataSource{}
+
+func NewTestCategoriesDataSource() datasource.DataSource {
+	return &TestCategoriesDataSource{}
+}
+
+// TestCategoriesDataSource defines the data source implementation.
+type TestCategoriesDataSource struct {
+	client          *Client
+	defaultPageSize int64
+}
+
+// TestCategoriesDataSourceModel describes the data source data model.
+type TestCategoriesDataSourceModel struct {
+	Names      types.List  `tfsdk:"names"`
+	Categories types.List  `tfsdk:"categories"`
+	PageSize   types.Int64 `tfsdk:"page_size"`
+}
+
+// testCategoryModel describes a category in the test categories data source.
+type testCategoryModel struct {
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+// testCategoryAttrTypes are the attribute types of testCategoryModel.
+var testCategoryAttrTypes = map[string]attr.Type{
+	"name":        types.StringType,
+	"description": types.StringType,
+}
+
+func (d *TestCategoriesDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_test_categories"
+}
+
+func (d *TestCategoriesDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Lists the test categories supported by Detectify, such as the attack vectors tested for. " +
+			"Use it to validate configured categories against the live values instead of hardcoding them.",
+
+		Attributes: map[string]schema.Attribute{
+			"names": schema.ListAttribute{
+				MarkdownDescription: "The names of the test categories, in the order returned by the API.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"categories": schema.ListNestedAttribute{
+				MarkdownDescription: "The test categories, in the order returned by the API.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "The name of the test category.",
+							Computed:            true,
+						},
+						"description": schema.StringAttribute{
+							MarkdownDescription: "A description of what the category tests for.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"page_size": pageSizeAttribute(),
+		},
+	}
+}
+
+func (d *TestCategoriesDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	providerData, ok := req.ProviderData.(*DetectifyProviderData)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *DetectifyProviderData, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = providerData.Client
+	d.defaultPageSize = providerData.DefaultPageSize
+}
+
+func (d *TestCategoriesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TestCategoriesDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	categories, err := d.client.ListTestCategories(withPageSize(ctx, data.PageSize, d.defaultPageSize))
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list test categories, got error: %s", err))
+		return
+	}
+
+	names := make([]string, len(categories))
+	items := make([]testCategoryModel, len(categories))
+	for i, category := range categories {
+		names[i] = category.Name
+		items[i] = testCategoryModel{
+			Name:        types.StringValue(category.Name),
+			Description: types.StringValue(category.Description),
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.Names, diags = types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	data.Categories, diags = types.ListValueFrom(ctx, types.ObjectType{AttrTypes: testCategoryAttrTypes}, items)
+	resp.Diagnostics.Append(diags...)
+
+	tflog.Trace(ctx, "read test categories", map[string]any{"count": len(categories)})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
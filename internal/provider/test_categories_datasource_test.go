@@ -0,0 +1,56 @@
+package provider_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccTestCategoriesDataSource(t *testing.T) {
+	api := newFakeAPI(t)
+
+	api.addTestCategory(provider.TestCategory{Name: "sql-injection", Description: "SQL injection."})
+	api.addTestCategory(provider.TestCategory{Name: "xss", Description: "Cross-site scripting."})
+	api.addTestCategory(provider.TestCategory{Name: "ssrf", Description: "Server-side request forgery."})
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { testProviderPreCheck(t) },
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: api.providerConfig() + `
+data "detectify_test_categories" "test" {}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					// The three categories span two pages.
+					resource.TestCheckResourceAttr("data.detectify_test_categories.test", "names.#", "3"),
+					resource.TestCheckResourceAttr("data.detectify_test_categories.test", "names.0", "sql-injection"),
+					resource.TestCheckResourceAttr("data.detectify_test_categories.test", "names.2", "ssrf"),
+					resource.TestCheckResourceAttr("data.detectify_test_categories.test", "categories.#", "3"),
+					resource.TestCheckResourceAttr("data.detectify_test_categories.test", "categories.1.name", "xss"),
+					resource.TestCheckResourceAttr("data.detectify_test_categories.test", "categories.1.description", "Cross-site scripting."),
+				),
+			},
+		},
+	})
+}
+
+func TestListTestCategoriesCached(t *testing.T) {
+	requests := 0
+	providerData := newTestProviderData(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"categories": [{"name": "xss", "description": "Cross-site scripting."}]}`))
+	}))
+
+	for i := 0; i < 3; i++ {
+		categories, err := providerData.Client.ListTestCategories(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []provider.TestCategory{{Name: "xss", Description: "Cross-site scripting."}}, categories)
+	}
+
+	require.Equal(t, 1, requests)
+}
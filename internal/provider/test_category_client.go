@@ -0,0 +1,31 @@
+package provider
+
+import (
+	"context"
+)
+
+// TestCategory is a category of security tests run by Detectify, as represented by the Detectify API.
+type TestCategory struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// ListTestCategories returns the test categories supported by Detectify. The categories
+// are only requested once per client, which is a single Terraform run.
+func (c *Client) ListTestCategories(ctx context.Context) ([]TestCategory, error) {
+	c.testCategoriesMu.Lock()
+	defer c.testCategoriesMu.Unlock()
+
+	if c.testCategories != nil {
+		return c.testCategories, nil
+	}
+
+	categories, err := listPages[TestCategory](ctx, c, "/v2/test-categories/", nil, "categories")
+	if err != nil {
+		return nil, err
+	}
+
+	c.testCategories = categories
+
+	return categories, nil
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &IsValidTokenFunction{}
+
+func NewIsValidTokenFunction() function.Function {
+	return &IsValidTokenFunction{}
+}
+
+// IsValidTokenFunction reports whether a string has the format of a Detectify token.
+type IsValidTokenFunction struct{}
+
+func (f *IsValidTokenFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "is_valid_token"
+}
+
+func (f *IsValidTokenFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks whether a string is a valid Detectify token",
+		MarkdownDescription: "Returns `true` if the string has the format of Detectify tokens, such as of assets, teams and scan profiles: " +
+			"32 lower case hexadecimal characters. Only the format is checked, not whether the token exists.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "token",
+				MarkdownDescription: "The token to check.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *IsValidTokenFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var token string
+
+	resp.Error = req.Arguments.Get(ctx, &token)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, isValidToken(token))
+}
@@ -0,0 +1,29 @@
+package provider_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidTokenFunction(t *testing.T) {
+	tests := map[string]bool{
+		"5bd1d4d6e3e9b2e5a8c7f0a1b2c3d4e5":   true,
+		"00000000000000000000000000000000":   true,
+		"5BD1D4D6E3E9B2E5A8C7F0A1B2C3D4E5":   false,
+		"5bd1d4d6e3e9b2e5a8c7f0a1b2c3d4e":    false,
+		"5bd1d4d6e3e9b2e5a8c7f0a1b2c3d4e5f":  false,
+		"5bd1d4d6e3e9b2e5a8c7f0a1b2c3d4eg":   false,
+		" 5bd1d4d6e3e9b2e5a8c7f0a1b2c3d4e5":  false,
+		"5bd1d4d6-e3e9-b2e5-a8c7-f0a1b2c3d4": false,
+		"":                                   false,
+	}
+
+	for token, want := range tests {
+		resp := runFunction(t, provider.NewIsValidTokenFunction(), token, types.BoolUnknown())
+		require.Nil(t, resp.Error, token)
+		require.Equal(t, types.BoolValue(want), resp.Result.Value(), token)
+	}
+}
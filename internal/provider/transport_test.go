@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTransportPathMatchesSignature(t *testing.T) {
+	const (
+		apiKey = "10840b0f938942feafb7186de74b9682"
+		secret = "c2VjcmV0"
+	)
+
+	var received *http.Request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+	}))
+	t.Cleanup(server.Close)
+
+	client := &http.Client{
+		Transport: &transport{
+			Transport: http.DefaultTransport,
+			apiKey:    apiKey,
+			secret:    secret,
+			algorithm: SignatureAlgorithmSHA256,
+			signQuery: true,
+		},
+	}
+
+	tests := map[string]string{
+		"trailing slash":             "/v2/domains/",
+		"no trailing slash":          "/v2/domains",
+		"query without slash":        "/v2/domains/aaaa1111/findings?order=desc",
+		"escaped path without slash": "/v2/domains/a%2Fb",
+		"escaped space":              "/v2/domains/a%20b/",
+	}
+
+	for name, path := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, server.URL+path, nil)
+			require.NoError(t, err)
+
+			resp, err := client.Do(req)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			require.True(t, strings.HasSuffix(received.URL.EscapedPath(), "/"), received.URL.EscapedPath())
+
+			// The path in the signed string is the path the request was sent with.
+			ts, err := strconv.ParseInt(received.Header.Get("X-Detectify-Timestamp"), 10, 64)
+			require.NoError(t, err)
+			signed := strings.Split(CanonicalRequestString(received, apiKey, time.Unix(ts, 0), false), ";")[1]
+			require.Equal(t, received.URL.EscapedPath(), signed)
+
+			expected := CalculateSignature(received, apiKey, secret, time.Unix(ts, 0), SignatureAlgorithmSHA256, true)
+			require.Equal(t, expected, received.Header.Get("X-Detectify-Signature"))
+		})
+	}
+
+	// The original request is not modified.
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/v2/domains", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, "/v2/domains", req.URL.Path)
+}
+
+func TestTransportSignsAfterWaitingForSlot(t *testing.T) {
+	const (
+		apiKey = "10840b0f938942feafb7186de74b9682"
+		secret = "c2VjcmV0"
+	)
+
+	received := make(chan *http.Request, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- r
+	}))
+	t.Cleanup(server.Close)
+
+	requests := newSemaphore(1)
+	client := &http.Client{
+		Transport: &transport{
+			Transport: http.DefaultTransport,
+			apiKey:    apiKey,
+			secret:    secret,
+			algorithm: SignatureAlgorithmSHA256,
+			requests:  requests,
+		},
+	}
+
+	// The only slot is taken, so the request waits for it past the second it was made in.
+	require.NoError(t, requests.acquire(context.Background()))
+
+	errc := make(chan error, 1)
+	go func() {
+		resp, err := client.Get(server.URL + "/v2/domains/")
+		if err == nil {
+			resp.Body.Close()
+		}
+		errc <- err
+	}()
+
+	time.Sleep(1100 * time.Millisecond)
+	released := time.Now()
+	requests.release()
+
+	require.NoError(t, <-errc)
+	r := <-received
+
+	ts, err := strconv.ParseInt(r.Header.Get("X-Detectify-Timestamp"), 10, 64)
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, ts, released.Unix())
+
+	expected := CalculateSignature(r, apiKey, secret, time.Unix(ts, 0), SignatureAlgorithmSHA256, false)
+	require.Equal(t, expected, r.Header.Get("X-Detectify-Signature"))
+}
+
+func TestNewHTTPTransportTLSMinVersion(t *testing.T) {
+	// The server supports TLS 1.2 at most.
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = &tls.Config{MaxVersion: tls.VersionTLS12}
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	trusted := server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+
+	for version, ok := range map[uint16]bool{tls.VersionTLS12: true, tls.VersionTLS13: false} {
+		t.Run(tls.VersionName(version), func(t *testing.T) {
+			transport := newHTTPTransport(version, false, nil)
+			require.Equal(t, version, transport.TLSClientConfig.MinVersion)
+			transport.TLSClientConfig.RootCAs = trusted
+
+			resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+			if ok {
+				require.NoError(t, err)
+				resp.Body.Close()
+				return
+			}
+
+			require.ErrorContains(t, err, "protocol version")
+		})
+	}
+
+	// The default transport is not modified.
+	if config := http.DefaultTransport.(*http.Transport).TLSClientConfig; config != nil {
+		require.Zero(t, config.MinVersion)
+	}
+}
+
+func TestNewHTTPTransportForceHTTP1(t *testing.T) {
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.EnableHTTP2 = true
+	server.StartTLS()
+	t.Cleanup(server.Close)
+
+	trusted := server.Client().Transport.(*http.Transport).TLSClientConfig.RootCAs
+
+	for forceHTTP1, proto := range map[bool]string{false: "HTTP/2.0", true: "HTTP/1.1"} {
+		t.Run(proto, func(t *testing.T) {
+			transport := newHTTPTransport(tls.VersionTLS12, forceHTTP1, nil)
+			transport.TLSClientConfig.RootCAs = trusted
+
+			resp, err := (&http.Client{Transport: transport}).Get(server.URL)
+			require.NoError(t, err)
+			resp.Body.Close()
+
+			require.Equal(t, proto, resp.Proto)
+		})
+	}
+}
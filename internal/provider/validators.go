@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// hostnamePattern matches hostnames made up of dot-separated labels, such as `www.example.com`.
+var hostnamePattern = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)*[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// isHostname reports whether s is a valid hostname.
+func isHostname(s string) bool {
+	return len(s) <= 253 && hostnamePattern.MatchString(s)
+}
+
+// normalizeDomain returns the canonical form of a domain name, lower case and
+// without surrounding whitespace or a trailing dot.
+func normalizeDomain(s string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(s), "."))
+}
+
+// isValidDomain reports whether s is a domain name that can be added as an asset,
+// after normalization. Unlike a hostname, a domain must have at least two labels.
+func isValidDomain(s string) bool {
+	domain := normalizeDomain(s)
+	return strings.Contains(domain, ".") && isHostname(domain)
+}
+
+// tokenPattern matches Detectify tokens, such as of assets, teams and scan profiles: 32 lower case hexadecimal characters.
+var tokenPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// isValidToken reports whether s has the format of a Detectify token.
+func isValidToken(s string) bool {
+	return tokenPattern.MatchString(s)
+}
+
+// isHostOrURL reports whether s is a valid hostname, or an HTTP(S) URL with a valid hostname.
+func isHostOrURL(s string) bool {
+	if isHostname(s) {
+		return true
+	}
+
+	u, err := url.Parse(s)
+	if err != nil {
+		return false
+	}
+
+	return (u.Scheme == "http" || u.Scheme == "https") && isHostname(u.Hostname())
+}
+
+// dnsServerAddress returns the address of the DNS server s, an IP address with an optional port,
+// with the default DNS port 53 added if s has no port. It reports whether s is valid.
+func dnsServerAddress(s string) (string, bool) {
+	if net.ParseIP(s) != nil {
+		return net.JoinHostPort(s, "53"), true
+	}
+
+	host, port, err := net.SplitHostPort(s)
+	if err != nil || net.ParseIP(host) == nil {
+		return "", false
+	}
+
+	if n, err := strconv.Atoi(port); err != nil || n < 1 || n > 65535 {
+		return "", false
+	}
+
+	return s, true
+}
+
+var _ validator.String = hostOrURLValidator{}
+
+// hostOrURLValidator validates that a string is a hostname or an HTTP(S) URL.
+type hostOrURLValidator struct{}
+
+func (v hostOrURLValidator) Description(ctx context.Context) string {
+	return "value must be a hostname or an HTTP(S) URL"
+}
+
+func (v hostOrURLValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v hostOrURLValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !isHostOrURL(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Host",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+var _ validator.String = hostnameValidator{}
+
+// hostnameValidator validates that a string is a hostname.
+type hostnameValidator struct{}
+
+func (v hostnameValidator) Description(ctx context.Context) string {
+	return "value must be a hostname"
+}
+
+func (v hostnameValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v hostnameValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if !isHostname(req.ConfigValue.ValueString()) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Host",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+var _ validator.String = domainValidator{}
+
+// domainValidator validates that a string is a domain name in its normalized form.
+type domainValidator struct{}
+
+func (v domainValidator) Description(ctx context.Context) string {
+	return "value must be a normalized domain name"
+}
+
+func (v domainValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v domainValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+
+	if !isValidDomain(value) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Domain",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), value),
+		)
+
+		return
+	}
+
+	if normalized := normalizeDomain(value); normalized != value {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Domain",
+			fmt.Sprintf("Attribute %s %s, got: %q. Use %q, or the provider::detectify::normalize_domain function.", req.Path, v.Description(ctx), value, normalized),
+		)
+	}
+}
+
+var _ validator.String = timestampValidator{}
+
+// timestampValidator validates that a string is an RFC 3339 timestamp, such as "2024-05-01T12:00:00Z".
+type timestampValidator struct{}
+
+func (v timestampValidator) Description(ctx context.Context) string {
+	return `value must be an RFC 3339 timestamp, such as "2024-05-01T12:00:00Z"`
+}
+
+func (v timestampValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v timestampValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Timestamp",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+var _ validator.String = durationValidator{}
+
+// durationValidator validates that a string is a positive duration, such as "30s".
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return `value must be a positive duration, such as "30s"`
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if d, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil || d <= 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
+
+var _ validator.String = dnsServerValidator{}
+
+// dnsServerValidator validates that a string is the address of a DNS server, such as "10.0.0.53:53".
+type dnsServerValidator struct{}
+
+func (v dnsServerValidator) Description(ctx context.Context) string {
+	return `value must be an IP address with an optional port, such as "10.0.0.53" or "10.0.0.53:53"`
+}
+
+func (v dnsServerValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v dnsServerValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, ok := dnsServerAddress(req.ConfigValue.ValueString()); !ok {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid DNS Server",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+		)
+	}
+}
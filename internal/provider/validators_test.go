@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsHostOrURL(t *testing.T) {
+	valid := []string{
+		"example.com",
+		"www.example.com",
+		"localhost",
+		"xn--bcher-kva.example",
+		"https://example.com",
+		"http://www.example.com/path?query=1",
+		"https://example.com:8443",
+	}
+
+	invalid := []string{
+		"",
+		"not a hostname",
+		"-example.com",
+		"example..com",
+		"ftp://example.com",
+		"https://",
+		"//example.com",
+	}
+
+	for _, s := range valid {
+		require.True(t, isHostOrURL(s), s)
+	}
+
+	for _, s := range invalid {
+		require.False(t, isHostOrURL(s), s)
+	}
+}
+
+func TestIsValidDomain(t *testing.T) {
+	valid := []string{
+		"example.com",
+		"www.example.com",
+		"Example.COM",
+		"example.com.",
+	}
+
+	invalid := []string{
+		"",
+		"localhost",
+		"not a domain",
+		".example.com",
+		"https://example.com",
+	}
+
+	for _, s := range valid {
+		require.True(t, isValidDomain(s), s)
+	}
+
+	for _, s := range invalid {
+		require.False(t, isValidDomain(s), s)
+	}
+}
+
+func TestDNSServerAddress(t *testing.T) {
+	tests := map[string]string{
+		"10.0.0.53":      "10.0.0.53:53",
+		"10.0.0.53:5353": "10.0.0.53:5353",
+		"fd00::53":       "[fd00::53]:53",
+		"[fd00::53]:53":  "[fd00::53]:53",
+	}
+
+	for s, expected := range tests {
+		addr, ok := dnsServerAddress(s)
+		require.True(t, ok, s)
+		require.Equal(t, expected, addr, s)
+	}
+
+	invalid := []string{
+		"",
+		"dns.example.com",
+		"dns.example.com:53",
+		"10.0.0.53:",
+		"10.0.0.53:0",
+		"10.0.0.53:65536",
+		"10.0.0.53:dns",
+		"fd00::53:53:invalid",
+	}
+
+	for _, s := range invalid {
+		_, ok := dnsServerAddress(s)
+		require.False(t, ok, s)
+	}
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/base64"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ function.Function = &VerifyWebhookFunction{}
+
+func NewVerifyWebhookFunction() function.Function {
+	return &VerifyWebhookFunction{}
+}
+
+// VerifyWebhookFunction reports whether a webhook payload matches its signature.
+type VerifyWebhookFunction struct{}
+
+func (f *VerifyWebhookFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "verify_webhook"
+}
+
+func (f *VerifyWebhookFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Verifies the signature of a webhook payload",
+		MarkdownDescription: "Returns `true` if the signature is the base64 encoded HMAC-SHA256 of the payload, " +
+			"keyed with the webhook secret. A signature that is not valid base64 does not match.",
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:                "payload",
+				MarkdownDescription: "The webhook request body, exactly as received.",
+			},
+			function.StringParameter{
+				Name:                "signature",
+				MarkdownDescription: "The signature sent with the webhook.",
+			},
+			function.StringParameter{
+				Name:                "secret",
+				MarkdownDescription: "The webhook secret.",
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+func (f *VerifyWebhookFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var payload, signature, secret string
+
+	resp.Error = req.Arguments.Get(ctx, &payload, &signature, &secret)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, VerifyWebhookSignature([]byte(payload), signature, secret))
+}
+
+// VerifyWebhookSignature reports whether signature is the base64 encoded
+// HMAC-SHA256 of the payload keyed with secret.
+func VerifyWebhookSignature(payload []byte, signature, secret string) bool {
+	want, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(signatureHashes[SignatureAlgorithmSHA256], []byte(secret))
+	mac.Write(payload)
+
+	return hmac.Equal(mac.Sum(nil), want)
+}
@@ -0,0 +1,52 @@
+package provider_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyWebhookFunction(t *testing.T) {
+	const (
+		payload   = `{"event":"finding.created"}`
+		signature = "Lfrr9hsgcnvmJLZJIZCN1DGuXcycfmyhU4FhQzXIz84="
+		secret    = "webhook-secret"
+	)
+
+	tests := map[string]struct {
+		payload   string
+		signature string
+		secret    string
+		want      bool
+	}{
+		"valid":            {payload, signature, secret, true},
+		"tampered payload": {`{"event":"finding.deleted"}`, signature, secret, false},
+		"wrong secret":     {payload, signature, "other-secret", false},
+		"invalid base64":   {payload, "not base64!", secret, false},
+		"empty signature":  {payload, "", secret, false},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := &function.RunResponse{
+				Result: function.NewResultData(types.BoolUnknown()),
+			}
+
+			provider.NewVerifyWebhookFunction().Run(context.Background(), function.RunRequest{
+				Arguments: function.NewArgumentsData([]attr.Value{
+					types.StringValue(tt.payload),
+					types.StringValue(tt.signature),
+					types.StringValue(tt.secret),
+				}),
+			}, resp)
+
+			require.Nil(t, resp.Error)
+			require.Equal(t, types.BoolValue(tt.want), resp.Result.Value())
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/jsvensson/terraform-provider-detectify/internal/provider"
@@ -30,7 +31,16 @@ func main() {
 		Debug:   debug,
 	}
 
-	if err := providerserver.Serve(context.Background(), provider.New(version), opts); err != nil {
+	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+
+	// Stop serving metrics once Terraform is done with the provider.
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if shutdownErr := provider.ShutdownMetricsServers(shutdownCtx); shutdownErr != nil {
+		log.Printf("stopping metrics servers: %s", shutdownErr)
+	}
+	cancel()
+
+	if err != nil {
 		log.Fatal(err.Error())
 	}
 }